@@ -3,15 +3,32 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"maps"
 	"net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/NicolasNSC/catalog-service-fiap/internal/client"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/event"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/exchange"
 	handler "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/reconcile"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/storage"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/worker"
 	"github.com/go-chi/chi"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
@@ -24,24 +41,134 @@ import (
 // @contact.name   API Support
 // @contact.email  dev@suaempresa.com
 
+// defaultShutdownTimeout bounds how long a shutdown waits for the requests
+// in flight and the background imports.
+const defaultShutdownTimeout = 10 * time.Second
+
 // @host      localhost:8080
 // @BasePath  /
 func main() {
 	loadConfig()
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcile(ctx, os.Args[2:])
+		return
+	}
+
 	db := setupDatabase()
 	defer db.Close()
 
-	showcaseURL := os.Getenv("SHOWCASE_SERVICE_URL")
-	showcaseClient := client.NewShowcaseClient(showcaseURL)
+	showcaseClient := setupShowcaseClient()
+	rates := setupExchangeRates(ctx)
+	blobs := setupBlobStore()
 
 	repo := repository.NewPostgresVehicleRepository(db)
-	useCase := usecase.NewVehicleUseCase(repo, showcaseClient)
+	outboxRepo := repository.NewPostgresOutboxRepository(db)
+	webhookRepo := repository.NewPostgresWebhookRepository(db)
+	publishers := setupEventPublishers(showcaseClient, rates, webhookRepo)
+	outboxPublisher := setupOutboxPublisher(outboxRepo, publishers)
+	transactor := repository.NewPostgresTransactor(db)
+	historyRepo := repository.NewPostgresVehicleHistoryRepository(db)
+	priceRepo := repository.NewPostgresVehiclePriceRepository(db)
+	catalogUseCase := usecase.NewCatalogUseCase(repository.NewPostgresCatalogRepository(db), usecase.CatalogConfig{
+		AutoCreate: boolFromEnv("CATALOG_AUTO_CREATE"),
+	})
+	imageUseCase := usecase.NewVehicleImageUseCase(repo, repository.NewPostgresVehicleImageRepository(db), blobs, transactor, outboxPublisher, usecase.VehicleImageConfig{
+		MaxSize:        int64(intFromEnv("IMAGE_MAX_SIZE")),
+		MaxImages:      intFromEnv("IMAGE_MAX_COUNT"),
+		ThumbnailWidth: intFromEnv("IMAGE_THUMBNAIL_WIDTH"),
+		MaxPixels:      intFromEnv("IMAGE_MAX_PIXELS"),
+	})
+	useCase := usecase.NewVehicleUseCase(repo, historyRepo, priceRepo, transactor, outboxPublisher, rates, catalogUseCase, imageUseCase)
 	vehicleHandler := handler.NewVehicleHandler(useCase)
+	webhookHandler := handler.NewWebhookHandler(usecase.NewWebhookUseCase(webhookRepo))
+	catalogHandler := handler.NewCatalogHandler(catalogUseCase)
+	imageHandler := handler.NewVehicleImageHandler(imageUseCase)
+	importUseCase := setupVehicleImport(ctx, repository.NewPostgresVehicleImportJobRepository(db), transactor, useCase)
+	importHandler := handler.NewVehicleImportHandler(importUseCase)
+
+	var workers sync.WaitGroup
+	dispatcher := setupOutboxDispatcher(outboxRepo, transactor, publishers)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		dispatcher.Run(ctx)
+	}()
+
+	webhookWorker := setupWebhookDeliveryWorker(webhookRepo, transactor)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		webhookWorker.Run(ctx)
+	}()
+
+	router := setupRouter(vehicleHandler, webhookHandler, catalogHandler, imageHandler, importHandler, blobs)
+
+	server := startServer(router)
+	<-ctx.Done()
+	log.Printf("Info: shutting down")
+
+	timeout := durationFromEnv("SHUTDOWN_TIMEOUT")
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: could not finish the requests in flight: %v", err)
+	}
+	workers.Wait()
+	if err := importUseCase.Wait(shutdownCtx); err != nil {
+		log.Printf("Warning: import jobs were still running at shutdown: %v", err)
+	}
+}
+
+// runReconcile implements the "reconcile" subcommand: it compares the catalog
+// with the showcase listings, repairs the differences unless -dry-run is set,
+// and prints the JSON report. It exits with status 1 when a repair fails.
+func runReconcile(ctx context.Context, args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "report the differences without repairing them")
+	pageSize := flags.Int("page-size", 0, "vehicles and listings read per request (default 100)")
+	gracePeriod := flags.Duration("grace-period", 0, "skip vehicles changed more recently than this (default 1m)")
+	flags.Parse(args)
+
+	db := setupDatabase()
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+	showcaseClient := setupShowcaseClient()
+	rates := setupExchangeRates(ctx)
+	// Only the image listing of the use case is used, to send the same
+	// pictures as the events do.
+	publishers := setupEventPublishers(showcaseClient, rates, repository.NewPostgresWebhookRepository(db))
+	outboxPublisher := setupOutboxPublisher(repository.NewPostgresOutboxRepository(db), publishers)
+	images := usecase.NewVehicleImageUseCase(repo, repository.NewPostgresVehicleImageRepository(db), setupBlobStore(), repository.NewPostgresTransactor(db), outboxPublisher, usecase.VehicleImageConfig{})
 
-	router := setupRouter(vehicleHandler)
+	reconciler := reconcile.NewReconciler(repo, showcaseClient, rates, images, reconcile.Config{
+		PageSize:    *pageSize,
+		GracePeriod: *gracePeriod,
+		DryRun:      *dryRun,
+		Currencies:  listFromEnv("SHOWCASE_CURRENCIES"),
+	})
 
-	startServer(router)
+	report, err := reconciler.Run(ctx)
+	if err != nil {
+		log.Fatalf("Fatal: reconciliation failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Fatal: could not write reconciliation report: %v", err)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
 }
 
 func loadConfig() {
@@ -69,16 +196,195 @@ func setupDatabase() *sql.DB {
 	return db
 }
 
-func setupRouter(vehicleHandler *handler.VehicleHandler) *chi.Mux {
+func setupShowcaseClient() client.ShowcaseClientInterface {
+	config := client.ShowcaseClientConfig{
+		Timeout:          durationFromEnv("SHOWCASE_TIMEOUT"),
+		MaxRetries:       intFromEnv("SHOWCASE_MAX_RETRIES"),
+		BaseBackoff:      durationFromEnv("SHOWCASE_BASE_BACKOFF"),
+		MaxBackoff:       durationFromEnv("SHOWCASE_MAX_BACKOFF"),
+		BreakerThreshold: intFromEnv("SHOWCASE_BREAKER_THRESHOLD"),
+		BreakerCooldown:  durationFromEnv("SHOWCASE_BREAKER_COOLDOWN"),
+	}
+	return client.NewShowcaseClient(os.Getenv("SHOWCASE_SERVICE_URL"), config)
+}
+
+// setupExchangeRates loads the exchange rates from EXCHANGE_RATES_FILE and
+// keeps them in sync with the file until ctx is cancelled. Without a file,
+// prices can only be converted to their own currency.
+func setupExchangeRates(ctx context.Context) usecase.ExchangeRateProvider {
+	path := os.Getenv("EXCHANGE_RATES_FILE")
+	if path == "" {
+		return exchange.NewStaticRateProvider(exchange.RateTable{Base: domain.DefaultCurrency})
+	}
+
+	provider, err := exchange.NewFileRateProvider(path, exchange.FileRateProviderConfig{
+		ReloadInterval: durationFromEnv("EXCHANGE_RATES_RELOAD_INTERVAL"),
+	})
+	if err != nil {
+		log.Fatalf("Fatal: could not load exchange rates: %v", err)
+	}
+	go provider.Run(ctx)
+	return provider
+}
+
+// setupBlobStore keeps vehicle images under MEDIA_DIR (default "media"),
+// served under /media. MEDIA_BASE_URL is the public address of that path, as
+// the showcase needs absolute image URLs.
+func setupBlobStore() *storage.LocalBlobStore {
+	dir := os.Getenv("MEDIA_DIR")
+	if dir == "" {
+		dir = "media"
+	}
+	baseURL := os.Getenv("MEDIA_BASE_URL")
+	if baseURL == "" {
+		baseURL = "/media"
+	}
+
+	store, err := storage.NewLocalBlobStore(dir, baseURL)
+	if err != nil {
+		log.Fatalf("Fatal: could not open media directory: %v", err)
+	}
+	return store
+}
+
+// setupEventPublishers builds the downstream publishers used by the outbox
+// dispatcher, keyed by outbox target: the showcase service, the deliveries of
+// the registered webhook subscriptions and any webhook listed in
+// EVENT_WEBHOOK_URLS (comma-separated). Listings also carry their price in
+// each currency of SHOWCASE_CURRENCIES.
+func setupEventPublishers(showcaseClient client.ShowcaseClientInterface, rates usecase.ExchangeRateProvider, webhookRepo repository.WebhookRepository) map[string]usecase.EventPublisher {
+	publishers := map[string]usecase.EventPublisher{
+		event.TargetShowcase:      event.NewShowcasePublisher(showcaseClient, rates, listFromEnv("SHOWCASE_CURRENCIES")),
+		event.TargetSubscriptions: event.NewSubscriptionPublisher(webhookRepo),
+	}
+	for _, url := range listFromEnv("EVENT_WEBHOOK_URLS") {
+		publishers[event.WebhookTarget(url)] = event.NewWebhookPublisher(url, durationFromEnv("EVENT_WEBHOOK_TIMEOUT"))
+	}
+	return publishers
+}
+
+// setupOutboxPublisher records every event in the outbox once for each of
+// the targets of publishers.
+func setupOutboxPublisher(outboxRepo repository.OutboxRepository, publishers map[string]usecase.EventPublisher) usecase.EventPublisher {
+	return event.NewOutboxPublisher(outboxRepo, slices.Sorted(maps.Keys(publishers)))
+}
+
+func setupOutboxDispatcher(outboxRepo repository.OutboxRepository, transactor repository.Transactor, publishers map[string]usecase.EventPublisher) *worker.OutboxDispatcher {
+	config := worker.OutboxDispatcherConfig{
+		PollInterval:  durationFromEnv("OUTBOX_POLL_INTERVAL"),
+		BatchSize:     intFromEnv("OUTBOX_BATCH_SIZE"),
+		BaseBackoff:   durationFromEnv("OUTBOX_BASE_BACKOFF"),
+		MaxBackoff:    durationFromEnv("OUTBOX_MAX_BACKOFF"),
+		LeaseDuration: durationFromEnv("OUTBOX_LEASE_DURATION"),
+		MaxAttempts:   intFromEnv("OUTBOX_MAX_ATTEMPTS"),
+	}
+	return worker.NewOutboxDispatcher(outboxRepo, transactor, publishers, config)
+}
+
+// setupVehicleImport builds the vehicle import use case. Background imports
+// do not survive a restart, so the jobs a stopped process left running are
+// marked as failed first.
+func setupVehicleImport(ctx context.Context, jobs repository.VehicleImportJobRepository, transactor repository.Transactor, vehicles usecase.VehicleCreator) usecase.VehicleImportUseCaseInterface {
+	useCase := usecase.NewVehicleImportUseCase(vehicles, jobs, transactor, usecase.VehicleImportConfig{
+		MaxSize:        int64(intFromEnv("IMPORT_MAX_SIZE")),
+		MaxRows:        intFromEnv("IMPORT_MAX_ROWS"),
+		BatchSize:      intFromEnv("IMPORT_BATCH_SIZE"),
+		AsyncThreshold: intFromEnv("IMPORT_ASYNC_THRESHOLD"),
+		StaleAfter:     durationFromEnv("IMPORT_STALE_AFTER"),
+	})
+
+	interrupted, err := useCase.FailStaleJobs(ctx)
+	if err != nil {
+		log.Printf("Warning: could not fail the interrupted import jobs: %v", err)
+	} else if interrupted > 0 {
+		log.Printf("Warning: %d import jobs were interrupted by a shutdown", interrupted)
+	}
+
+	return useCase
+}
+
+func setupWebhookDeliveryWorker(webhookRepo repository.WebhookRepository, transactor repository.Transactor) *worker.WebhookDeliveryWorker {
+	config := worker.WebhookDeliveryWorkerConfig{
+		PollInterval:  durationFromEnv("WEBHOOK_POLL_INTERVAL"),
+		BatchSize:     intFromEnv("WEBHOOK_BATCH_SIZE"),
+		Timeout:       durationFromEnv("WEBHOOK_TIMEOUT"),
+		BaseBackoff:   durationFromEnv("WEBHOOK_BASE_BACKOFF"),
+		MaxBackoff:    durationFromEnv("WEBHOOK_MAX_BACKOFF"),
+		MaxAttempts:   intFromEnv("WEBHOOK_MAX_ATTEMPTS"),
+		LeaseDuration: durationFromEnv("WEBHOOK_LEASE_DURATION"),
+	}
+	return worker.NewWebhookDeliveryWorker(webhookRepo, transactor, config)
+}
+
+// durationFromEnv returns zero when the variable is unset so the component's
+// default applies.
+func durationFromEnv(key string) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Fatal: invalid duration in %s: %v", key, err)
+	}
+	return d
+}
+
+// listFromEnv splits a comma-separated variable, skipping blank items.
+func listFromEnv(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func intFromEnv(key string) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Fatal: invalid integer in %s: %v", key, err)
+	}
+	return n
+}
+
+func boolFromEnv(key string) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Fatal: invalid boolean in %s: %v", key, err)
+	}
+	return b
+}
+
+func setupRouter(vehicleHandler *handler.VehicleHandler, webhookHandler *handler.WebhookHandler, catalogHandler *handler.CatalogHandler, imageHandler *handler.VehicleImageHandler, importHandler *handler.VehicleImportHandler, blobs *storage.LocalBlobStore) *chi.Mux {
 	r := chi.NewRouter()
-	handler.SetupRoutes(r, vehicleHandler)
+	handler.SetupRoutes(r, vehicleHandler, webhookHandler, catalogHandler, imageHandler, importHandler)
+	r.Handle("/media/*", http.StripPrefix("/media", blobs.Handler()))
 	return r
 }
 
-func startServer(router *chi.Mux) {
+// startServer serves router on API_PORT in the background. The server is
+// returned so it can be shut down.
+func startServer(router *chi.Mux) *http.Server {
 	apiPort := os.Getenv("API_PORT")
-	log.Printf("Info: server starting on port %s", apiPort)
-	if err := http.ListenAndServe(":"+apiPort, router); err != nil {
-		log.Fatalf("Fatal: could not start server: %v", err)
-	}
+	server := &http.Server{Addr: ":" + apiPort, Handler: router}
+
+	go func() {
+		log.Printf("Info: server starting on port %s", apiPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Fatal: could not start server: %v", err)
+		}
+	}()
+
+	return server
 }
@@ -4,7 +4,156 @@ CREATE TABLE IF NOT EXISTS vehicles (
     model VARCHAR(100) NOT NULL,
     year INT NOT NULL,
     color VARCHAR(50),
-    price NUMERIC(10, 2) NOT NULL,
+    price NUMERIC(19, 2) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'BRL',
+    vin VARCHAR(17),
+    plate VARCHAR(7),
+    renavam VARCHAR(11),
+    mileage INT NOT NULL DEFAULT 0 CHECK (mileage >= 0),
+    fuel_type VARCHAR(20) CHECK (fuel_type IN ('GASOLINE', 'ETHANOL', 'FLEX', 'DIESEL', 'HYBRID', 'ELECTRIC')),
+    transmission VARCHAR(20) CHECK (transmission IN ('MANUAL', 'AUTOMATIC', 'AUTOMATED', 'CVT')),
+    body_type VARCHAR(20) CHECK (body_type IN ('HATCHBACK', 'SEDAN', 'SUV', 'PICKUP', 'COUPE', 'CONVERTIBLE', 'WAGON', 'MINIVAN', 'VAN')),
+    doors SMALLINT,
+    engine_displacement INT,
+    condition VARCHAR(10) CHECK (condition IN ('NEW', 'USED')),
+    status VARCHAR(20) NOT NULL DEFAULT 'AVAILABLE' CHECK (status IN ('AVAILABLE', 'RESERVED', 'SOLD')),
+    version INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vehicles_vin ON vehicles (vin) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vehicles_plate ON vehicles (plate) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vehicles_renavam ON vehicles (renavam) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    target TEXT NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    claimed_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (next_attempt_at) WHERE sent_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_aggregate_pending ON outbox (aggregate_id, target, created_at) WHERE sent_at IS NULL AND failed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    url TEXT NOT NULL,
+    event_types JSONB NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    url TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    response_status INT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    claimed_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    redelivered_from VARCHAR(36)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id) WHERE redelivered_from IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, created_at);
+
+CREATE TABLE IF NOT EXISTS vehicle_history (
+    id VARCHAR(36) PRIMARY KEY,
+    vehicle_id VARCHAR(36) NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('CREATE', 'UPDATE', 'DELETE')),
+    before JSONB,
+    after JSONB,
+    actor VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_history_vehicle ON vehicle_history (vehicle_id, changed_at DESC);
+
+CREATE TABLE IF NOT EXISTS vehicle_price_history (
+    id VARCHAR(36) PRIMARY KEY,
+    vehicle_id VARCHAR(36) NOT NULL,
+    previous_price NUMERIC(19, 2) NOT NULL,
+    previous_currency CHAR(3) NOT NULL,
+    price NUMERIC(19, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_price_history_vehicle ON vehicle_price_history (vehicle_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS brands (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    name_key VARCHAR(100) NOT NULL,
+    aliases JSONB NOT NULL DEFAULT '[]',
+    alias_keys JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_brands_name_key ON brands (name_key);
+CREATE INDEX IF NOT EXISTS idx_brands_alias_keys ON brands USING GIN (alias_keys);
+
+CREATE TABLE IF NOT EXISTS models (
+    id VARCHAR(36) PRIMARY KEY,
+    brand_id VARCHAR(36) NOT NULL REFERENCES brands (id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    name_key VARCHAR(100) NOT NULL,
+    aliases JSONB NOT NULL DEFAULT '[]',
+    alias_keys JSONB NOT NULL DEFAULT '[]',
     created_at TIMESTAMPTZ NOT NULL,
     updated_at TIMESTAMPTZ NOT NULL
-);
\ No newline at end of file
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_models_name_key ON models (brand_id, name_key);
+CREATE INDEX IF NOT EXISTS idx_models_alias_keys ON models USING GIN (alias_keys);
+
+CREATE TABLE IF NOT EXISTS vehicle_images (
+    id VARCHAR(36) PRIMARY KEY,
+    vehicle_id VARCHAR(36) NOT NULL,
+    blob_key VARCHAR(255) NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    is_cover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_images_vehicle ON vehicle_images (vehicle_id, position);
+
+CREATE TABLE IF NOT EXISTS vehicle_import_jobs (
+    id VARCHAR(36) PRIMARY KEY,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    total INT NOT NULL,
+    processed INT NOT NULL DEFAULT 0,
+    succeeded INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    results JSONB NOT NULL DEFAULT '[]',
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ
+);
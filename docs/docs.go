@@ -18,9 +18,33 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/vehicles/add": {
+        "/brands": {
+            "get": {
+                "description": "Returns every brand of the catalog, sorted by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "List brands",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListBrandsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Adds a new vehicle to the catalog.",
+                "description": "Adds a canonical brand to the catalog. Vehicles typed with the name or any alias, in any case, are stored with the name.",
                 "consumes": [
                     "application/json"
                 ],
@@ -28,17 +52,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicles"
+                    "Catalog"
                 ],
-                "summary": "Create a new vehicle",
+                "summary": "Create a brand",
                 "parameters": [
                     {
-                        "description": "Vehicle data to create",
-                        "name": "vehicle",
+                        "description": "Brand data",
+                        "name": "brand",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.InputCreateVehicleDTO"
+                            "$ref": "#/definitions/dto.InputBrandDTO"
                         }
                     }
                 ],
@@ -46,27 +70,78 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.OutputCreateVehicleDTO"
+                            "$ref": "#/definitions/dto.OutputBrandDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or brand data",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or alias already used by another brand",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
                         }
                     }
                 }
             }
         },
-        "/vehicles/{id}": {
+        "/brands/{id}": {
+            "get": {
+                "description": "Returns a brand of the catalog by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get a brand",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputBrandDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
             "put": {
-                "description": "Updates the data of a vehicle by its ID.",
+                "description": "Replaces the name and aliases of a brand. Vehicles already stored keep the brand they were saved with.",
                 "consumes": [
                     "application/json"
                 ],
@@ -74,24 +149,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicles"
+                    "Catalog"
                 ],
-                "summary": "Update an existing vehicle",
+                "summary": "Update a brand",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vehicle ID",
+                        "description": "Brand ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Vehicle data to update",
-                        "name": "vehicle",
+                        "description": "Brand data",
+                        "name": "brand",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.InputUpdateVehicleDTO"
+                            "$ref": "#/definitions/dto.InputBrandDTO"
                         }
                     }
                 ],
@@ -99,79 +174,2873 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.OutputBrandDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or ID",
+                        "description": "Invalid request body, ID or brand data",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
                         }
                     },
                     "404": {
-                        "description": "Vehicle not found",
+                        "description": "Brand not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or alias already used by another brand",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "dto.InputCreateVehicleDTO": {
-            "type": "object",
-            "properties": {
-                "brand": {
-                    "type": "string"
-                },
-                "color": {
-                    "type": "string"
-                },
-                "model": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "year": {
-                    "type": "integer"
+            },
+            "delete": {
+                "description": "Removes a brand and its models from the catalog. Vehicles already stored are not changed.",
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Delete a brand",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
                 }
             }
         },
-        "dto.InputUpdateVehicleDTO": {
-            "type": "object",
-            "properties": {
-                "brand": {
-                    "type": "string"
-                },
-                "color": {
-                    "type": "string"
-                },
-                "model": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "year": {
-                    "type": "integer"
+        "/brands/{id}/models": {
+            "get": {
+                "description": "Returns every model of a brand, sorted by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "List models",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListModelsDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a canonical model to a brand of the catalog.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Create a model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Model data",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputModelDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputModelDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, ID or model data",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or alias already used by another model of the brand",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
                 }
             }
         },
-        "dto.OutputCreateVehicleDTO": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+        "/brands/{id}/models/{modelId}": {
+            "get": {
+                "description": "Returns a model of a brand by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get a model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputModelDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Model not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the name and aliases of a model. Vehicles already stored keep the model they were saved with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Update a model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Model data",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputModelDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputModelDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, ID or model data",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Model not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or alias already used by another model of the brand",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a model from a brand of the catalog. Vehicles already stored are not changed.",
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Delete a model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model ID",
+                        "name": "modelId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Model not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles": {
+            "get": {
+                "description": "Returns a page of the vehicle catalog matching the given filters, along with the total number of matches.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "List vehicles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by brand (case-insensitive)",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by model (case-insensitive)",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by color (case-insensitive)",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum manufacturing year",
+                        "name": "year_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum manufacturing year",
+                        "name": "year_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum mileage in km",
+                        "name": "mileage_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum mileage in km",
+                        "name": "mileage_max",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "GASOLINE",
+                            "ETHANOL",
+                            "FLEX",
+                            "DIESEL",
+                            "HYBRID",
+                            "ELECTRIC"
+                        ],
+                        "type": "string",
+                        "description": "Filter by fuel type",
+                        "name": "fuel_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MANUAL",
+                            "AUTOMATIC",
+                            "AUTOMATED",
+                            "CVT"
+                        ],
+                        "type": "string",
+                        "description": "Filter by transmission",
+                        "name": "transmission",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "HATCHBACK",
+                            "SEDAN",
+                            "SUV",
+                            "PICKUP",
+                            "COUPE",
+                            "CONVERTIBLE",
+                            "WAGON",
+                            "MINIVAN",
+                            "VAN"
+                        ],
+                        "type": "string",
+                        "description": "Filter by body type",
+                        "name": "body_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by number of doors",
+                        "name": "doors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum engine displacement in cc",
+                        "name": "engine_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum engine displacement in cc",
+                        "name": "engine_max",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "NEW",
+                            "USED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by condition",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Also return the prices converted to this currency; required by price_min, price_max and sort=price, which only match vehicles priced in it",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price",
+                            "year",
+                            "mileage",
+                            "created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (default created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of vehicles to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of vehicles to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListVehiclesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort, pagination or currency parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/add": {
+            "post": {
+                "description": "Adds a new vehicle to the catalog. Brand and model are stored with their canonical names from the brand and model catalog.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Create a new vehicle",
+                "parameters": [
+                    {
+                        "description": "Vehicle data to create",
+                        "name": "vehicle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputCreateVehicleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputCreateVehicleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or vehicle data, a brand or year contradicting the VIN, or a brand or model not in the catalog",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "VIN, plate or RENAVAM already registered to the vehicle in conflicting_id",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/import": {
+            "post": {
+                "description": "Creates vehicles from a CSV file, with a header row naming the fields of POST /vehicles/add, or from a JSONL file with one such object per line. Each row is validated like a single vehicle and the report lists the outcome of every row. With dry_run the rows are only validated. Files with more rows than the async threshold, or any file with async, are imported in the background and return a job to poll.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Import vehicles",
+                "parameters": [
+                    {
+                        "description": "CSV or JSONL file",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the rows without creating the vehicles",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Import in the background regardless of the file size",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputImportReportDTO"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputImportJobDTO"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the import job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters, or a file that is empty, too large or unreadable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Content-Type is not CSV or JSONL",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/import/{jobId}": {
+            "get": {
+                "description": "Returns the progress of a background import, with the outcome of the rows processed so far.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get an import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputImportJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/price-stats": {
+            "get": {
+                "description": "Reports, for each brand and model of the catalog, the average days on market and the average discount from the first asking price. Days on market run until the sale, or until now for vehicles still on sale.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get price statistics by brand and model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by brand (case-insensitive)",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by model (case-insensitive)",
+                        "name": "model",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListPriceStatsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}": {
+            "get": {
+                "description": "Returns a vehicle of the catalog by its ID, along with its images.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "USD",
+                        "description": "Also return the price converted to this currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the vehicle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or unsupported currency",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates the data of a vehicle by its ID. A changed brand or model is stored with its canonical name from the catalog. Without currency, the price keeps the currency of the vehicle.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Update an existing vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vehicle version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Vehicle data to update",
+                        "name": "vehicle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputUpdateVehicleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated vehicle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, ID or vehicle data, or a brand or model not in the catalog",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Vehicle was modified concurrently, or an identifier is registered to the vehicle in conflicting_id",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-deletes a vehicle by its ID and removes its listing from the showcase.",
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Delete a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies a JSON Merge Patch (RFC 7396) to a vehicle. Absent fields are left unchanged.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Partially update a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vehicle version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Vehicle fields to change",
+                        "name": "vehicle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputPatchVehicleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleDTO"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated vehicle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, ID or vehicle data, or a brand or model not in the catalog",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Vehicle was modified concurrently, or an identifier is registered to the vehicle in conflicting_id",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match does not match the current version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported media type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/history": {
+            "get": {
+                "description": "Returns a page of the audit trail of a vehicle, newest first: every creation, update and deletion with the vehicle before and after the change, who made it and when. The history of deleted vehicles remains available.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get the change history of a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListVehicleHistoryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/images": {
+            "get": {
+                "description": "Returns the images of a vehicle in display order, with the URLs of each image and its thumbnail.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle Images"
+                ],
+                "summary": "List vehicle images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListVehicleImagesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a JPEG or PNG picture to the end of the images of a vehicle and generates its thumbnail. The first image of a vehicle becomes its cover.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle Images"
+                ],
+                "summary": "Upload a vehicle image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "JPEG or PNG image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleImageDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, missing file, unsupported image, image over the size or pixel limit, or too many images",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/images/order": {
+            "put": {
+                "description": "Sets the display order of the images of a vehicle. image_ids must list every image of the vehicle exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle Images"
+                ],
+                "summary": "Reorder vehicle images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image IDs in the new order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputReorderVehicleImagesDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListVehicleImagesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or ID, or image IDs not matching the images of the vehicle",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/images/{imageId}": {
+            "delete": {
+                "description": "Removes an image and its thumbnail. The images after it move up one position, and when the cover is removed the first remaining image takes its place.",
+                "tags": [
+                    "Vehicle Images"
+                ],
+                "summary": "Delete a vehicle image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle or image not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/images/{imageId}/cover": {
+            "post": {
+                "description": "Makes an image the cover of its vehicle, in place of the current one. The display order is not changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle Images"
+                ],
+                "summary": "Set the vehicle cover image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "imageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListVehicleImagesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle or image not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/prices": {
+            "get": {
+                "description": "Returns the asking prices of a vehicle, oldest first, with when each one took effect, who set it and how many days the vehicle stayed at it. The last period is the current price.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get the price timeline of a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehiclePricesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/release": {
+            "post": {
+                "description": "Moves a reserved vehicle back to the AVAILABLE status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Release a vehicle reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/reserve": {
+            "post": {
+                "description": "Moves an available vehicle to the RESERVED status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Reserve a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicles/{id}/sell": {
+            "post": {
+                "description": "Moves an available or reserved vehicle to the SOLD status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Sell a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputVehicleDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid status transition",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Returns every webhook subscription. Secrets are not included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListWebhooksDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a URL to receive catalog events. Every delivery is signed with an HMAC-SHA256 of the body in the X-Signature header; a secret is generated when none is given and is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Subscribe a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook subscription",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InputCreateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputCreateWebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or subscription data",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Returns a webhook subscription by its ID. The secret is not included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputWebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a webhook subscription along with its delivery log. Pending deliveries are discarded.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Returns a page of the delivery log of a webhook, newest first, along with the total number of entries.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "SUCCEEDED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Filter by delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputListWebhookDeliveriesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, status or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "description": "Queues a new delivery of the same event to the current URL of the webhook. The original entry of the log is kept unchanged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutputWebhookDeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDetailsDTO"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.ConvertedPriceDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "14800.00"
+                }
+            }
+        },
+        "dto.FieldErrorDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportRowResultDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldErrorDTO"
+                    }
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "CREATED",
+                        "VALID",
+                        "FAILED"
+                    ],
+                    "example": "CREATED"
+                },
+                "vehicle_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InputBrandDTO": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "VW"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Volkswagen"
+                }
+            }
+        },
+        "dto.InputCreateVehicleDTO": {
+            "type": "object",
+            "properties": {
+                "body_type": {
+                    "type": "string",
+                    "enum": [
+                        "HATCHBACK",
+                        "SEDAN",
+                        "SUV",
+                        "PICKUP",
+                        "COUPE",
+                        "CONVERTIBLE",
+                        "WAGON",
+                        "MINIVAN",
+                        "VAN"
+                    ],
+                    "example": "HATCHBACK"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "NEW",
+                        "USED"
+                    ],
+                    "example": "USED"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "doors": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "engine_displacement": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "fuel_type": {
+                    "type": "string",
+                    "enum": [
+                        "GASOLINE",
+                        "ETHANOL",
+                        "FLEX",
+                        "DIESEL",
+                        "HYBRID",
+                        "ELECTRIC"
+                    ],
+                    "example": "FLEX"
+                },
+                "mileage": {
+                    "type": "integer",
+                    "example": 42000
+                },
+                "model": {
+                    "type": "string"
+                },
+                "override_vin": {
+                    "description": "OverrideVIN accepts a brand and year that contradict the VIN.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "plate": {
+                    "type": "string",
+                    "example": "BRA2E19"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "80000.00"
+                },
+                "renavam": {
+                    "type": "string",
+                    "example": "00639884962"
+                },
+                "transmission": {
+                    "type": "string",
+                    "enum": [
+                        "MANUAL",
+                        "AUTOMATIC",
+                        "AUTOMATED",
+                        "CVT"
+                    ],
+                    "example": "MANUAL"
+                },
+                "vin": {
+                    "type": "string",
+                    "example": "9BWZZZ377VT004251"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InputCreateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InputModelDTO": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gol"
+                }
+            }
+        },
+        "dto.InputPatchVehicleDTO": {
+            "type": "object",
+            "properties": {
+                "body_type": {
+                    "type": "string",
+                    "enum": [
+                        "HATCHBACK",
+                        "SEDAN",
+                        "SUV",
+                        "PICKUP",
+                        "COUPE",
+                        "CONVERTIBLE",
+                        "WAGON",
+                        "MINIVAN",
+                        "VAN"
+                    ],
+                    "example": "HATCHBACK"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "NEW",
+                        "USED"
+                    ],
+                    "example": "USED"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "doors": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "engine_displacement": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "fuel_type": {
+                    "type": "string",
+                    "enum": [
+                        "GASOLINE",
+                        "ETHANOL",
+                        "FLEX",
+                        "DIESEL",
+                        "HYBRID",
+                        "ELECTRIC"
+                    ],
+                    "example": "FLEX"
+                },
+                "mileage": {
+                    "type": "integer",
+                    "example": 42000
+                },
+                "model": {
+                    "type": "string"
+                },
+                "plate": {
+                    "type": "string",
+                    "example": "BRA2E19"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "80000.00"
+                },
+                "renavam": {
+                    "type": "string",
+                    "example": "00639884962"
+                },
+                "transmission": {
+                    "type": "string",
+                    "enum": [
+                        "MANUAL",
+                        "AUTOMATIC",
+                        "AUTOMATED",
+                        "CVT"
+                    ],
+                    "example": "MANUAL"
+                },
+                "vin": {
+                    "type": "string",
+                    "example": "9BWZZZ377VT004251"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InputReorderVehicleImagesDTO": {
+            "type": "object",
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.InputUpdateVehicleDTO": {
+            "type": "object",
+            "properties": {
+                "body_type": {
+                    "type": "string",
+                    "enum": [
+                        "HATCHBACK",
+                        "SEDAN",
+                        "SUV",
+                        "PICKUP",
+                        "COUPE",
+                        "CONVERTIBLE",
+                        "WAGON",
+                        "MINIVAN",
+                        "VAN"
+                    ],
+                    "example": "HATCHBACK"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "NEW",
+                        "USED"
+                    ],
+                    "example": "USED"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "doors": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "engine_displacement": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "fuel_type": {
+                    "type": "string",
+                    "enum": [
+                        "GASOLINE",
+                        "ETHANOL",
+                        "FLEX",
+                        "DIESEL",
+                        "HYBRID",
+                        "ELECTRIC"
+                    ],
+                    "example": "FLEX"
+                },
+                "mileage": {
+                    "type": "integer",
+                    "example": 42000
+                },
+                "model": {
+                    "type": "string"
+                },
+                "plate": {
+                    "type": "string",
+                    "example": "BRA2E19"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "80000.00"
+                },
+                "renavam": {
+                    "type": "string",
+                    "example": "00639884962"
+                },
+                "transmission": {
+                    "type": "string",
+                    "enum": [
+                        "MANUAL",
+                        "AUTOMATIC",
+                        "AUTOMATED",
+                        "CVT"
+                    ],
+                    "example": "MANUAL"
+                },
+                "vin": {
+                    "type": "string",
+                    "example": "9BWZZZ377VT004251"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputBrandDTO": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputCreateVehicleDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputCreateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputImportJobDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowResultDTO"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "RUNNING",
+                        "COMPLETED",
+                        "FAILED"
+                    ],
+                    "example": "RUNNING"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputImportReportDTO": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowResultDTO"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputListBrandsDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputBrandDTO"
+                    }
+                }
+            }
+        },
+        "dto.OutputListModelsDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputModelDTO"
+                    }
+                }
+            }
+        },
+        "dto.OutputListPriceStatsDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputPriceStatsDTO"
+                    }
+                }
+            }
+        },
+        "dto.OutputListVehicleHistoryDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputVehicleHistoryDTO"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputListVehicleImagesDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputVehicleImageDTO"
+                    }
+                }
+            }
+        },
+        "dto.OutputListVehiclesDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputVehicleDTO"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputListWebhookDeliveriesDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputWebhookDeliveryDTO"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputListWebhooksDTO": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputWebhookDTO"
+                    }
+                }
+            }
+        },
+        "dto.OutputModelDTO": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "brand_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputPricePeriodDTO": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "days": {
+                    "type": "number"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "previous_price": {
+                    "type": "string",
+                    "example": "85000.00"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "80000.00"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputPriceStatsDTO": {
+            "type": "object",
+            "properties": {
+                "avg_days_on_market": {
+                    "type": "number"
+                },
+                "avg_discount": {
+                    "type": "string",
+                    "example": "2500.00"
+                },
+                "avg_discount_percent": {
+                    "type": "number"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "price_changes": {
+                    "type": "integer"
+                },
+                "sold": {
+                    "type": "integer"
+                },
+                "vehicles": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputVehicleDTO": {
+            "type": "object",
+            "properties": {
+                "body_type": {
+                    "type": "string",
+                    "example": "HATCHBACK"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string",
+                    "example": "USED"
+                },
+                "converted": {
+                    "$ref": "#/definitions/dto.ConvertedPriceDTO"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "doors": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "engine_displacement": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "fuel_type": {
+                    "type": "string",
+                    "example": "FLEX"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputVehicleImageDTO"
+                    }
+                },
+                "mileage": {
+                    "type": "integer",
+                    "example": 42000
+                },
+                "model": {
+                    "type": "string"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "80000.00"
+                },
+                "renavam": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transmission": {
+                    "type": "string",
+                    "example": "MANUAL"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "vin": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OutputVehicleHistoryDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputVehicleImageDTO": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "cover": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 524288
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1600
+                }
+            }
+        },
+        "dto.OutputVehiclePricesDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "current_price": {
+                    "type": "string",
+                    "example": "80000.00"
+                },
+                "initial_price": {
+                    "type": "string",
+                    "example": "85000.00"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OutputPricePeriodDTO"
+                    }
+                },
+                "vehicle_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OutputWebhookDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "redelivered_from": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProblemDetailsDTO": {
+            "type": "object",
+            "properties": {
+                "conflicting_id": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldErrorDTO"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
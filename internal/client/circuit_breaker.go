@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("showcase service circuit breaker is open")
+
+// circuitBreaker opens after threshold consecutive failed calls and rejects
+// calls until cooldown has elapsed. It then lets a single trial call through:
+// success closes the circuit again, failure keeps it open for another cooldown.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow reports whether a call may go through and whether it is the trial
+// call of an open circuit. The caller hands probe back to record or abandon.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	if b.threshold < 0 {
+		return false, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return false, nil
+	}
+	if b.probing || time.Now().Before(b.openUntil) {
+		return false, ErrCircuitOpen
+	}
+
+	b.probing = true
+	return true, nil
+}
+
+// record counts the outcome of a call. Only the trial call ends the trial, so
+// calls let through before the circuit opened cannot start a second one.
+func (b *circuitBreaker) record(probe, success bool) {
+	if b.threshold < 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// abandon ends a call without counting it, for calls given up by their caller,
+// which say nothing about the health of the showcase service. An abandoned
+// trial lets the next call try again.
+func (b *circuitBreaker) abandon(probe bool) {
+	if b.threshold < 0 || !probe {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
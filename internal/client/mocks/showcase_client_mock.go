@@ -55,6 +55,35 @@ func (mr *MockShowcaseClientInterfaceMockRecorder) CreateListing(ctx, data any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockShowcaseClientInterface)(nil).CreateListing), ctx, data)
 }
 
+// DeleteListing mocks base method.
+func (m *MockShowcaseClientInterface) DeleteListing(ctx context.Context, vehicleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteListing", ctx, vehicleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteListing indicates an expected call of DeleteListing.
+func (mr *MockShowcaseClientInterfaceMockRecorder) DeleteListing(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListing", reflect.TypeOf((*MockShowcaseClientInterface)(nil).DeleteListing), ctx, vehicleID)
+}
+
+// ListListings mocks base method.
+func (m *MockShowcaseClientInterface) ListListings(ctx context.Context, limit, offset int) (*dto.ListingPageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListListings", ctx, limit, offset)
+	ret0, _ := ret[0].(*dto.ListingPageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListListings indicates an expected call of ListListings.
+func (mr *MockShowcaseClientInterfaceMockRecorder) ListListings(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListListings", reflect.TypeOf((*MockShowcaseClientInterface)(nil).ListListings), ctx, limit, offset)
+}
+
 // UpdateListing mocks base method.
 func (m *MockShowcaseClientInterface) UpdateListing(ctx context.Context, vehicleID string, data dto.UpdateListingDTO) error {
 	m.ctrl.T.Helper()
@@ -68,3 +97,17 @@ func (mr *MockShowcaseClientInterfaceMockRecorder) UpdateListing(ctx, vehicleID,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateListing", reflect.TypeOf((*MockShowcaseClientInterface)(nil).UpdateListing), ctx, vehicleID, data)
 }
+
+// UpdateListingStatus mocks base method.
+func (m *MockShowcaseClientInterface) UpdateListingStatus(ctx context.Context, vehicleID string, data dto.UpdateListingStatusDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateListingStatus", ctx, vehicleID, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateListingStatus indicates an expected call of UpdateListingStatus.
+func (mr *MockShowcaseClientInterfaceMockRecorder) UpdateListingStatus(ctx, vehicleID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateListingStatus", reflect.TypeOf((*MockShowcaseClientInterface)(nil).UpdateListingStatus), ctx, vehicleID, data)
+}
@@ -6,78 +6,242 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/google/uuid"
 )
 
 //go:generate mockgen -source=showcase_client.go -destination=./mocks/showcase_client_mock.go -package=mocks
 type ShowcaseClientInterface interface {
 	CreateListing(ctx context.Context, data dto.CreateListingDTO) error
 	UpdateListing(ctx context.Context, vehicleID string, data dto.UpdateListingDTO) error
+	UpdateListingStatus(ctx context.Context, vehicleID string, data dto.UpdateListingStatusDTO) error
+	DeleteListing(ctx context.Context, vehicleID string) error
+	// ListListings returns one page of the showcase listings, ordered by
+	// vehicle ID.
+	ListListings(ctx context.Context, limit, offset int) (*dto.ListingPageDTO, error)
+}
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	defaultTimeout          = 5 * time.Second
+	defaultMaxRetries       = 3
+	defaultBaseBackoff      = 200 * time.Millisecond
+	defaultMaxBackoff       = 5 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// ShowcaseClientConfig tunes the resilience of the showcase client. Zero
+// values fall back to the defaults; a negative MaxRetries disables retries and
+// a negative BreakerThreshold disables the circuit breaker.
+type ShowcaseClientConfig struct {
+	Timeout          time.Duration
+	MaxRetries       int
+	BaseBackoff      time.Duration
+	MaxBackoff       time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey makes the showcase client send key as the Idempotency-Key
+// of requests issued with the returned context. Without it every call gets a
+// fresh key, shared only by the retries of that call.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
 }
 
 type httpShowcaseClient struct {
 	client  *http.Client
 	baseURL string
+	config  ShowcaseClientConfig
+	breaker *circuitBreaker
 }
 
-func NewShowcaseClient(baseURL string) ShowcaseClientInterface {
+func NewShowcaseClient(baseURL string, config ShowcaseClientConfig) ShowcaseClientInterface {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaultBaseBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.BreakerThreshold == 0 {
+		config.BreakerThreshold = defaultBreakerThreshold
+	}
+	if config.BreakerCooldown <= 0 {
+		config.BreakerCooldown = defaultBreakerCooldown
+	}
+
 	return &httpShowcaseClient{
 		client:  &http.Client{},
 		baseURL: baseURL,
+		config:  config,
+		breaker: newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
 	}
 }
 
 func (c *httpShowcaseClient) CreateListing(ctx context.Context, data dto.CreateListingDTO) error {
-	payload, err := json.Marshal(data)
+	return c.send(ctx, http.MethodPost, "/listings", data, nil)
+}
+
+func (c *httpShowcaseClient) UpdateListing(ctx context.Context, vehicleID string, data dto.UpdateListingDTO) error {
+	return c.send(ctx, http.MethodPut, fmt.Sprintf("/listings/vehicle/%s", vehicleID), data, nil)
+}
+
+func (c *httpShowcaseClient) UpdateListingStatus(ctx context.Context, vehicleID string, data dto.UpdateListingStatusDTO) error {
+	return c.send(ctx, http.MethodPatch, fmt.Sprintf("/listings/vehicle/%s/status", vehicleID), data, nil)
+}
+
+func (c *httpShowcaseClient) DeleteListing(ctx context.Context, vehicleID string) error {
+	return c.send(ctx, http.MethodDelete, fmt.Sprintf("/listings/vehicle/%s", vehicleID), nil, nil)
+}
+
+func (c *httpShowcaseClient) ListListings(ctx context.Context, limit, offset int) (*dto.ListingPageDTO, error) {
+	var page dto.ListingPageDTO
+	err := c.send(ctx, http.MethodGet, fmt.Sprintf("/listings?limit=%d&offset=%d", limit, offset), nil, &page)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &page, nil
+}
 
-	url := c.baseURL + "/listings"
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
-	if err != nil {
-		return err
+// retryableError marks failures worth another attempt: network errors and
+// 5xx responses.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// send issues the request, retrying retryable failures, and decodes a
+// successful response body into out when it is not nil.
+func (c *httpShowcaseClient) send(ctx context.Context, method, path string, data any, out any) error {
+	var payload []byte
+	if data != nil {
+		var err error
+		payload, err = json.Marshal(data)
+		if err != nil {
+			return err
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	if !ok || key == "" {
+		key = uuid.New().String()
+	}
+
+	probe, err := c.breaker.allow()
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return errors.New("showcase service returned non-success status: " + resp.Status)
+	for attempt := 0; ; attempt++ {
+		err = c.attempt(ctx, method, c.baseURL+path, payload, key, out)
+
+		var retryable *retryableError
+		if err == nil || !errors.As(err, &retryable) {
+			// A 4xx still proves the showcase service is reachable.
+			c.breaker.record(probe, true)
+			return err
+		}
+		if attempt >= c.config.MaxRetries || ctx.Err() != nil {
+			c.recordFailure(ctx, probe)
+			return retryable.err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.recordFailure(ctx, probe)
+			return ctx.Err()
+		case <-time.After(c.backoff(attempt)):
+		}
 	}
-
-	return nil
 }
 
-func (c *httpShowcaseClient) UpdateListing(ctx context.Context, vehicleID string, data dto.UpdateListingDTO) error {
-	payload, err := json.Marshal(data)
-	if err != nil {
-		return err
+// recordFailure counts a failed call against the circuit breaker, unless the
+// caller canceled it, in which case the failure is not the service's fault.
+func (c *httpShowcaseClient) recordFailure(ctx context.Context, probe bool) {
+	if ctx.Err() != nil {
+		c.breaker.abandon(probe)
+		return
 	}
+	c.breaker.record(probe, false)
+}
+
+func (c *httpShowcaseClient) attempt(ctx context.Context, method, url string, payload []byte, key string, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
 
-	url := fmt.Sprintf("%s/listings/vehicle/%s", c.baseURL, vehicleID)
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(idempotencyKeyHeader, key)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return err
+		return &retryableError{err: err}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return errors.New("showcase service returned non-success status: " + resp.Status)
+		err = errors.New("showcase service returned non-success status: " + resp.Status)
+		if resp.StatusCode >= 500 {
+			return &retryableError{err: err}
+		}
+		if rejected(resp.StatusCode) {
+			return fmt.Errorf("%w: %v", domain.ErrRejected, err)
+		}
+		return err
+	}
+
+	if out != nil {
+		err = json.NewDecoder(resp.Body).Decode(out)
+		if err != nil {
+			return fmt.Errorf("decoding showcase response: %w", err)
+		}
 	}
 
 	return nil
 }
+
+// rejected reports whether a 4xx status refuses the request for good. 408 and
+// 429 only ask the client to come back later.
+func rejected(status int) bool {
+	return status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
+// backoff doubles BaseBackoff for every previous attempt, capped at
+// MaxBackoff.
+func (c *httpShowcaseClient) backoff(attempt int) time.Duration {
+	delay := c.config.BaseBackoff
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if delay >= c.config.MaxBackoff {
+			return c.config.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -2,14 +2,28 @@ package client_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"path"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/NicolasNSC/catalog-service-fiap/internal/client"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
 )
 
+// newTestClient keeps retry delays short so failing calls finish quickly.
+func newTestClient(baseURL string) client.ShowcaseClientInterface {
+	return client.NewShowcaseClient(baseURL, client.ShowcaseClientConfig{
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	})
+}
+
 func TestCreateListing_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -18,16 +32,21 @@ func TestCreateListing_Success(t *testing.T) {
 		if r.URL.Path != "/listings" {
 			t.Errorf("expected path /listings, got %s", r.URL.Path)
 		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["price"] != "100000.00" || body["currency"] != "BRL" {
+			t.Errorf("expected the price as a string decimal with its currency, got %v (%v)", body, err)
+		}
 		w.WriteHeader(http.StatusCreated)
 	}))
 	defer server.Close()
-	showcaseClient := client.NewShowcaseClient(server.URL)
+	showcaseClient := newTestClient(server.URL)
 
 	data := dto.CreateListingDTO{
 		VehicleID: "123",
 		Brand:     "Toyota",
 		Model:     "Corolla",
-		Price:     100000,
+		Price:     domain.NewMoney(10000000, "BRL"),
+		Currency:  "BRL",
 	}
 
 	err := showcaseClient.CreateListing(context.Background(), data)
@@ -42,7 +61,7 @@ func TestCreateListing_NonSuccessStatus(t *testing.T) {
 	}))
 	defer server.Close()
 
-	showcaseClient := client.NewShowcaseClient(server.URL)
+	showcaseClient := newTestClient(server.URL)
 
 	data := dto.CreateListingDTO{}
 
@@ -53,7 +72,7 @@ func TestCreateListing_NonSuccessStatus(t *testing.T) {
 }
 
 func TestCreateListing_HTTPError(t *testing.T) {
-	showcaseClient := client.NewShowcaseClient("http://invalid-host")
+	showcaseClient := newTestClient("http://invalid-host")
 
 	data := dto.CreateListingDTO{}
 
@@ -76,12 +95,12 @@ func TestUpdateListing_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	showcaseClient := client.NewShowcaseClient(server.URL)
+	showcaseClient := newTestClient(server.URL)
 
 	data := dto.UpdateListingDTO{
 		Brand: "Honda",
 		Model: "Civic",
-		Price: 120000,
+		Price: domain.NewMoney(12000000, "BRL"),
 	}
 
 	err := showcaseClient.UpdateListing(context.Background(), "123", data)
@@ -96,7 +115,7 @@ func TestUpdateListing_NonSuccessStatus(t *testing.T) {
 	}))
 	defer server.Close()
 
-	showcaseClient := client.NewShowcaseClient(server.URL)
+	showcaseClient := newTestClient(server.URL)
 
 	data := dto.UpdateListingDTO{}
 
@@ -107,7 +126,7 @@ func TestUpdateListing_NonSuccessStatus(t *testing.T) {
 }
 
 func TestUpdateListing_HTTPError(t *testing.T) {
-	showcaseClient := client.NewShowcaseClient("http://invalid-host")
+	showcaseClient := newTestClient("http://invalid-host")
 
 	data := dto.UpdateListingDTO{}
 
@@ -115,4 +134,395 @@ func TestUpdateListing_HTTPError(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-}
\ No newline at end of file
+}
+func TestUpdateListingStatus_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		expectedPath := "/listings/vehicle/123/status"
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	data := dto.UpdateListingStatusDTO{Status: "SOLD"}
+
+	err := showcaseClient.UpdateListingStatus(context.Background(), "123", data)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestUpdateListingStatus_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	err := showcaseClient.UpdateListingStatus(context.Background(), "123", dto.UpdateListingStatusDTO{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestUpdateListingStatus_HTTPError(t *testing.T) {
+	showcaseClient := newTestClient("http://invalid-host")
+
+	err := showcaseClient.UpdateListingStatus(context.Background(), "123", dto.UpdateListingStatusDTO{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestDeleteListing_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		expectedPath := "/listings/vehicle/123"
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	err := showcaseClient.DeleteListing(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteListing_NonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	err := showcaseClient.DeleteListing(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestDeleteListing_HTTPError(t *testing.T) {
+	showcaseClient := newTestClient("http://invalid-host")
+
+	err := showcaseClient.DeleteListing(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestCreateListing_RetriesServerErrorsWithSameIdempotencyKey(t *testing.T) {
+	var calls atomic.Int32
+	keys := make(chan string, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get("Idempotency-Key")
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	err := showcaseClient.CreateListing(context.Background(), dto.CreateListingDTO{VehicleID: "123"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+
+	first := <-keys
+	if first == "" {
+		t.Fatal("expected an Idempotency-Key header")
+	}
+	for i := 0; i < 2; i++ {
+		if key := <-keys; key != first {
+			t.Errorf("expected retries to reuse key %q, got %q", first, key)
+		}
+	}
+}
+
+func TestCreateListing_UsesIdempotencyKeyFromContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("Idempotency-Key"); key != "evt-1" {
+			t.Errorf("expected Idempotency-Key evt-1, got %q", key)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	ctx := client.WithIdempotencyKey(context.Background(), "evt-1")
+	err := showcaseClient.CreateListing(ctx, dto.CreateListingDTO{VehicleID: "123"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestUpdateListing_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	err := showcaseClient.UpdateListing(context.Background(), "123", dto.UpdateListingDTO{})
+	if !errors.Is(err, domain.ErrRejected) {
+		t.Fatalf("expected rejected error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestUpdateListing_TooManyRequestsIsNotRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	showcaseClient := newTestClient(server.URL)
+
+	err := showcaseClient.UpdateListing(context.Background(), "123", dto.UpdateListingDTO{})
+	if err == nil || errors.Is(err, domain.ErrRejected) {
+		t.Fatalf("expected an error that can be retried later, got %v", err)
+	}
+}
+
+func TestDeleteListing_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	showcaseClient := client.NewShowcaseClient(server.URL, client.ShowcaseClientConfig{
+		MaxRetries:  2,
+		BaseBackoff: time.Millisecond,
+	})
+
+	err := showcaseClient.DeleteListing(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDeleteListing_TimesOutHungRequests(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	showcaseClient := client.NewShowcaseClient(server.URL, client.ShowcaseClientConfig{
+		Timeout:    20 * time.Millisecond,
+		MaxRetries: -1,
+	})
+
+	start := time.Now()
+	err := showcaseClient.DeleteListing(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the request to time out quickly, took %s", elapsed)
+	}
+}
+
+func TestShowcaseClient_CircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	showcaseClient := client.NewShowcaseClient(server.URL, client.ShowcaseClientConfig{
+		MaxRetries:       -1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := showcaseClient.DeleteListing(ctx, "123"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	}
+
+	err := showcaseClient.DeleteListing(ctx, "123")
+	if !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected the open circuit to skip the request, got %d calls", calls.Load())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+
+	err = showcaseClient.DeleteListing(ctx, "123")
+	if err != nil {
+		t.Fatalf("expected the trial call to succeed, got %v", err)
+	}
+	err = showcaseClient.DeleteListing(ctx, "123")
+	if err != nil {
+		t.Fatalf("expected the circuit to be closed, got %v", err)
+	}
+}
+
+func TestShowcaseClient_CircuitBreakerIgnoresCanceledCalls(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	showcaseClient := client.NewShowcaseClient(server.URL, client.ShowcaseClientConfig{
+		MaxRetries:       -1,
+		BreakerThreshold: 1,
+		BreakerCooldown:  time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := showcaseClient.DeleteListing(ctx, "123"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	err := showcaseClient.DeleteListing(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("expected the canceled call not to open the circuit, got %v", err)
+	}
+}
+
+func TestShowcaseClient_CircuitBreakerSingleTrial(t *testing.T) {
+	// Each request waits for the status sent on the channel of its vehicle ID;
+	// other IDs succeed at once.
+	statuses := map[string]chan int{"a": make(chan int), "b": make(chan int), "probe": make(chan int)}
+	arrived := make(chan string, len(statuses))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		status, ok := statuses[id]
+		if !ok {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		arrived <- id
+		w.WriteHeader(<-status)
+	}))
+	defer server.Close()
+
+	showcaseClient := client.NewShowcaseClient(server.URL, client.ShowcaseClientConfig{
+		MaxRetries:       -1,
+		BreakerThreshold: 1,
+		BreakerCooldown:  20 * time.Millisecond,
+	})
+	ctx := context.Background()
+	call := func(id string) <-chan error {
+		done := make(chan error, 1)
+		go func() { done <- showcaseClient.DeleteListing(ctx, id) }()
+		return done
+	}
+
+	a, b := call("a"), call("b")
+	<-arrived
+	<-arrived
+	statuses["a"] <- http.StatusInternalServerError
+	if err := <-a; err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	probe := call("probe")
+	<-arrived
+
+	// A call let through before the circuit opened must not end the trial.
+	statuses["b"] <- http.StatusInternalServerError
+	if err := <-b; err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	time.Sleep(30 * time.Millisecond)
+	err := showcaseClient.DeleteListing(ctx, "c")
+	if !errors.Is(err, client.ErrCircuitOpen) {
+		t.Errorf("expected circuit open error during the trial, got %v", err)
+	}
+
+	statuses["probe"] <- http.StatusOK
+	if err := <-probe; err != nil {
+		t.Fatalf("expected the trial call to succeed, got %v", err)
+	}
+}
+
+func TestListListings_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/listings" || r.URL.Query().Get("limit") != "50" || r.URL.Query().Get("offset") != "100" {
+			t.Errorf("unexpected request %s", r.URL.RequestURI())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[{"vehicle_id":"123","brand":"Toyota","model":"Corolla","price":"100000.00","currency":"BRL","status":"AVAILABLE"}],"total":101,"limit":50,"offset":100}`))
+	}))
+	defer server.Close()
+
+	page, err := newTestClient(server.URL).ListListings(context.Background(), 50, 100)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if page.Total != 101 || len(page.Items) != 1 || page.Items[0].VehicleID != "123" || page.Items[0].Status != "AVAILABLE" ||
+		page.Items[0].Price.String() != "100000.00" || page.Items[0].Currency != "BRL" {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
+
+func TestListListings_InvalidBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`not json`))
+	}))
+	defer server.Close()
+
+	page, err := newTestClient(server.URL).ListListings(context.Background(), 50, 0)
+	if err == nil || page != nil {
+		t.Fatalf("expected error and nil page, got err=%v, page=%+v", err, page)
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Brand is a canonical vehicle brand. Aliases are alternative spellings, such
+// as "VW" for "Volkswagen", that resolve to it.
+type Brand struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Model is a canonical model of a brand. Its name and aliases are unique
+// within the brand.
+type Model struct {
+	ID        string    `json:"id"`
+	BrandID   string    `json:"brand_id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CatalogName trims a brand or model name and collapses its inner
+// whitespace, keeping its case.
+func CatalogName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// CatalogKey is the form in which brand and model names and aliases are
+// compared: case- and whitespace-insensitive.
+func CatalogKey(name string) string {
+	return strings.ToLower(CatalogName(name))
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("resource not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict with the current state of the resource")
+
+	ErrPreconditionFailed = errors.New("precondition failed")
+
+	// ErrRejected reports a request refused for good, either by a downstream
+	// service (a 4xx other than 408 and 429) or because its payload is
+	// invalid. Repeating the request cannot succeed.
+	ErrRejected = errors.New("request rejected")
+)
+
+const (
+	CodeRequired        = "required"
+	CodeTooLong         = "too_long"
+	CodeOutOfRange      = "out_of_range"
+	CodeMustBePositive  = "must_be_positive"
+	CodeInvalid         = "invalid"
+	CodeTooShort        = "too_short"
+	CodeTooPrecise      = "too_precise"
+	CodeInvalidChecksum = "invalid_checksum"
+	CodeVINMismatch     = "vin_mismatch"
+	CodeUnknown         = "unknown"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Message)
+	}
+	return ErrValidation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() error {
+	return ErrValidation
+}
+
+// DuplicateVehicleError reports that an identifier of a vehicle is already
+// registered to another vehicle. It matches ErrConflict.
+type DuplicateVehicleError struct {
+	Field     string
+	VehicleID string
+}
+
+func (e *DuplicateVehicleError) Error() string {
+	return fmt.Sprintf("%s: %s is already registered to vehicle %s", ErrConflict, e.Field, e.VehicleID)
+}
+
+func (e *DuplicateVehicleError) Unwrap() error {
+	return ErrConflict
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventVehicleCreated  EventType = "vehicle.created"
+	EventVehicleUpdated  EventType = "vehicle.updated"
+	EventVehicleReserved EventType = "vehicle.reserved"
+	EventVehicleSold     EventType = "vehicle.sold"
+	EventVehicleReleased EventType = "vehicle.released"
+	EventVehicleDeleted  EventType = "vehicle.deleted"
+)
+
+// EventTypes lists every event type the catalog publishes.
+var EventTypes = []EventType{
+	EventVehicleCreated,
+	EventVehicleUpdated,
+	EventVehicleReserved,
+	EventVehicleSold,
+	EventVehicleReleased,
+	EventVehicleDeleted,
+}
+
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// EventSchemaVersion is bumped whenever the payload of an existing event type
+// changes incompatibly, so consumers can tell the shapes apart.
+const EventSchemaVersion = 1
+
+// Event is the envelope of every catalog event published to other services.
+type Event struct {
+	ID          string          `json:"id"`
+	Type        EventType       `json:"type"`
+	Version     int             `json:"version"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of prices given without one.
+const DefaultCurrency = "BRL"
+
+// MoneyScale is the number of decimal places of a valid amount.
+const MoneyScale = 2
+
+var (
+	ErrInvalidMoney        = errors.New("invalid money amount")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// Money is an exact decimal amount of a currency, held as an integer number of
+// units at a given scale. Amounts are normalized to at least MoneyScale
+// decimals with trailing zeros beyond it trimmed, so equal prices compare
+// equal with == and the zero value is zero. Amounts with more decimals than
+// MoneyScale are kept as given so that validation can reject them.
+//
+// Money encodes to JSON as a string decimal such as "80000.00" and decodes
+// from either a string or a number, without going through float64. The
+// currency is not part of the JSON or SQL value; it travels in a field of its
+// own.
+type Money struct {
+	units int64
+	// extraScale counts the decimals beyond MoneyScale.
+	extraScale int
+	currency   string
+}
+
+// NewMoney returns an amount given in minor units (cents).
+func NewMoney(minor int64, currency string) Money {
+	return Money{units: minor, currency: currency}
+}
+
+// ParseMoney parses a decimal amount such as "80000.00" or "-12.5".
+func ParseMoney(amount, currency string) (Money, error) {
+	m, err := parseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	m.currency = currency
+	return m, nil
+}
+
+func parseDecimal(s string) (Money, error) {
+	digits := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+
+	integer, fraction, _ := strings.Cut(digits, ".")
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) < MoneyScale {
+		fraction += strings.Repeat("0", MoneyScale-len(fraction))
+	}
+
+	units, err := strconv.ParseInt(integer+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+	if negative {
+		units = -units
+	}
+
+	return Money{units: units, extraScale: len(fraction) - MoneyScale}, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Currency returns the ISO 4217 code of the amount.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency returns the same amount in currency.
+func (m Money) WithCurrency(currency string) Money {
+	m.currency = currency
+	return m
+}
+
+// Scale returns the number of decimal places of the amount.
+func (m Money) Scale() int {
+	return MoneyScale + m.extraScale
+}
+
+// MinorUnits returns the amount in cents, truncating any extra decimals.
+func (m Money) MinorUnits() int64 {
+	units := m.units
+	for i := 0; i < m.extraScale; i++ {
+		units /= 10
+	}
+	return units
+}
+
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.units > 0
+}
+
+// Cmp compares the amounts of m and other, ignoring their currencies.
+func (m Money) Cmp(other Money) int {
+	return m.rat().Cmp(other.rat())
+}
+
+// Convert returns the amount multiplied by rate, in currency, rounded half
+// away from zero to MoneyScale decimals. It fails with ErrInvalidMoney when
+// the converted amount does not fit.
+func (m Money) Convert(rate *big.Rat, currency string) (Money, error) {
+	converted := new(big.Rat).Mul(m.rat(), rate)
+	numerator := new(big.Int).Mul(converted.Num(), pow10(MoneyScale))
+	units, remainder := new(big.Int).QuoRem(numerator, converted.Denom(), new(big.Int))
+
+	// Round away from zero when the remainder is at least half the denominator.
+	remainder.Abs(remainder).Mul(remainder, big.NewInt(2))
+	if remainder.Cmp(converted.Denom()) >= 0 {
+		units.Add(units, big.NewInt(int64(converted.Sign())))
+	}
+	if !units.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s converted to %s is out of range", ErrInvalidMoney, m, currency)
+	}
+
+	return Money{units: units.Int64(), currency: currency}, nil
+}
+
+func (m Money) rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.units), pow10(m.Scale()))
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// String formats the amount as a decimal with Scale places, without the
+// currency.
+func (m Money) String() string {
+	scale := m.Scale()
+	sign := ""
+	digits := strconv.FormatInt(m.units, 10)
+	if m.units < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accepts a string decimal or a JSON number and keeps the
+// currency of m.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		var err error
+		text, err = strconv.Unquote(text)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidMoney, data)
+		}
+	}
+
+	parsed, err := parseDecimal(text)
+	if err != nil {
+		return err
+	}
+	parsed.currency = m.currency
+	*m = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC column and keeps the currency of m, which is stored
+// in a column of its own.
+func (m *Money) Scan(src any) error {
+	var text string
+	switch value := src.(type) {
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	case int64:
+		text = strconv.FormatInt(value, 10)
+	case float64:
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+	}
+
+	parsed, err := parseDecimal(text)
+	if err != nil {
+		return err
+	}
+	parsed.currency = m.currency
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as a decimal string, which Postgres casts to
+// NUMERIC exactly.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
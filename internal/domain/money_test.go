@@ -0,0 +1,143 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount    string
+		want      string
+		wantScale int
+		wantErr   bool
+	}{
+		{amount: "80000", want: "80000.00", wantScale: 2},
+		{amount: "80000.5", want: "80000.50", wantScale: 2},
+		{amount: "0.1", want: "0.10", wantScale: 2},
+		{amount: "-12.34", want: "-12.34", wantScale: 2},
+		{amount: "19.990", want: "19.99", wantScale: 2},
+		{amount: ".5", want: "0.50", wantScale: 2},
+		{amount: "10.005", want: "10.005", wantScale: 3},
+		{amount: "", wantErr: true},
+		{amount: "1e3", wantErr: true},
+		{amount: "12.3.4", wantErr: true},
+		{amount: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := domain.ParseMoney(tt.amount, "BRL")
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidMoney) {
+					t.Fatalf("expected ErrInvalidMoney, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got.String() != tt.want || got.Scale() != tt.wantScale || got.Currency() != "BRL" {
+				t.Errorf("got %s (scale %d, %s), want %s (scale %d, BRL)", got, got.Scale(), got.Currency(), tt.want, tt.wantScale)
+			}
+		})
+	}
+}
+
+func TestMoney_Equality(t *testing.T) {
+	parsed, _ := domain.ParseMoney("80000.10", "BRL")
+	if parsed != domain.NewMoney(8000010, "BRL") {
+		t.Errorf("expected parsed and minor-unit amounts to be equal")
+	}
+	zero, _ := domain.ParseMoney("0.00", "")
+	if zero != (domain.Money{}) {
+		t.Errorf("expected zero to equal the zero value")
+	}
+
+	precise, _ := domain.ParseMoney("100.001", "BRL")
+	if precise.Cmp(domain.NewMoney(10000, "BRL")) <= 0 || precise.MinorUnits() != 10000 {
+		t.Errorf("unexpected comparison of %s", precise)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	payload, err := json.Marshal(struct {
+		Price domain.Money `json:"price"`
+	}{domain.NewMoney(-150, "BRL")})
+	if err != nil || string(payload) != `{"price":"-1.50"}` {
+		t.Fatalf("unexpected encoding %s (%v)", payload, err)
+	}
+
+	for _, input := range []string{`"80000.10"`, `80000.1`} {
+		price := domain.NewMoney(0, "USD")
+		if err := json.Unmarshal([]byte(input), &price); err != nil {
+			t.Fatalf("expected no error decoding %s, got %v", input, err)
+		}
+		if price != domain.NewMoney(8000010, "USD") {
+			t.Errorf("decoding %s: got %s %s", input, price, price.Currency())
+		}
+	}
+
+	var price domain.Money
+	if err := json.Unmarshal([]byte(`"abc"`), &price); !errors.Is(err, domain.ErrInvalidMoney) {
+		t.Errorf("expected ErrInvalidMoney, got %v", err)
+	}
+}
+
+func TestMoney_Scan(t *testing.T) {
+	for _, src := range []any{"1234.50", []byte("1234.5"), 1234.5} {
+		price := domain.NewMoney(0, "EUR")
+		if err := price.Scan(src); err != nil {
+			t.Fatalf("expected no error scanning %v, got %v", src, err)
+		}
+		if price != domain.NewMoney(123450, "EUR") {
+			t.Errorf("scanning %v: got %s %s", src, price, price.Currency())
+		}
+	}
+
+	var price domain.Money
+	if err := price.Scan(true); !errors.Is(err, domain.ErrInvalidMoney) {
+		t.Errorf("expected ErrInvalidMoney, got %v", err)
+	}
+
+	value, _ := domain.NewMoney(5, "BRL").Value()
+	if value != "0.05" {
+		t.Errorf("expected 0.05, got %v", value)
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	tests := []struct {
+		amount string
+		rate   *big.Rat
+		want   string
+	}{
+		{amount: "80000.00", rate: big.NewRat(1851, 10000), want: "14808.00"},
+		{amount: "100.00", rate: big.NewRat(1, 3), want: "33.33"},
+		{amount: "0.05", rate: big.NewRat(1, 2), want: "0.03"},
+		{amount: "-0.05", rate: big.NewRat(1, 2), want: "-0.03"},
+		{amount: "10.005", rate: big.NewRat(1, 1), want: "10.01"},
+	}
+
+	for _, tt := range tests {
+		amount, _ := domain.ParseMoney(tt.amount, "BRL")
+		got, err := amount.Convert(tt.rate, "USD")
+		if err != nil {
+			t.Errorf("converting %s at %s: unexpected error %v", tt.amount, tt.rate.FloatString(4), err)
+			continue
+		}
+		if got.String() != tt.want || got.Currency() != "USD" || got.Scale() != domain.MoneyScale {
+			t.Errorf("converting %s at %s: got %s %s, want %s USD", tt.amount, tt.rate.FloatString(4), got, got.Currency(), tt.want)
+		}
+	}
+
+	_, err := domain.NewMoney(math.MaxInt64/2, "BRL").Convert(big.NewRat(3, 1), "USD")
+	if !errors.Is(err, domain.ErrInvalidMoney) {
+		t.Errorf("expected ErrInvalidMoney on overflow, got %v", err)
+	}
+}
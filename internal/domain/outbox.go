@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// OutboxEvent is a catalog event waiting to be delivered to Target, persisted
+// in the same transaction as the vehicle change that produced it. Payload
+// holds the JSON-encoded Event envelope, whose ID is shared by the rows of the
+// other targets. An event is delivered once SentAt is set and
+// abandoned once FailedAt is set.
+type OutboxEvent struct {
+	ID            string     `json:"id"`
+	Target        string     `json:"target"`
+	Type          EventType  `json:"type"`
+	AggregateID   string     `json:"aggregate_id"`
+	Payload       []byte     `json:"payload"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	FailedAt      *time.Time `json:"failed_at,omitempty"`
+}
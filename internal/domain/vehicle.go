@@ -4,13 +4,87 @@ import (
 	"time"
 )
 
+type VehicleStatus string
+
+const (
+	StatusAvailable VehicleStatus = "AVAILABLE"
+	StatusReserved  VehicleStatus = "RESERVED"
+	StatusSold      VehicleStatus = "SOLD"
+)
+
+// FuelType, Transmission, BodyType and VehicleCondition are optional: the
+// empty value means the attribute was not informed.
+type FuelType string
+
+const (
+	FuelGasoline FuelType = "GASOLINE"
+	FuelEthanol  FuelType = "ETHANOL"
+	FuelFlex     FuelType = "FLEX"
+	FuelDiesel   FuelType = "DIESEL"
+	FuelHybrid   FuelType = "HYBRID"
+	FuelElectric FuelType = "ELECTRIC"
+)
+
+var FuelTypes = []FuelType{FuelGasoline, FuelEthanol, FuelFlex, FuelDiesel, FuelHybrid, FuelElectric}
+
+type Transmission string
+
+const (
+	TransmissionManual    Transmission = "MANUAL"
+	TransmissionAutomatic Transmission = "AUTOMATIC"
+	TransmissionAutomated Transmission = "AUTOMATED"
+	TransmissionCVT       Transmission = "CVT"
+)
+
+var Transmissions = []Transmission{TransmissionManual, TransmissionAutomatic, TransmissionAutomated, TransmissionCVT}
+
+type BodyType string
+
+const (
+	BodyHatchback   BodyType = "HATCHBACK"
+	BodySedan       BodyType = "SEDAN"
+	BodySUV         BodyType = "SUV"
+	BodyPickup      BodyType = "PICKUP"
+	BodyCoupe       BodyType = "COUPE"
+	BodyConvertible BodyType = "CONVERTIBLE"
+	BodyWagon       BodyType = "WAGON"
+	BodyMinivan     BodyType = "MINIVAN"
+	BodyVan         BodyType = "VAN"
+)
+
+var BodyTypes = []BodyType{BodyHatchback, BodySedan, BodySUV, BodyPickup, BodyCoupe, BodyConvertible, BodyWagon, BodyMinivan, BodyVan}
+
+type VehicleCondition string
+
+const (
+	ConditionNew  VehicleCondition = "NEW"
+	ConditionUsed VehicleCondition = "USED"
+)
+
+var VehicleConditions = []VehicleCondition{ConditionNew, ConditionUsed}
+
+// Vehicle is a catalog entry. Mileage is in kilometers and EngineDisplacement
+// in cubic centimeters; zero Doors or EngineDisplacement means not informed.
 type Vehicle struct {
-	ID        string    `json:"id"`
-	Brand     string    `json:"brand"`
-	Model     string    `json:"model"`
-	Year      int       `json:"year"`
-	Color     string    `json:"color"`
-	Price     float64   `json:"price"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                 string           `json:"id"`
+	Brand              string           `json:"brand"`
+	Model              string           `json:"model"`
+	Year               int              `json:"year"`
+	Color              string           `json:"color"`
+	Price              Money            `json:"price"`
+	VIN                string           `json:"vin,omitempty"`
+	Plate              string           `json:"plate,omitempty"`
+	Renavam            string           `json:"renavam,omitempty"`
+	Mileage            int              `json:"mileage"`
+	FuelType           FuelType         `json:"fuel_type,omitempty"`
+	Transmission       Transmission     `json:"transmission,omitempty"`
+	BodyType           BodyType         `json:"body_type,omitempty"`
+	Doors              int              `json:"doors,omitempty"`
+	EngineDisplacement int              `json:"engine_displacement,omitempty"`
+	Condition          VehicleCondition `json:"condition,omitempty"`
+	Status             VehicleStatus    `json:"status"`
+	Version            int              `json:"version"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+	DeletedAt          *time.Time       `json:"deleted_at,omitempty"`
 }
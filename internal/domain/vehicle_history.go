@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type HistoryAction string
+
+const (
+	HistoryCreate HistoryAction = "CREATE"
+	HistoryUpdate HistoryAction = "UPDATE"
+	HistoryDelete HistoryAction = "DELETE"
+)
+
+// VehicleHistoryEntry records one change of a vehicle. Before and After hold
+// JSON snapshots of the vehicle; Before is nil on creation and After is nil
+// on deletion.
+type VehicleHistoryEntry struct {
+	ID        string        `json:"id"`
+	VehicleID string        `json:"vehicle_id"`
+	Action    HistoryAction `json:"action"`
+	Before    []byte        `json:"before,omitempty"`
+	After     []byte        `json:"after,omitempty"`
+	Actor     string        `json:"actor"`
+	ChangedAt time.Time     `json:"changed_at"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// VehicleImage is a picture of a vehicle. The image and its thumbnail are
+// kept in blob storage under Key and ThumbnailKey; Position orders the images
+// of the vehicle from zero, and exactly one of them is the cover.
+type VehicleImage struct {
+	ID           string    `json:"id"`
+	VehicleID    string    `json:"vehicle_id"`
+	Key          string    `json:"key"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	Position     int       `json:"position"`
+	Cover        bool      `json:"cover"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+type ImportJobStatus string
+
+const (
+	ImportRunning   ImportJobStatus = "RUNNING"
+	ImportCompleted ImportJobStatus = "COMPLETED"
+	ImportFailed    ImportJobStatus = "FAILED"
+)
+
+type ImportRowStatus string
+
+const (
+	// ImportRowCreated is a row whose vehicle was created.
+	ImportRowCreated ImportRowStatus = "CREATED"
+	// ImportRowValid is a row that passed a dry run.
+	ImportRowValid  ImportRowStatus = "VALID"
+	ImportRowFailed ImportRowStatus = "FAILED"
+)
+
+// ImportRowResult is the outcome of one row of an import file. Line is the
+// line of the file where the row starts.
+type ImportRowResult struct {
+	Line      int             `json:"line"`
+	Status    ImportRowStatus `json:"status"`
+	VehicleID string          `json:"vehicle_id,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Errors    []FieldError    `json:"errors,omitempty"`
+}
+
+// VehicleImportJob tracks an import processed in the background. Results
+// grows as the batches of the file are processed, and Processed counts the
+// rows in it.
+type VehicleImportJob struct {
+	ID         string            `json:"id"`
+	Status     ImportJobStatus   `json:"status"`
+	DryRun     bool              `json:"dry_run"`
+	Total      int               `json:"total"`
+	Processed  int               `json:"processed"`
+	Succeeded  int               `json:"succeeded"`
+	Failed     int               `json:"failed"`
+	Results    []ImportRowResult `json:"results"`
+	Error      string            `json:"error,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+}
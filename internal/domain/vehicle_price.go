@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// PriceChange records a change of the asking price of a vehicle.
+type PriceChange struct {
+	ID            string    `json:"id"`
+	VehicleID     string    `json:"vehicle_id"`
+	PreviousPrice Money     `json:"previous_price"`
+	Price         Money     `json:"price"`
+	Actor         string    `json:"actor"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+// PriceStats aggregates the pricing of the vehicles of one brand, model and
+// currency.
+// Days on market run from creation until the sale, or until now for vehicles
+// still on sale. The discount is measured from the first asking price to the
+// current one.
+type PriceStats struct {
+	Brand              string  `json:"brand"`
+	Model              string  `json:"model"`
+	Currency           string  `json:"currency"`
+	Vehicles           int     `json:"vehicles"`
+	Sold               int     `json:"sold"`
+	PriceChanges       int     `json:"price_changes"`
+	AvgDaysOnMarket    float64 `json:"avg_days_on_market"`
+	AvgDiscount        Money   `json:"avg_discount"`
+	AvgDiscountPercent float64 `json:"avg_discount_percent"`
+}
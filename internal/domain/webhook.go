@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "PENDING"
+	DeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	DeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookSubscription registers a URL that receives the catalog events listed
+// in EventTypes. Secret signs every delivery and is never exposed after the
+// subscription is created.
+type WebhookSubscription struct {
+	ID         string      `json:"id"`
+	URL        string      `json:"url"`
+	EventTypes []EventType `json:"event_types"`
+	Secret     string      `json:"-"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// WebhookDelivery is one entry of the delivery log: an event sent, or still
+// to be sent, to a subscription. A redelivery is a new entry pointing at the
+// delivery it repeats through RedeliveredFrom.
+type WebhookDelivery struct {
+	ID              string                `json:"id"`
+	SubscriptionID  string                `json:"subscription_id"`
+	EventID         string                `json:"event_id"`
+	EventType       EventType             `json:"event_type"`
+	URL             string                `json:"url"`
+	Payload         []byte                `json:"payload"`
+	Status          WebhookDeliveryStatus `json:"status"`
+	Attempts        int                   `json:"attempts"`
+	LastError       string                `json:"last_error,omitempty"`
+	ResponseStatus  int                   `json:"response_status,omitempty"`
+	NextAttemptAt   time.Time             `json:"next_attempt_at"`
+	CreatedAt       time.Time             `json:"created_at"`
+	DeliveredAt     *time.Time            `json:"delivered_at,omitempty"`
+	RedeliveredFrom string                `json:"redelivered_from,omitempty"`
+}
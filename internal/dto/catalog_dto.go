@@ -0,0 +1,36 @@
+package dto
+
+type InputBrandDTO struct {
+	Name    string   `json:"name" example:"Volkswagen"`
+	Aliases []string `json:"aliases,omitempty" example:"VW"`
+}
+
+type OutputBrandDTO struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type OutputListBrandsDTO struct {
+	Items []OutputBrandDTO `json:"items"`
+}
+
+type InputModelDTO struct {
+	Name    string   `json:"name" example:"Gol"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+type OutputModelDTO struct {
+	ID        string   `json:"id"`
+	BrandID   string   `json:"brand_id"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type OutputListModelsDTO struct {
+	Items []OutputModelDTO `json:"items"`
+}
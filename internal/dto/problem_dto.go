@@ -0,0 +1,17 @@
+package dto
+
+type FieldErrorDTO struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ProblemDetailsDTO struct {
+	Type          string          `json:"type"`
+	Title         string          `json:"title"`
+	Status        int             `json:"status"`
+	Detail        string          `json:"detail,omitempty"`
+	Instance      string          `json:"instance,omitempty"`
+	Errors        []FieldErrorDTO `json:"errors,omitempty"`
+	ConflictingID string          `json:"conflicting_id,omitempty"`
+}
@@ -1,11 +1,30 @@
 package dto
 
+import (
+	"encoding/json"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
 type InputCreateVehicleDTO struct {
-	Brand string  `json:"brand"`
-	Model string  `json:"model"`
-	Year  int     `json:"year"`
-	Color string  `json:"color"`
-	Price float64 `json:"price"`
+	Brand              string       `json:"brand"`
+	Model              string       `json:"model"`
+	Year               int          `json:"year"`
+	Color              string       `json:"color"`
+	Price              domain.Money `json:"price" swaggertype:"string" example:"80000.00"`
+	Currency           string       `json:"currency,omitempty" example:"BRL"`
+	VIN                string       `json:"vin,omitempty" example:"9BWZZZ377VT004251"`
+	Plate              string       `json:"plate,omitempty" example:"BRA2E19"`
+	Renavam            string       `json:"renavam,omitempty" example:"00639884962"`
+	Mileage            int          `json:"mileage,omitempty" example:"42000"`
+	FuelType           string       `json:"fuel_type,omitempty" enums:"GASOLINE,ETHANOL,FLEX,DIESEL,HYBRID,ELECTRIC" example:"FLEX"`
+	Transmission       string       `json:"transmission,omitempty" enums:"MANUAL,AUTOMATIC,AUTOMATED,CVT" example:"MANUAL"`
+	BodyType           string       `json:"body_type,omitempty" enums:"HATCHBACK,SEDAN,SUV,PICKUP,COUPE,CONVERTIBLE,WAGON,MINIVAN,VAN" example:"HATCHBACK"`
+	Doors              int          `json:"doors,omitempty" example:"4"`
+	EngineDisplacement int          `json:"engine_displacement,omitempty" example:"999"`
+	Condition          string       `json:"condition,omitempty" enums:"NEW,USED" example:"USED"`
+	// OverrideVIN accepts a brand and year that contradict the VIN.
+	OverrideVIN bool `json:"override_vin,omitempty" example:"false"`
 }
 
 type OutputCreateVehicleDTO struct {
@@ -14,22 +33,235 @@ type OutputCreateVehicleDTO struct {
 }
 
 type InputUpdateVehicleDTO struct {
-	Brand string  `json:"brand"`
-	Model string  `json:"model"`
-	Year  int     `json:"year"`
-	Color string  `json:"color"`
-	Price float64 `json:"price"`
+	Brand              string       `json:"brand"`
+	Model              string       `json:"model"`
+	Year               int          `json:"year"`
+	Color              string       `json:"color"`
+	Price              domain.Money `json:"price" swaggertype:"string" example:"80000.00"`
+	Currency           string       `json:"currency,omitempty" example:"BRL"`
+	VIN                string       `json:"vin,omitempty" example:"9BWZZZ377VT004251"`
+	Plate              string       `json:"plate,omitempty" example:"BRA2E19"`
+	Renavam            string       `json:"renavam,omitempty" example:"00639884962"`
+	Mileage            int          `json:"mileage,omitempty" example:"42000"`
+	FuelType           string       `json:"fuel_type,omitempty" enums:"GASOLINE,ETHANOL,FLEX,DIESEL,HYBRID,ELECTRIC" example:"FLEX"`
+	Transmission       string       `json:"transmission,omitempty" enums:"MANUAL,AUTOMATIC,AUTOMATED,CVT" example:"MANUAL"`
+	BodyType           string       `json:"body_type,omitempty" enums:"HATCHBACK,SEDAN,SUV,PICKUP,COUPE,CONVERTIBLE,WAGON,MINIVAN,VAN" example:"HATCHBACK"`
+	Doors              int          `json:"doors,omitempty" example:"4"`
+	EngineDisplacement int          `json:"engine_displacement,omitempty" example:"999"`
+	Condition          string       `json:"condition,omitempty" enums:"NEW,USED" example:"USED"`
+}
+
+type InputPatchVehicleDTO struct {
+	Brand              *string       `json:"brand,omitempty"`
+	Model              *string       `json:"model,omitempty"`
+	Year               *int          `json:"year,omitempty"`
+	Color              *string       `json:"color,omitempty"`
+	Price              *domain.Money `json:"price,omitempty" swaggertype:"string" example:"80000.00"`
+	Currency           *string       `json:"currency,omitempty" example:"BRL"`
+	VIN                *string       `json:"vin,omitempty" example:"9BWZZZ377VT004251"`
+	Plate              *string       `json:"plate,omitempty" example:"BRA2E19"`
+	Renavam            *string       `json:"renavam,omitempty" example:"00639884962"`
+	Mileage            *int          `json:"mileage,omitempty" example:"42000"`
+	FuelType           *string       `json:"fuel_type,omitempty" enums:"GASOLINE,ETHANOL,FLEX,DIESEL,HYBRID,ELECTRIC" example:"FLEX"`
+	Transmission       *string       `json:"transmission,omitempty" enums:"MANUAL,AUTOMATIC,AUTOMATED,CVT" example:"MANUAL"`
+	BodyType           *string       `json:"body_type,omitempty" enums:"HATCHBACK,SEDAN,SUV,PICKUP,COUPE,CONVERTIBLE,WAGON,MINIVAN,VAN" example:"HATCHBACK"`
+	Doors              *int          `json:"doors,omitempty" example:"4"`
+	EngineDisplacement *int          `json:"engine_displacement,omitempty" example:"999"`
+	Condition          *string       `json:"condition,omitempty" enums:"NEW,USED" example:"USED"`
 }
 
 type CreateListingDTO struct {
-	VehicleID string  `json:"vehicle_id"`
-	Brand     string  `json:"brand"`
-	Model     string  `json:"model"`
-	Price     float64 `json:"price"`
+	VehicleID          string              `json:"vehicle_id"`
+	Brand              string              `json:"brand"`
+	Model              string              `json:"model"`
+	Price              domain.Money        `json:"price"`
+	Currency           string              `json:"currency"`
+	ConvertedPrices    []ConvertedPriceDTO `json:"converted_prices,omitempty"`
+	Mileage            int                 `json:"mileage"`
+	FuelType           string              `json:"fuel_type,omitempty"`
+	Transmission       string              `json:"transmission,omitempty"`
+	BodyType           string              `json:"body_type,omitempty"`
+	Doors              int                 `json:"doors,omitempty"`
+	EngineDisplacement int                 `json:"engine_displacement,omitempty"`
+	Condition          string              `json:"condition,omitempty"`
+	Images             []ListingImageDTO   `json:"images,omitempty"`
+	Status             string              `json:"status"`
 }
 
 type UpdateListingDTO struct {
-	Brand string  `json:"brand"`
-	Model string  `json:"model"`
-	Price float64 `json:"price"`
+	Brand              string              `json:"brand,omitempty"`
+	Model              string              `json:"model,omitempty"`
+	Price              domain.Money        `json:"price"`
+	Currency           string              `json:"currency"`
+	ConvertedPrices    []ConvertedPriceDTO `json:"converted_prices,omitempty"`
+	Mileage            int                 `json:"mileage"`
+	FuelType           string              `json:"fuel_type,omitempty"`
+	Transmission       string              `json:"transmission,omitempty"`
+	BodyType           string              `json:"body_type,omitempty"`
+	Doors              int                 `json:"doors,omitempty"`
+	EngineDisplacement int                 `json:"engine_displacement,omitempty"`
+	Condition          string              `json:"condition,omitempty"`
+	// Images replaces the pictures of the listing; null leaves them unchanged
+	// and an empty list removes them.
+	Images []ListingImageDTO `json:"images"`
+}
+
+// ListingImageDTO is a picture of a listing, in display order.
+type ListingImageDTO struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Cover        bool   `json:"cover"`
+}
+
+// ConvertedPriceDTO is a price converted to another currency at the current
+// exchange rate.
+type ConvertedPriceDTO struct {
+	Price    domain.Money `json:"price" swaggertype:"string" example:"14800.00"`
+	Currency string       `json:"currency" example:"USD"`
+}
+
+type UpdateListingStatusDTO struct {
+	Status string `json:"status"`
+}
+
+type ListingDTO struct {
+	VehicleID          string            `json:"vehicle_id"`
+	Brand              string            `json:"brand"`
+	Model              string            `json:"model"`
+	Price              domain.Money      `json:"price"`
+	Currency           string            `json:"currency"`
+	Mileage            int               `json:"mileage"`
+	FuelType           string            `json:"fuel_type"`
+	Transmission       string            `json:"transmission"`
+	BodyType           string            `json:"body_type"`
+	Doors              int               `json:"doors"`
+	EngineDisplacement int               `json:"engine_displacement"`
+	Condition          string            `json:"condition"`
+	Images             []ListingImageDTO `json:"images"`
+	Status             string            `json:"status"`
+}
+
+type ListingPageDTO struct {
+	Items  []ListingDTO `json:"items"`
+	Total  int          `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+type OutputVehicleDTO struct {
+	ID                 string             `json:"id"`
+	Brand              string             `json:"brand"`
+	Model              string             `json:"model"`
+	Year               int                `json:"year"`
+	Color              string             `json:"color"`
+	Price              domain.Money       `json:"price" swaggertype:"string" example:"80000.00"`
+	Currency           string             `json:"currency" example:"BRL"`
+	Converted          *ConvertedPriceDTO `json:"converted,omitempty"`
+	VIN                string             `json:"vin,omitempty"`
+	Plate              string             `json:"plate,omitempty"`
+	Renavam            string             `json:"renavam,omitempty"`
+	Mileage            int                `json:"mileage" example:"42000"`
+	FuelType           string             `json:"fuel_type,omitempty" example:"FLEX"`
+	Transmission       string             `json:"transmission,omitempty" example:"MANUAL"`
+	BodyType           string             `json:"body_type,omitempty" example:"HATCHBACK"`
+	Doors              int                `json:"doors,omitempty" example:"4"`
+	EngineDisplacement int                `json:"engine_displacement,omitempty" example:"999"`
+	Condition          string             `json:"condition,omitempty" example:"USED"`
+	Status             string             `json:"status"`
+	Version            int                `json:"version"`
+	CreatedAt          string             `json:"created_at"`
+	UpdatedAt          string             `json:"updated_at"`
+
+	Images []OutputVehicleImageDTO `json:"images,omitempty"`
+}
+
+type InputListVehiclesDTO struct {
+	Brand                 string
+	Model                 string
+	Color                 string
+	YearMin               *int
+	YearMax               *int
+	PriceMin              *domain.Money
+	PriceMax              *domain.Money
+	MileageMin            *int
+	MileageMax            *int
+	FuelType              string
+	Transmission          string
+	BodyType              string
+	Doors                 *int
+	EngineDisplacementMin *int
+	EngineDisplacementMax *int
+	Condition             string
+	Currency              string
+	SortBy                string
+	SortOrder             string
+	Limit                 int
+	Offset                int
+}
+
+type OutputListVehiclesDTO struct {
+	Items  []OutputVehicleDTO `json:"items"`
+	Total  int                `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+type InputListVehicleHistoryDTO struct {
+	Limit  int
+	Offset int
+}
+
+type OutputVehicleHistoryDTO struct {
+	ID        string          `json:"id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	Actor     string          `json:"actor"`
+	ChangedAt string          `json:"changed_at"`
+}
+
+type OutputListVehicleHistoryDTO struct {
+	Items  []OutputVehicleHistoryDTO `json:"items"`
+	Total  int                       `json:"total"`
+	Limit  int                       `json:"limit"`
+	Offset int                       `json:"offset"`
+}
+
+type OutputPricePeriodDTO struct {
+	Price         domain.Money  `json:"price" swaggertype:"string" example:"80000.00"`
+	PreviousPrice *domain.Money `json:"previous_price,omitempty" swaggertype:"string" example:"85000.00"`
+	Currency      string        `json:"currency" example:"BRL"`
+	Actor         string        `json:"actor,omitempty"`
+	From          string        `json:"from"`
+	Until         string        `json:"until,omitempty"`
+	Days          float64       `json:"days"`
+}
+
+type OutputVehiclePricesDTO struct {
+	VehicleID    string                 `json:"vehicle_id"`
+	InitialPrice domain.Money           `json:"initial_price" swaggertype:"string" example:"85000.00"`
+	CurrentPrice domain.Money           `json:"current_price" swaggertype:"string" example:"80000.00"`
+	Currency     string                 `json:"currency" example:"BRL"`
+	Items        []OutputPricePeriodDTO `json:"items"`
+}
+
+type InputPriceStatsDTO struct {
+	Brand string
+	Model string
+}
+
+type OutputPriceStatsDTO struct {
+	Brand              string       `json:"brand"`
+	Model              string       `json:"model"`
+	Currency           string       `json:"currency"`
+	Vehicles           int          `json:"vehicles"`
+	Sold               int          `json:"sold"`
+	PriceChanges       int          `json:"price_changes"`
+	AvgDaysOnMarket    float64      `json:"avg_days_on_market"`
+	AvgDiscount        domain.Money `json:"avg_discount" swaggertype:"string" example:"2500.00"`
+	AvgDiscountPercent float64      `json:"avg_discount_percent"`
+}
+
+type OutputListPriceStatsDTO struct {
+	Items []OutputPriceStatsDTO `json:"items"`
 }
@@ -0,0 +1,22 @@
+package dto
+
+type OutputVehicleImageDTO struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type" example:"image/jpeg"`
+	Size         int64  `json:"size" example:"524288"`
+	Width        int    `json:"width" example:"1600"`
+	Height       int    `json:"height" example:"1200"`
+	Position     int    `json:"position"`
+	Cover        bool   `json:"cover"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type OutputListVehicleImagesDTO struct {
+	Items []OutputVehicleImageDTO `json:"items"`
+}
+
+type InputReorderVehicleImagesDTO struct {
+	ImageIDs []string `json:"image_ids"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+// InputImportVehiclesDTO holds the options of an import; the file itself is
+// read separately.
+type InputImportVehiclesDTO struct {
+	Format string
+	DryRun bool
+	Async  bool
+}
+
+// OutputImportVehiclesDTO is the outcome of an import request: the report of
+// a file imported right away, or the job importing it in the background.
+type OutputImportVehiclesDTO struct {
+	Report *OutputImportReportDTO
+	Job    *OutputImportJobDTO
+}
+
+type OutputImportReportDTO struct {
+	DryRun    bool                 `json:"dry_run"`
+	Total     int                  `json:"total"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Rows      []ImportRowResultDTO `json:"rows"`
+}
+
+type ImportRowResultDTO struct {
+	Line      int             `json:"line" example:"2"`
+	Status    string          `json:"status" enums:"CREATED,VALID,FAILED" example:"CREATED"`
+	VehicleID string          `json:"vehicle_id,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Errors    []FieldErrorDTO `json:"errors,omitempty"`
+}
+
+type OutputImportJobDTO struct {
+	ID         string               `json:"id"`
+	Status     string               `json:"status" enums:"RUNNING,COMPLETED,FAILED" example:"RUNNING"`
+	DryRun     bool                 `json:"dry_run"`
+	Total      int                  `json:"total"`
+	Processed  int                  `json:"processed"`
+	Succeeded  int                  `json:"succeeded"`
+	Failed     int                  `json:"failed"`
+	Rows       []ImportRowResultDTO `json:"rows"`
+	Error      string               `json:"error,omitempty"`
+	CreatedAt  string               `json:"created_at"`
+	UpdatedAt  string               `json:"updated_at"`
+	FinishedAt string               `json:"finished_at,omitempty"`
+}
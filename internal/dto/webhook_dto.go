@@ -0,0 +1,56 @@
+package dto
+
+import "encoding/json"
+
+type InputCreateWebhookDTO struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret,omitempty"`
+}
+
+type OutputWebhookDTO struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// OutputCreateWebhookDTO is the only response that discloses the secret.
+type OutputCreateWebhookDTO struct {
+	OutputWebhookDTO
+	Secret string `json:"secret"`
+}
+
+type OutputListWebhooksDTO struct {
+	Items []OutputWebhookDTO `json:"items"`
+}
+
+type InputListWebhookDeliveriesDTO struct {
+	Status string
+	Limit  int
+	Offset int
+}
+
+type OutputWebhookDeliveryDTO struct {
+	ID              string          `json:"id"`
+	SubscriptionID  string          `json:"subscription_id"`
+	EventID         string          `json:"event_id"`
+	EventType       string          `json:"event_type"`
+	URL             string          `json:"url"`
+	Payload         json.RawMessage `json:"payload" swaggertype:"object"`
+	Status          string          `json:"status"`
+	Attempts        int             `json:"attempts"`
+	LastError       string          `json:"last_error,omitempty"`
+	ResponseStatus  int             `json:"response_status,omitempty"`
+	NextAttemptAt   string          `json:"next_attempt_at"`
+	CreatedAt       string          `json:"created_at"`
+	DeliveredAt     string          `json:"delivered_at,omitempty"`
+	RedeliveredFrom string          `json:"redelivered_from,omitempty"`
+}
+
+type OutputListWebhookDeliveriesDTO struct {
+	Items  []OutputWebhookDeliveryDTO `json:"items"`
+	Total  int                        `json:"total"`
+	Limit  int                        `json:"limit"`
+	Offset int                        `json:"offset"`
+}
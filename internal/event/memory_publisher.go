@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type Handler func(ctx context.Context, event domain.Event) error
+
+// InMemoryPublisher keeps published events in memory and hands them to its
+// subscribers synchronously. It suits tests and single-process setups.
+type InMemoryPublisher struct {
+	mu       sync.RWMutex
+	events   []domain.Event
+	handlers []Handler
+}
+
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{}
+}
+
+func (p *InMemoryPublisher) Subscribe(handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers = append(p.handlers, handler)
+}
+
+func (p *InMemoryPublisher) Publish(ctx context.Context, event domain.Event) error {
+	p.mu.Lock()
+	p.events = append(p.events, event)
+	handlers := append([]Handler(nil), p.handlers...)
+	p.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Events returns a copy of every event published so far.
+func (p *InMemoryPublisher) Events() []domain.Event {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]domain.Event(nil), p.events...)
+}
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// Outbox targets of the downstream publishers.
+const (
+	TargetShowcase      = "showcase"
+	TargetSubscriptions = "webhook_subscriptions"
+)
+
+// WebhookTarget is the outbox target of the webhook POSTed at url.
+func WebhookTarget(url string) string {
+	return "webhook:" + url
+}
+
+type outboxPublisher struct {
+	outbox  repository.OutboxRepository
+	targets []string
+}
+
+// NewOutboxPublisher records events in the outbox, joining the transaction
+// carried by the context. Every event gets a row per target, so the outbox
+// dispatcher delivers, retries and abandons it for each target on its own.
+func NewOutboxPublisher(outbox repository.OutboxRepository, targets []string) usecase.EventPublisher {
+	return &outboxPublisher{
+		outbox:  outbox,
+		targets: targets,
+	}
+}
+
+func (p *outboxPublisher) Publish(ctx context.Context, event domain.Event) error {
+	envelope, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, target := range p.targets {
+		outboxEvent := &domain.OutboxEvent{
+			ID:            uuid.New().String(),
+			Target:        target,
+			Type:          event.Type,
+			AggregateID:   event.AggregateID,
+			Payload:       envelope,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+
+		err = p.outbox.Add(ctx, outboxEvent)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package event_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mclient "github.com/NicolasNSC/catalog-service-fiap/internal/client/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/event"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	musecase "github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PublisherSuite struct {
+	suite.Suite
+
+	ctx  context.Context
+	ctrl *gomock.Controller
+}
+
+func (suite *PublisherSuite) BeforeTest(_, _ string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.ctx = context.Background()
+}
+
+func Test_PublisherSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PublisherSuite))
+}
+
+func newEvent(eventType domain.EventType, vehicle dto.OutputVehicleDTO) domain.Event {
+	payload, _ := json.Marshal(vehicle)
+	return domain.Event{
+		ID:          "evt-1",
+		Type:        eventType,
+		Version:     domain.EventSchemaVersion,
+		AggregateID: vehicle.ID,
+		OccurredAt:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Payload:     payload,
+	}
+}
+
+func (suite *PublisherSuite) Test_OutboxPublisher() {
+	outbox := mocks.NewMockOutboxRepository(suite.ctrl)
+	publisher := event.NewOutboxPublisher(outbox, []string{event.TargetShowcase, event.WebhookTarget("https://a.example.com")})
+	evt := newEvent(domain.EventVehicleSold, dto.OutputVehicleDTO{ID: "v1", Status: "SOLD"})
+
+	suite.T().Run("should store the envelope once for every target", func(t *testing.T) {
+		ids := map[string]bool{}
+		for _, target := range []string{"showcase", "webhook:https://a.example.com"} {
+			outbox.EXPECT().
+				Add(suite.ctx, gomock.Cond(func(e *domain.OutboxEvent) bool {
+					var stored domain.Event
+					_ = json.Unmarshal(e.Payload, &stored)
+					return e.Target == target && e.ID != "" && e.Type == domain.EventVehicleSold && e.AggregateID == "v1" &&
+						stored.ID == evt.ID && stored.Version == domain.EventSchemaVersion && !e.NextAttemptAt.IsZero()
+				})).
+				Do(func(_ context.Context, e *domain.OutboxEvent) { ids[e.ID] = true }).
+				Return(nil)
+		}
+
+		suite.NoError(publisher.Publish(suite.ctx, evt))
+		suite.Len(ids, 2)
+	})
+
+	suite.T().Run("should return error when the outbox write fails", func(t *testing.T) {
+		outbox.EXPECT().Add(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		suite.ErrorIs(publisher.Publish(suite.ctx, evt), assert.AnError)
+	})
+}
+
+func (suite *PublisherSuite) Test_SubscriptionPublisher() {
+	webhooks := mocks.NewMockWebhookRepository(suite.ctrl)
+	publisher := event.NewSubscriptionPublisher(webhooks)
+	evt := newEvent(domain.EventVehicleSold, dto.OutputVehicleDTO{ID: "v1", Status: "SOLD"})
+
+	suite.T().Run("should queue a delivery for every subscribed webhook", func(t *testing.T) {
+		subscriptions := []*domain.WebhookSubscription{
+			{ID: "wh-1", URL: "https://a.example.com"},
+			{ID: "wh-2", URL: "https://b.example.com"},
+		}
+		webhooks.EXPECT().ListSubscriptionsForEvent(suite.ctx, domain.EventVehicleSold).Return(subscriptions, nil)
+		for _, subscription := range subscriptions {
+			webhooks.EXPECT().
+				AddDelivery(suite.ctx, gomock.Cond(func(d *domain.WebhookDelivery) bool {
+					var stored domain.Event
+					_ = json.Unmarshal(d.Payload, &stored)
+					return d.SubscriptionID == subscription.ID && d.URL == subscription.URL && d.EventID == "evt-1" &&
+						d.Status == domain.DeliveryPending && d.RedeliveredFrom == "" && stored.ID == evt.ID
+				})).
+				Return(nil)
+		}
+
+		suite.NoError(publisher.Publish(suite.ctx, evt))
+	})
+
+	suite.T().Run("should do nothing without subscriptions", func(t *testing.T) {
+		webhooks.EXPECT().ListSubscriptionsForEvent(suite.ctx, domain.EventVehicleSold).Return(nil, nil)
+
+		suite.NoError(publisher.Publish(suite.ctx, evt))
+	})
+
+	suite.T().Run("should return error when recording a delivery fails", func(t *testing.T) {
+		webhooks.EXPECT().ListSubscriptionsForEvent(suite.ctx, domain.EventVehicleSold).
+			Return([]*domain.WebhookSubscription{{ID: "wh-1"}}, nil)
+		webhooks.EXPECT().AddDelivery(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		suite.ErrorIs(publisher.Publish(suite.ctx, evt), assert.AnError)
+	})
+}
+
+func (suite *PublisherSuite) Test_InMemoryPublisher() {
+	suite.T().Run("should record events and notify subscribers", func(t *testing.T) {
+		publisher := event.NewInMemoryPublisher()
+		var received []domain.EventType
+		publisher.Subscribe(func(ctx context.Context, e domain.Event) error {
+			received = append(received, e.Type)
+			return nil
+		})
+
+		suite.NoError(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleCreated, dto.OutputVehicleDTO{ID: "v1"})))
+		suite.NoError(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleSold, dto.OutputVehicleDTO{ID: "v1"})))
+
+		suite.Equal([]domain.EventType{domain.EventVehicleCreated, domain.EventVehicleSold}, received)
+		suite.Len(publisher.Events(), 2)
+	})
+
+	suite.T().Run("should return the subscriber error", func(t *testing.T) {
+		publisher := event.NewInMemoryPublisher()
+		publisher.Subscribe(func(ctx context.Context, e domain.Event) error { return assert.AnError })
+
+		suite.ErrorIs(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleCreated, dto.OutputVehicleDTO{ID: "v1"})), assert.AnError)
+	})
+}
+
+func (suite *PublisherSuite) Test_WebhookPublisher() {
+	evt := newEvent(domain.EventVehicleUpdated, dto.OutputVehicleDTO{ID: "v1", Price: domain.NewMoney(100000, "BRL"), Currency: "BRL"})
+
+	suite.T().Run("should post the versioned envelope", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal(http.MethodPost, r.Method)
+			suite.Equal("application/json", r.Header.Get("Content-Type"))
+			suite.Equal("evt-1", r.Header.Get("X-Event-ID"))
+			suite.Equal("vehicle.updated", r.Header.Get("X-Event-Type"))
+
+			body, _ := io.ReadAll(r.Body)
+			var received map[string]any
+			suite.NoError(json.Unmarshal(body, &received))
+			suite.Equal("evt-1", received["id"])
+			suite.Equal("vehicle.updated", received["type"])
+			suite.Equal(float64(1), received["version"])
+			suite.Equal("2024-05-01T12:00:00Z", received["occurred_at"])
+			suite.Equal("v1", received["payload"].(map[string]any)["id"])
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		publisher := event.NewWebhookPublisher(server.URL, time.Second)
+		suite.NoError(publisher.Publish(suite.ctx, evt))
+	})
+
+	suite.T().Run("should return error on non-success status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		publisher := event.NewWebhookPublisher(server.URL, time.Second)
+		err := publisher.Publish(suite.ctx, evt)
+		suite.Error(err)
+		suite.NotErrorIs(err, domain.ErrRejected)
+	})
+
+	suite.T().Run("should report a client error as rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		publisher := event.NewWebhookPublisher(server.URL, time.Second)
+		suite.ErrorIs(publisher.Publish(suite.ctx, evt), domain.ErrRejected)
+	})
+}
+
+func (suite *PublisherSuite) Test_ShowcasePublisher() {
+	showcaseClient := mclient.NewMockShowcaseClientInterface(suite.ctrl)
+	rates := musecase.NewMockExchangeRateProvider(suite.ctrl)
+	publisher := event.NewShowcasePublisher(showcaseClient, rates, []string{"BRL", "USD", "JPY"})
+	vehicle := dto.OutputVehicleDTO{ID: "v1", Brand: "Ford", Model: "Ka", Price: domain.NewMoney(5000000, ""), Currency: "BRL", Status: "AVAILABLE",
+		Mileage: 42000, FuelType: "FLEX", Transmission: "MANUAL", Doors: 4, EngineDisplacement: 999,
+		Images: []dto.OutputVehicleImageDTO{{ID: "img-1", URL: "http://media/v1/img-1.jpg", ThumbnailURL: "http://media/v1/img-1_thumb.jpg", Cover: true}}}
+	converted := []dto.ConvertedPriceDTO{{Price: domain.NewMoney(1000000, "USD"), Currency: "USD"}}
+	images := []dto.ListingImageDTO{{URL: "http://media/v1/img-1.jpg", ThumbnailURL: "http://media/v1/img-1_thumb.jpg", Cover: true}}
+
+	suite.T().Run("should create the listing for created vehicles", func(t *testing.T) {
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "USD").Return(big.NewRat(1, 5), nil)
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "JPY").Return(nil, domain.ErrUnsupportedCurrency)
+		showcaseClient.EXPECT().
+			CreateListing(gomock.Any(), dto.CreateListingDTO{VehicleID: "v1", Brand: "Ford", Model: "Ka", Price: domain.NewMoney(5000000, ""), Currency: "BRL", ConvertedPrices: converted, Status: "AVAILABLE",
+				Mileage: 42000, FuelType: "FLEX", Transmission: "MANUAL", Doors: 4, EngineDisplacement: 999, Images: images}).
+			Return(nil)
+
+		suite.NoError(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleCreated, vehicle)))
+	})
+
+	suite.T().Run("should update the listing for updated vehicles", func(t *testing.T) {
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "USD").Return(big.NewRat(1, 5), nil)
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "JPY").Return(nil, domain.ErrUnsupportedCurrency)
+		showcaseClient.EXPECT().
+			UpdateListing(gomock.Any(), "v1", dto.UpdateListingDTO{Brand: "Ford", Model: "Ka", Price: domain.NewMoney(5000000, ""), Currency: "BRL", ConvertedPrices: converted,
+				Mileage: 42000, FuelType: "FLEX", Transmission: "MANUAL", Doors: 4, EngineDisplacement: 999, Images: images}).
+			Return(nil)
+
+		suite.NoError(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleUpdated, vehicle)))
+	})
+
+	suite.T().Run("should clear the listing images when the vehicle has none", func(t *testing.T) {
+		withoutImages := vehicle
+		withoutImages.Images = nil
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "USD").Return(big.NewRat(1, 5), nil)
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "JPY").Return(nil, domain.ErrUnsupportedCurrency)
+		showcaseClient.EXPECT().
+			UpdateListing(gomock.Any(), "v1", gomock.Cond(func(data dto.UpdateListingDTO) bool {
+				encoded, _ := json.Marshal(data)
+				return strings.Contains(string(encoded), `"images":[]`)
+			})).
+			Return(nil)
+
+		suite.NoError(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleUpdated, withoutImages)))
+	})
+
+	suite.T().Run("should leave out the prices that are out of range", func(t *testing.T) {
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "USD").Return(big.NewRat(1<<62, 1), nil)
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "JPY").Return(nil, domain.ErrUnsupportedCurrency)
+		showcaseClient.EXPECT().
+			UpdateListing(gomock.Any(), "v1", gomock.Cond(func(data dto.UpdateListingDTO) bool { return len(data.ConvertedPrices) == 0 })).
+			Return(nil)
+
+		suite.NoError(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleUpdated, vehicle)))
+	})
+
+	suite.T().Run("should retry later when the exchange rates cannot be read", func(t *testing.T) {
+		rates.EXPECT().Rate(gomock.Any(), "BRL", "USD").Return(nil, assert.AnError)
+
+		suite.ErrorIs(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleUpdated, vehicle)), assert.AnError)
+	})
+
+	suite.T().Run("should update the listing status for status events", func(t *testing.T) {
+		for _, eventType := range []domain.EventType{domain.EventVehicleReserved, domain.EventVehicleSold, domain.EventVehicleReleased} {
+			showcaseClient.EXPECT().
+				UpdateListingStatus(gomock.Any(), "v1", dto.UpdateListingStatusDTO{Status: "AVAILABLE"}).
+				Return(nil)
+
+			suite.NoError(publisher.Publish(suite.ctx, newEvent(eventType, vehicle)))
+		}
+	})
+
+	suite.T().Run("should delete the listing for deleted vehicles", func(t *testing.T) {
+		showcaseClient.EXPECT().DeleteListing(gomock.Any(), "v1").Return(assert.AnError)
+
+		suite.ErrorIs(publisher.Publish(suite.ctx, newEvent(domain.EventVehicleDeleted, vehicle)), assert.AnError)
+	})
+
+	suite.T().Run("should reject payloads it cannot decode", func(t *testing.T) {
+		evt := newEvent(domain.EventVehicleCreated, vehicle)
+		evt.Payload = json.RawMessage(`"oops"`)
+
+		suite.Error(publisher.Publish(suite.ctx, evt))
+	})
+}
@@ -0,0 +1,98 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/client"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+)
+
+type showcasePublisher struct {
+	showcaseClient client.ShowcaseClientInterface
+	rates          usecase.ExchangeRateProvider
+	currencies     []string
+}
+
+// NewShowcasePublisher translates catalog events into the listing calls the
+// showcase service understands. The event ID is used as idempotency key, so
+// redelivering an event never duplicates a listing. Listing prices are also
+// sent converted to each of currencies at the rates of the moment.
+func NewShowcasePublisher(showcaseClient client.ShowcaseClientInterface, rates usecase.ExchangeRateProvider, currencies []string) usecase.EventPublisher {
+	return &showcasePublisher{
+		showcaseClient: showcaseClient,
+		rates:          rates,
+		currencies:     currencies,
+	}
+}
+
+func (p *showcasePublisher) Publish(ctx context.Context, event domain.Event) error {
+	var vehicle dto.OutputVehicleDTO
+	if err := json.Unmarshal(event.Payload, &vehicle); err != nil {
+		return fmt.Errorf("%w: decoding %s payload: %v", domain.ErrRejected, event.Type, err)
+	}
+
+	ctx = client.WithIdempotencyKey(ctx, event.ID)
+
+	switch event.Type {
+	case domain.EventVehicleCreated:
+		converted, err := usecase.ListingPrices(ctx, p.rates, p.currencies, vehicle.ID, vehicle.Price.WithCurrency(vehicle.Currency))
+		if err != nil {
+			return err
+		}
+		listingDTO := dto.CreateListingDTO{
+			VehicleID:       vehicle.ID,
+			Brand:           vehicle.Brand,
+			Model:           vehicle.Model,
+			Price:           vehicle.Price,
+			Currency:        vehicle.Currency,
+			ConvertedPrices: converted,
+			Status:          vehicle.Status,
+
+			Mileage:            vehicle.Mileage,
+			FuelType:           vehicle.FuelType,
+			Transmission:       vehicle.Transmission,
+			BodyType:           vehicle.BodyType,
+			Doors:              vehicle.Doors,
+			EngineDisplacement: vehicle.EngineDisplacement,
+			Condition:          vehicle.Condition,
+			Images:             usecase.ListingImages(vehicle.Images),
+		}
+		return p.showcaseClient.CreateListing(ctx, listingDTO)
+	case domain.EventVehicleUpdated:
+		converted, err := usecase.ListingPrices(ctx, p.rates, p.currencies, vehicle.ID, vehicle.Price.WithCurrency(vehicle.Currency))
+		if err != nil {
+			return err
+		}
+		listingDTO := dto.UpdateListingDTO{
+			Brand:           vehicle.Brand,
+			Model:           vehicle.Model,
+			Price:           vehicle.Price,
+			Currency:        vehicle.Currency,
+			ConvertedPrices: converted,
+
+			Mileage:            vehicle.Mileage,
+			FuelType:           vehicle.FuelType,
+			Transmission:       vehicle.Transmission,
+			BodyType:           vehicle.BodyType,
+			Doors:              vehicle.Doors,
+			EngineDisplacement: vehicle.EngineDisplacement,
+			Condition:          vehicle.Condition,
+			Images:             usecase.ListingImages(vehicle.Images),
+		}
+		return p.showcaseClient.UpdateListing(ctx, vehicle.ID, listingDTO)
+	case domain.EventVehicleReserved, domain.EventVehicleSold, domain.EventVehicleReleased:
+		listingDTO := dto.UpdateListingStatusDTO{
+			Status: vehicle.Status,
+		}
+		return p.showcaseClient.UpdateListingStatus(ctx, vehicle.ID, listingDTO)
+	case domain.EventVehicleDeleted:
+		return p.showcaseClient.DeleteListing(ctx, vehicle.ID)
+	default:
+		// The showcase has no use for other event types.
+		return nil
+	}
+}
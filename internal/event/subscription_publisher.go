@@ -0,0 +1,58 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/google/uuid"
+)
+
+type subscriptionPublisher struct {
+	webhooks repository.WebhookRepository
+}
+
+// NewSubscriptionPublisher queues a delivery of every event for each webhook
+// subscribed to its type. The webhook delivery worker sends them afterwards.
+func NewSubscriptionPublisher(webhooks repository.WebhookRepository) usecase.EventPublisher {
+	return &subscriptionPublisher{
+		webhooks: webhooks,
+	}
+}
+
+func (p *subscriptionPublisher) Publish(ctx context.Context, event domain.Event) error {
+	subscriptions, err := p.webhooks.ListSubscriptionsForEvent(ctx, event.Type)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	envelope, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		delivery := &domain.WebhookDelivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			URL:            subscription.URL,
+			Payload:        envelope,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+
+		err = p.webhooks.AddDelivery(ctx, delivery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+)
+
+const defaultWebhookTimeout = 5 * time.Second
+
+type webhookPublisher struct {
+	client *http.Client
+	url    string
+}
+
+// NewWebhookPublisher POSTs every event envelope as JSON to url.
+func NewWebhookPublisher(url string, timeout time.Duration) usecase.EventPublisher {
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &webhookPublisher{
+		client: &http.Client{Timeout: timeout},
+		url:    url,
+	}
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, event domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", event.ID)
+	req.Header.Set("X-Event-Type", string(event.Type))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err = errors.New("webhook returned non-success status: " + resp.Status)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return fmt.Errorf("%w: %v", domain.ErrRejected, err)
+		}
+		return err
+	}
+
+	return nil
+}
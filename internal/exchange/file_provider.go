@@ -0,0 +1,150 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultReloadInterval = 30 * time.Second
+
+type FileRateProviderConfig struct {
+	ReloadInterval time.Duration
+}
+
+// rateFile is the layout of a rates file, in YAML or JSON:
+//
+//	base: BRL
+//	rates:
+//	  USD: 0.1850
+//	  EUR: 0.1710
+//
+// Rates are read as written, without going through float64.
+type rateFile struct {
+	Base  string            `yaml:"base"`
+	Rates map[string]string `yaml:"rates"`
+}
+
+// FileRateProvider converts with the rates of a local file, so conversions
+// keep working offline. Run watches the file and swaps in the new rates when
+// it changes; a file that fails to load leaves the previous rates in place.
+type FileRateProvider struct {
+	path   string
+	config FileRateProviderConfig
+
+	mu      sync.RWMutex
+	table   RateTable
+	modTime time.Time
+	size    int64
+}
+
+// NewFileRateProvider loads the rates at path, failing when they cannot be
+// read.
+func NewFileRateProvider(path string, config FileRateProviderConfig) (*FileRateProvider, error) {
+	if config.ReloadInterval <= 0 {
+		config.ReloadInterval = defaultReloadInterval
+	}
+
+	p := &FileRateProvider{
+		path:   path,
+		config: config,
+	}
+	if _, err := p.ReloadIfChanged(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *FileRateProvider) Rate(_ context.Context, from, to string) (*big.Rat, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.table.Rate(from, to)
+}
+
+// Run reloads the rates whenever the file changes, until ctx is cancelled.
+func (p *FileRateProvider) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := p.ReloadIfChanged()
+		if err != nil {
+			log.Printf("Warning: keeping previous exchange rates: %v", err)
+		} else if reloaded {
+			log.Printf("Info: exchange rates reloaded from %s", p.path)
+		}
+	}
+}
+
+// ReloadIfChanged reads the file again when its modification time or size
+// differ from the last load, and reports whether the rates were replaced.
+func (p *FileRateProvider) ReloadIfChanged() (bool, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return false, fmt.Errorf("reading exchange rates: %w", err)
+	}
+
+	p.mu.RLock()
+	unchanged := info.ModTime().Equal(p.modTime) && info.Size() == p.size
+	p.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	table, err := loadRateTable(p.path)
+	if err != nil {
+		return false, err
+	}
+
+	p.mu.Lock()
+	p.table = table
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	p.mu.Unlock()
+
+	return true, nil
+}
+
+func loadRateTable(path string) (RateTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RateTable{}, fmt.Errorf("reading exchange rates: %w", err)
+	}
+
+	// JSON is valid YAML, so one decoder reads both formats.
+	var file rateFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return RateTable{}, fmt.Errorf("decoding exchange rates %s: %w", path, err)
+	}
+	if file.Base == "" {
+		return RateTable{}, fmt.Errorf("exchange rates %s: base currency is required", path)
+	}
+
+	table := RateTable{
+		Base:  strings.ToUpper(file.Base),
+		Rates: make(map[string]*big.Rat, len(file.Rates)),
+	}
+	for currency, value := range file.Rates {
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || rate.Sign() <= 0 {
+			return RateTable{}, fmt.Errorf("exchange rates %s: invalid rate %q for %s", path, value, currency)
+		}
+		table.Rates[strings.ToUpper(currency)] = rate
+	}
+
+	return table, nil
+}
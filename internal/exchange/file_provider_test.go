@@ -0,0 +1,126 @@
+package exchange_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/exchange"
+)
+
+func writeRates(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write rates: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to touch rates: %v", err)
+	}
+}
+
+func assertRate(t *testing.T, provider interface {
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}, from, to, want string) {
+	t.Helper()
+	rate, err := provider.Rate(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("expected no error converting %s to %s, got %v", from, to, err)
+	}
+	expected, _ := new(big.Rat).SetString(want)
+	if rate.Cmp(expected) != 0 {
+		t.Errorf("expected %s to %s rate %s, got %s", from, to, want, rate.FloatString(6))
+	}
+}
+
+func TestStaticRateProvider(t *testing.T) {
+	provider := exchange.NewStaticRateProvider(exchange.RateTable{
+		Base: "BRL",
+		Rates: map[string]*big.Rat{
+			"USD": big.NewRat(1, 5),
+			"EUR": big.NewRat(1, 6),
+		},
+	})
+
+	assertRate(t, provider, "BRL", "USD", "0.2")
+	assertRate(t, provider, "USD", "BRL", "5")
+	assertRate(t, provider, "USD", "EUR", "5/6")
+	assertRate(t, provider, "JPY", "JPY", "1")
+
+	_, err := provider.Rate(context.Background(), "BRL", "JPY")
+	if !errors.Is(err, domain.ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestFileRateProvider(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+
+	t.Run("should load rates from YAML", func(t *testing.T) {
+		path := filepath.Join(dir, "rates.yaml")
+		writeRates(t, path, "base: brl\nrates:\n  usd: 0.1850\n  EUR: \"0.1710\"\n", start)
+
+		provider, err := exchange.NewFileRateProvider(path, exchange.FileRateProviderConfig{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		assertRate(t, provider, "BRL", "USD", "0.185")
+		assertRate(t, provider, "BRL", "EUR", "0.171")
+	})
+
+	t.Run("should load rates from JSON", func(t *testing.T) {
+		path := filepath.Join(dir, "rates.json")
+		writeRates(t, path, `{"base": "USD", "rates": {"BRL": 5.4}}`, start)
+
+		provider, err := exchange.NewFileRateProvider(path, exchange.FileRateProviderConfig{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		assertRate(t, provider, "USD", "BRL", "5.4")
+	})
+
+	t.Run("should fail on invalid rates", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.yaml")
+		writeRates(t, path, "base: BRL\nrates:\n  USD: -1\n", start)
+
+		if _, err := exchange.NewFileRateProvider(path, exchange.FileRateProviderConfig{}); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		if _, err := exchange.NewFileRateProvider(filepath.Join(dir, "missing.yaml"), exchange.FileRateProviderConfig{}); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	t.Run("should reload changed rates and keep them when the file breaks", func(t *testing.T) {
+		path := filepath.Join(dir, "reload.yaml")
+		writeRates(t, path, "base: BRL\nrates:\n  USD: 0.2\n", start)
+
+		provider, err := exchange.NewFileRateProvider(path, exchange.FileRateProviderConfig{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		reloaded, err := provider.ReloadIfChanged()
+		if err != nil || reloaded {
+			t.Errorf("expected no reload of an unchanged file, got %v, %v", reloaded, err)
+		}
+
+		writeRates(t, path, "base: BRL\nrates:\n  USD: 0.25\n", start.Add(time.Minute))
+		reloaded, err = provider.ReloadIfChanged()
+		if err != nil || !reloaded {
+			t.Fatalf("expected a reload, got %v, %v", reloaded, err)
+		}
+		assertRate(t, provider, "BRL", "USD", "0.25")
+
+		writeRates(t, path, "base: [", start.Add(2*time.Minute))
+		if _, err := provider.ReloadIfChanged(); err == nil {
+			t.Errorf("expected error, got nil")
+		}
+		assertRate(t, provider, "BRL", "USD", "0.25")
+	})
+}
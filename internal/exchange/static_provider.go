@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+)
+
+// RateTable quotes currencies against Base: Rates[code] is the value of one
+// unit of Base in code. Rates between two quoted currencies are derived
+// through Base.
+type RateTable struct {
+	Base  string
+	Rates map[string]*big.Rat
+}
+
+// Rate returns the value of one unit of from in to.
+func (t RateTable) Rate(from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	fromQuote, ok := t.quote(from)
+	if !ok {
+		return nil, fmt.Errorf("%w: no exchange rate for %q", domain.ErrUnsupportedCurrency, from)
+	}
+	toQuote, ok := t.quote(to)
+	if !ok {
+		return nil, fmt.Errorf("%w: no exchange rate for %q", domain.ErrUnsupportedCurrency, to)
+	}
+
+	return new(big.Rat).Quo(toQuote, fromQuote), nil
+}
+
+func (t RateTable) quote(currency string) (*big.Rat, bool) {
+	if currency == t.Base {
+		return big.NewRat(1, 1), true
+	}
+	rate, ok := t.Rates[currency]
+	return rate, ok
+}
+
+type staticRateProvider struct {
+	table RateTable
+}
+
+// NewStaticRateProvider converts with a fixed rate table. With no rates it
+// only converts a currency to itself.
+func NewStaticRateProvider(table RateTable) usecase.ExchangeRateProvider {
+	return &staticRateProvider{
+		table: table,
+	}
+}
+
+func (p *staticRateProvider) Rate(_ context.Context, from, to string) (*big.Rat, error) {
+	return p.table.Rate(from, to)
+}
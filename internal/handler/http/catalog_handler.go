@@ -0,0 +1,199 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/go-chi/chi"
+)
+
+type CatalogHandler struct {
+	useCase usecase.CatalogUseCaseInterface
+}
+
+func NewCatalogHandler(useCase usecase.CatalogUseCaseInterface) *CatalogHandler {
+	return &CatalogHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *CatalogHandler) CreateBrand(w http.ResponseWriter, r *http.Request) {
+	var input dto.InputBrandDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.CreateBrand(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err, "Failed to create brand")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *CatalogHandler) ListBrands(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.ListBrands(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to list brands")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CatalogHandler) GetBrand(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "BrandID is required")
+		return
+	}
+
+	output, err := h.useCase.GetBrand(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to get brand")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CatalogHandler) UpdateBrand(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "BrandID is required")
+		return
+	}
+
+	var input dto.InputBrandDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.UpdateBrand(r.Context(), id, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to update brand")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CatalogHandler) DeleteBrand(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "BrandID is required")
+		return
+	}
+
+	err := h.useCase.DeleteBrand(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to delete brand")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CatalogHandler) CreateModel(w http.ResponseWriter, r *http.Request) {
+	brandID := chi.URLParam(r, "id")
+	if brandID == "" {
+		writeBadRequest(w, r, "BrandID is required")
+		return
+	}
+
+	var input dto.InputModelDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.CreateModel(r.Context(), brandID, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to create model")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *CatalogHandler) ListModels(w http.ResponseWriter, r *http.Request) {
+	brandID := chi.URLParam(r, "id")
+	if brandID == "" {
+		writeBadRequest(w, r, "BrandID is required")
+		return
+	}
+
+	output, err := h.useCase.ListModels(r.Context(), brandID)
+	if err != nil {
+		writeError(w, r, err, "Failed to list models")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CatalogHandler) GetModel(w http.ResponseWriter, r *http.Request) {
+	brandID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "modelId")
+	if brandID == "" || id == "" {
+		writeBadRequest(w, r, "BrandID and ModelID are required")
+		return
+	}
+
+	output, err := h.useCase.GetModel(r.Context(), brandID, id)
+	if err != nil {
+		writeError(w, r, err, "Failed to get model")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CatalogHandler) UpdateModel(w http.ResponseWriter, r *http.Request) {
+	brandID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "modelId")
+	if brandID == "" || id == "" {
+		writeBadRequest(w, r, "BrandID and ModelID are required")
+		return
+	}
+
+	var input dto.InputModelDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.UpdateModel(r.Context(), brandID, id, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to update model")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *CatalogHandler) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	brandID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "modelId")
+	if brandID == "" || id == "" {
+		writeBadRequest(w, r, "BrandID and ModelID are required")
+		return
+	}
+
+	err := h.useCase.DeleteModel(r.Context(), brandID, id)
+	if err != nil {
+		writeError(w, r, err, "Failed to delete model")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,191 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CatalogHandlerSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	useCase *mocks.MockCatalogUseCaseInterface
+	handler *h.CatalogHandler
+}
+
+func (suite *CatalogHandlerSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.useCase = mocks.NewMockCatalogUseCaseInterface(ctrl)
+	suite.handler = h.NewCatalogHandler(suite.useCase)
+}
+
+func Test_CatalogHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CatalogHandlerSuite))
+}
+
+func (suite *CatalogHandlerSuite) Test_Brands() {
+	suite.T().Run("CreateBrand - Success", func(t *testing.T) {
+		input := dto.InputBrandDTO{Name: "Volkswagen", Aliases: []string{"VW"}}
+		expectedOutput := &dto.OutputBrandDTO{ID: "brand-1", Name: "Volkswagen", Aliases: []string{"VW"}}
+		suite.useCase.EXPECT().CreateBrand(suite.ctx, input).Return(expectedOutput, nil)
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPost, "/brands", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		suite.handler.CreateBrand(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusCreated, resp.StatusCode)
+		var got dto.OutputBrandDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("CreateBrand - Invalid Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/brands", strings.NewReader("invalid-json"))
+		w := httptest.NewRecorder()
+
+		suite.handler.CreateBrand(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("CreateBrand - Conflict", func(t *testing.T) {
+		suite.useCase.EXPECT().CreateBrand(suite.ctx, gomock.Any()).
+			Return(nil, fmt.Errorf("%w: \"VW\" already resolves to brand Volkswagen", domain.ErrConflict))
+
+		req := httptest.NewRequest(http.MethodPost, "/brands", strings.NewReader(`{"name":"VW"}`))
+		w := httptest.NewRecorder()
+
+		suite.handler.CreateBrand(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.T().Run("UpdateBrand - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().UpdateBrand(gomock.Any(), "missing", dto.InputBrandDTO{Name: "Fiat"}).Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodPut, "/brands/missing", strings.NewReader(`{"name":"Fiat"}`))
+		req = muxSetURLParam(req, "id", "missing")
+		w := httptest.NewRecorder()
+
+		suite.handler.UpdateBrand(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.T().Run("DeleteBrand - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/brands/", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.DeleteBrand(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *CatalogHandlerSuite) Test_Models() {
+	suite.T().Run("CreateModel - Success", func(t *testing.T) {
+		input := dto.InputModelDTO{Name: "Gol"}
+		suite.useCase.EXPECT().CreateModel(gomock.Any(), "brand-1", input).
+			Return(&dto.OutputModelDTO{ID: "model-1", BrandID: "brand-1", Name: "Gol", Aliases: []string{}}, nil)
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPost, "/brands/brand-1/models", bytes.NewReader(body))
+		req = muxSetURLParam(req, "id", "brand-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.CreateModel(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.T().Run("ListModels - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().ListModels(gomock.Any(), "brand-1").
+			Return(&dto.OutputListModelsDTO{Items: []dto.OutputModelDTO{{ID: "model-1"}}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/brands/brand-1/models", nil)
+		req = muxSetURLParam(req, "id", "brand-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.ListModels(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputListModelsDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Len(got.Items, 1)
+	})
+
+	suite.T().Run("DeleteModel - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().DeleteModel(gomock.Any(), "brand-1", "model-1").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/brands/brand-1/models/model-1", nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"id", "modelId"},
+				Values: []string{"brand-1", "model-1"},
+			},
+		}))
+		w := httptest.NewRecorder()
+
+		suite.handler.DeleteModel(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.T().Run("GetModel - Missing Model ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/brands/brand-1/models/", nil)
+		req = muxSetURLParam(req, "id", "brand-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.GetModel(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/storage"
+)
+
+const problemContentType = "application/problem+json"
+
+const (
+	problemTypeBadRequest       = "/problems/bad-request"
+	problemTypeValidation       = "/problems/validation-error"
+	problemTypeNotFound         = "/problems/not-found"
+	problemTypeMethodNotAllowed = "/problems/method-not-allowed"
+	problemTypeConflict         = "/problems/conflict"
+	problemTypeUnsupportedMedia = "/problems/unsupported-media-type"
+	problemTypePayloadTooLarge  = "/problems/payload-too-large"
+	problemTypePrecondition     = "/problems/precondition-failed"
+	problemTypeInternal         = "/problems/internal-error"
+)
+
+func writeProblem(w http.ResponseWriter, r *http.Request, problem dto.ProblemDetailsDTO) {
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	problem.Instance = r.URL.RequestURI()
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+func writeBadRequest(w http.ResponseWriter, r *http.Request, detail string) {
+	writeProblem(w, r, dto.ProblemDetailsDTO{
+		Type:   problemTypeBadRequest,
+		Title:  "Bad request",
+		Status: http.StatusBadRequest,
+		Detail: detail,
+	})
+}
+
+func writeUnsupportedMediaType(w http.ResponseWriter, r *http.Request, detail string) {
+	writeProblem(w, r, dto.ProblemDetailsDTO{
+		Type:   problemTypeUnsupportedMedia,
+		Title:  "Unsupported media type",
+		Status: http.StatusUnsupportedMediaType,
+		Detail: detail,
+	})
+}
+
+func writePayloadTooLarge(w http.ResponseWriter, r *http.Request, detail string) {
+	writeProblem(w, r, dto.ProblemDetailsDTO{
+		Type:   problemTypePayloadTooLarge,
+		Title:  "Payload too large",
+		Status: http.StatusRequestEntityTooLarge,
+		Detail: detail,
+	})
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error, internalMessage string) {
+	writeProblem(w, r, problemFromError(err, internalMessage))
+}
+
+func problemFromError(err error, internalMessage string) dto.ProblemDetailsDTO {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		problem := dto.ProblemDetailsDTO{
+			Type:   problemTypeValidation,
+			Title:  "Validation failed",
+			Status: http.StatusBadRequest,
+			Detail: err.Error(),
+		}
+		var validationErrs domain.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			for _, fieldErr := range validationErrs {
+				problem.Errors = append(problem.Errors, dto.FieldErrorDTO{
+					Field:   fieldErr.Field,
+					Code:    fieldErr.Code,
+					Message: fieldErr.Message,
+				})
+			}
+		}
+		return problem
+	case errors.Is(err, storage.ErrInvalidKey):
+		// The key of a blob is built from the IDs in the path, so an invalid
+		// one means the request named something that cannot exist.
+		return dto.ProblemDetailsDTO{
+			Type:   problemTypeBadRequest,
+			Title:  "Bad request",
+			Status: http.StatusBadRequest,
+			Detail: "Invalid ID",
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		return dto.ProblemDetailsDTO{
+			Type:   problemTypeNotFound,
+			Title:  "Resource not found",
+			Status: http.StatusNotFound,
+			Detail: err.Error(),
+		}
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return dto.ProblemDetailsDTO{
+			Type:   problemTypePrecondition,
+			Title:  "Precondition failed",
+			Status: http.StatusPreconditionFailed,
+			Detail: err.Error(),
+		}
+	case errors.Is(err, domain.ErrConflict):
+		problem := dto.ProblemDetailsDTO{
+			Type:   problemTypeConflict,
+			Title:  "Conflict",
+			Status: http.StatusConflict,
+			Detail: err.Error(),
+		}
+		var duplicateErr *domain.DuplicateVehicleError
+		if errors.As(err, &duplicateErr) {
+			problem.ConflictingID = duplicateErr.VehicleID
+		}
+		return problem
+	default:
+		log.Printf("Error: %s: %v", internalMessage, err)
+		return dto.ProblemDetailsDTO{
+			Type:   problemTypeInternal,
+			Title:  "Internal server error",
+			Status: http.StatusInternalServerError,
+			Detail: internalMessage,
+		}
+	}
+}
+
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, dto.ProblemDetailsDTO{
+		Type:   problemTypeNotFound,
+		Title:  "Resource not found",
+		Status: http.StatusNotFound,
+		Detail: "no route matches " + r.Method + " " + r.URL.Path,
+	})
+}
+
+func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, dto.ProblemDetailsDTO{
+		Type:   problemTypeMethodNotAllowed,
+		Title:  "Method not allowed",
+		Status: http.StatusMethodNotAllowed,
+		Detail: "method " + r.Method + " is not allowed on " + r.URL.Path,
+	})
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+)
+
+func formatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch returns the vehicle version required by the If-Match header,
+// or zero when the header is absent or "*". Weak and malformed tags can never
+// match under the strong comparison If-Match requires.
+func parseIfMatch(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return 0, fmt.Errorf("%w: If-Match %s is not a valid vehicle ETag", domain.ErrPreconditionFailed, header)
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("%w: If-Match %s is not a valid vehicle ETag", domain.ErrPreconditionFailed, header)
+	}
+
+	return version, nil
+}
+
+func writeVehicle(w http.ResponseWriter, status int, output *dto.OutputVehicleDTO) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", formatETag(output.Version))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(output)
+}
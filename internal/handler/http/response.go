@@ -0,0 +1,28 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+)
+
+// actorHeader identifies who makes a change, for the vehicle audit trail.
+const actorHeader = "X-Actor"
+
+func writeJSON(w http.ResponseWriter, status int, output any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(output)
+}
+
+// actorMiddleware attributes the changes made by a request to the actor named
+// in its X-Actor header.
+func actorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(actorHeader); actor != "" {
+			r = r.WithContext(usecase.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
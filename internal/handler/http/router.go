@@ -8,12 +8,51 @@ import (
 	_ "github.com/NicolasNSC/catalog-service-fiap/docs"
 )
 
-func SetupRoutes(router *chi.Mux, vehicleHandler *VehicleHandler) {
+func SetupRoutes(router *chi.Mux, vehicleHandler *VehicleHandler, webhookHandler *WebhookHandler, catalogHandler *CatalogHandler, imageHandler *VehicleImageHandler, importHandler *VehicleImportHandler) {
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
+	router.Use(actorMiddleware)
+
+	router.NotFound(notFoundHandler)
+	router.MethodNotAllowed(methodNotAllowedHandler)
 
 	router.Get("/swagger/*", httpSwagger.WrapHandler)
 
+	router.Get("/vehicles", vehicleHandler.List)
 	router.Post("/vehicles/add", vehicleHandler.Create)
+	router.Get("/vehicles/price-stats", vehicleHandler.PriceStats)
+	router.Post("/vehicles/import", importHandler.Import)
+	router.Get("/vehicles/import/{jobId}", importHandler.GetJob)
+	router.Get("/vehicles/{id}", vehicleHandler.GetByID)
 	router.Put("/vehicles/{id}", vehicleHandler.Update)
+	router.Patch("/vehicles/{id}", vehicleHandler.Patch)
+	router.Delete("/vehicles/{id}", vehicleHandler.Delete)
+	router.Post("/vehicles/{id}/reserve", vehicleHandler.Reserve)
+	router.Post("/vehicles/{id}/sell", vehicleHandler.Sell)
+	router.Post("/vehicles/{id}/release", vehicleHandler.Release)
+	router.Get("/vehicles/{id}/history", vehicleHandler.History)
+	router.Get("/vehicles/{id}/prices", vehicleHandler.Prices)
+	router.Get("/vehicles/{id}/images", imageHandler.List)
+	router.Post("/vehicles/{id}/images", imageHandler.Upload)
+	router.Put("/vehicles/{id}/images/order", imageHandler.Reorder)
+	router.Post("/vehicles/{id}/images/{imageId}/cover", imageHandler.SetCover)
+	router.Delete("/vehicles/{id}/images/{imageId}", imageHandler.Delete)
+
+	router.Get("/webhooks", webhookHandler.List)
+	router.Post("/webhooks", webhookHandler.Create)
+	router.Get("/webhooks/{id}", webhookHandler.GetByID)
+	router.Delete("/webhooks/{id}", webhookHandler.Delete)
+	router.Get("/webhooks/{id}/deliveries", webhookHandler.ListDeliveries)
+	router.Post("/webhooks/{id}/deliveries/{deliveryId}/redeliver", webhookHandler.Redeliver)
+
+	router.Get("/brands", catalogHandler.ListBrands)
+	router.Post("/brands", catalogHandler.CreateBrand)
+	router.Get("/brands/{id}", catalogHandler.GetBrand)
+	router.Put("/brands/{id}", catalogHandler.UpdateBrand)
+	router.Delete("/brands/{id}", catalogHandler.DeleteBrand)
+	router.Get("/brands/{id}/models", catalogHandler.ListModels)
+	router.Post("/brands/{id}/models", catalogHandler.CreateModel)
+	router.Get("/brands/{id}/models/{modelId}", catalogHandler.GetModel)
+	router.Put("/brands/{id}/models/{modelId}", catalogHandler.UpdateModel)
+	router.Delete("/brands/{id}/models/{modelId}", catalogHandler.DeleteModel)
 }
@@ -0,0 +1,127 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetupRoutes_ProblemResponses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := chi.NewRouter()
+	h.SetupRoutes(router,
+		h.NewVehicleHandler(mocks.NewMockVehicleUseCaseInterface(ctrl)),
+		h.NewWebhookHandler(mocks.NewMockWebhookUseCaseInterface(ctrl)),
+		h.NewCatalogHandler(mocks.NewMockCatalogUseCaseInterface(ctrl)),
+		h.NewVehicleImageHandler(mocks.NewMockVehicleImageUseCaseInterface(ctrl)),
+		h.NewVehicleImportHandler(mocks.NewMockVehicleImportUseCaseInterface(ctrl)),
+	)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedType string
+	}{
+		{"unknown route", http.MethodGet, "/unknown", http.StatusNotFound, "/problems/not-found"},
+		{"method not allowed", http.MethodDelete, "/vehicles", http.StatusMethodNotAllowed, "/problems/method-not-allowed"},
+		{"webhook method not allowed", http.MethodPut, "/webhooks", http.StatusMethodNotAllowed, "/problems/method-not-allowed"},
+		{"brand method not allowed", http.MethodPatch, "/brands/123", http.StatusMethodNotAllowed, "/problems/method-not-allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedCode, resp.StatusCode)
+			assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+			var problem dto.ProblemDetailsDTO
+			err := json.NewDecoder(resp.Body).Decode(&problem)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedType, problem.Type)
+			assert.Equal(t, tt.expectedCode, problem.Status)
+			assert.Equal(t, tt.path, problem.Instance)
+			assert.NotEmpty(t, problem.Title)
+			assert.NotEmpty(t, problem.Detail)
+		})
+	}
+}
+
+func TestSetupRoutes_Actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	vehicleUseCase := mocks.NewMockVehicleUseCaseInterface(ctrl)
+	router := chi.NewRouter()
+	h.SetupRoutes(router, h.NewVehicleHandler(vehicleUseCase), h.NewWebhookHandler(mocks.NewMockWebhookUseCaseInterface(ctrl)), h.NewCatalogHandler(mocks.NewMockCatalogUseCaseInterface(ctrl)), h.NewVehicleImageHandler(mocks.NewMockVehicleImageUseCaseInterface(ctrl)), h.NewVehicleImportHandler(mocks.NewMockVehicleImportUseCaseInterface(ctrl)))
+
+	t.Run("should attribute the change to the X-Actor header", func(t *testing.T) {
+		vehicleUseCase.EXPECT().
+			Delete(gomock.Cond(func(ctx context.Context) bool {
+				return usecase.ActorFromContext(ctx) == "ana"
+			}), "123").
+			Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/123", nil)
+		req.Header.Set("X-Actor", "ana")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestSetupRoutes_PriceStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	vehicleUseCase := mocks.NewMockVehicleUseCaseInterface(ctrl)
+	router := chi.NewRouter()
+	h.SetupRoutes(router, h.NewVehicleHandler(vehicleUseCase), h.NewWebhookHandler(mocks.NewMockWebhookUseCaseInterface(ctrl)), h.NewCatalogHandler(mocks.NewMockCatalogUseCaseInterface(ctrl)), h.NewVehicleImageHandler(mocks.NewMockVehicleImageUseCaseInterface(ctrl)), h.NewVehicleImportHandler(mocks.NewMockVehicleImportUseCaseInterface(ctrl)))
+
+	t.Run("should not treat price-stats as a vehicle ID", func(t *testing.T) {
+		vehicleUseCase.EXPECT().
+			PriceStats(gomock.Any(), dto.InputPriceStatsDTO{Brand: "Ford", Model: "Focus"}).
+			Return(&dto.OutputListPriceStatsDTO{Items: []dto.OutputPriceStatsDTO{}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/price-stats?brand=Ford&model=Focus", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestSetupRoutes_ImportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	importUseCase := mocks.NewMockVehicleImportUseCaseInterface(ctrl)
+	router := chi.NewRouter()
+	h.SetupRoutes(router, h.NewVehicleHandler(mocks.NewMockVehicleUseCaseInterface(ctrl)), h.NewWebhookHandler(mocks.NewMockWebhookUseCaseInterface(ctrl)), h.NewCatalogHandler(mocks.NewMockCatalogUseCaseInterface(ctrl)), h.NewVehicleImageHandler(mocks.NewMockVehicleImageUseCaseInterface(ctrl)), h.NewVehicleImportHandler(importUseCase))
+
+	t.Run("should route import jobs apart from vehicle IDs", func(t *testing.T) {
+		importUseCase.EXPECT().GetJob(gomock.Any(), "job-1").Return(&dto.OutputImportJobDTO{ID: "job-1", Status: "RUNNING"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/import/job-1", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
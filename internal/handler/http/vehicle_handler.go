@@ -1,14 +1,26 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
 	"github.com/go-chi/chi"
 )
 
+const mergePatchContentType = "application/merge-patch+json"
+
 type VehicleHandler struct {
 	useCase usecase.VehicleUseCaseInterface
 }
@@ -23,13 +35,13 @@ func (h *VehicleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var input dto.InputCreateVehicleDTO
 	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeBadRequest(w, r, "Invalid request body")
 		return
 	}
 
 	output, err := h.useCase.Create(r.Context(), input)
 	if err != nil {
-		http.Error(w, "Failed to create vehicle", http.StatusInternalServerError)
+		writeError(w, r, err, "Failed to create vehicle")
 		return
 	}
 
@@ -41,22 +53,353 @@ func (h *VehicleHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *VehicleHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		http.Error(w, "VehicleID is required", http.StatusBadRequest)
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		writeError(w, r, err, "Invalid If-Match header")
 		return
 	}
 
 	var input dto.InputUpdateVehicleDTO
-	err := json.NewDecoder(r.Body).Decode(&input)
+	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.Update(r.Context(), id, expectedVersion, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to update vehicle")
+		return
+	}
+
+	writeVehicle(w, http.StatusOK, output)
+}
+
+func (h *VehicleHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "VehicleID is required")
 		return
 	}
 
-	err = h.useCase.Update(r.Context(), id, input)
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != mergePatchContentType {
+		writeUnsupportedMediaType(w, r, "Content-Type must be "+mergePatchContentType)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err, "Invalid If-Match header")
 		return
 	}
 
+	var input dto.InputPatchVehicleDTO
+	err = decodeMergePatch(r.Body, &input)
+	if err != nil {
+		if errors.Is(err, domain.ErrValidation) {
+			writeError(w, r, err, "Invalid request body")
+			return
+		}
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.Patch(r.Context(), id, expectedVersion, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to update vehicle")
+		return
+	}
+
+	writeVehicle(w, http.StatusOK, output)
+}
+
+// decodeMergePatch rejects explicit nulls, since every vehicle field is
+// required and cannot be removed through a merge patch.
+func decodeMergePatch(body io.Reader, input *dto.InputPatchVehicleDTO) error {
+	var members map[string]json.RawMessage
+	err := json.NewDecoder(body).Decode(&members)
+	if err != nil {
+		return err
+	}
+
+	var nullErrs domain.ValidationErrors
+	for field, value := range members {
+		if string(value) == "null" {
+			nullErrs = append(nullErrs, domain.FieldError{
+				Field:   field,
+				Code:    domain.CodeRequired,
+				Message: field + " cannot be removed",
+			})
+		}
+	}
+	if len(nullErrs) > 0 {
+		sort.Slice(nullErrs, func(i, j int) bool { return nullErrs[i].Field < nullErrs[j].Field })
+		return nullErrs
+	}
+
+	raw, err := json.Marshal(members)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, input)
+}
+
+func (h *VehicleHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	output, err := h.useCase.GetByID(r.Context(), id, parseCurrency(r.URL.Query()))
+	if err != nil {
+		writeError(w, r, err, "Failed to get vehicle")
+		return
+	}
+
+	writeVehicle(w, http.StatusOK, output)
+}
+
+func (h *VehicleHandler) List(w http.ResponseWriter, r *http.Request) {
+	input, err := parseListVehiclesQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, r, err, "Invalid query parameters")
+		return
+	}
+
+	output, err := h.useCase.List(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err, "Failed to list vehicles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(output)
+}
+
+func (h *VehicleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	err := h.useCase.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to delete vehicle")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *VehicleHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.useCase.Reserve)
+}
+
+func (h *VehicleHandler) Sell(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.useCase.Sell)
+}
+
+func (h *VehicleHandler) Release(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.useCase.Release)
+}
+
+func (h *VehicleHandler) changeStatus(w http.ResponseWriter, r *http.Request, transition func(ctx context.Context, id string) (*dto.OutputVehicleDTO, error)) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	output, err := transition(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to change vehicle status")
+		return
+	}
+
+	writeVehicle(w, http.StatusOK, output)
+}
+
+func (h *VehicleHandler) History(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	var input dto.InputListVehicleHistoryDTO
+	var err error
+	input.Limit, input.Offset, err = parsePagination(r.URL.Query())
+	if err != nil {
+		writeError(w, r, err, "Invalid query parameters")
+		return
+	}
+
+	output, err := h.useCase.History(r.Context(), id, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to get vehicle history")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *VehicleHandler) Prices(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	output, err := h.useCase.Prices(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to get vehicle prices")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *VehicleHandler) PriceStats(w http.ResponseWriter, r *http.Request) {
+	input := dto.InputPriceStatsDTO{
+		Brand: r.URL.Query().Get("brand"),
+		Model: r.URL.Query().Get("model"),
+	}
+
+	output, err := h.useCase.PriceStats(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err, "Failed to get price statistics")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func parseListVehiclesQuery(query url.Values) (dto.InputListVehiclesDTO, error) {
+	input := dto.InputListVehiclesDTO{
+		Brand:     query.Get("brand"),
+		Model:     query.Get("model"),
+		Color:     query.Get("color"),
+		Currency:  parseCurrency(query),
+		SortBy:    query.Get("sort"),
+		SortOrder: query.Get("order"),
+
+		FuelType:     query.Get("fuel_type"),
+		Transmission: query.Get("transmission"),
+		BodyType:     query.Get("body_type"),
+		Condition:    query.Get("condition"),
+	}
+
+	var err error
+	if input.YearMin, err = parseOptionalInt(query, "year_min"); err != nil {
+		return input, err
+	}
+	if input.YearMax, err = parseOptionalInt(query, "year_max"); err != nil {
+		return input, err
+	}
+	if input.PriceMin, err = parseOptionalMoney(query, "price_min"); err != nil {
+		return input, err
+	}
+	if input.PriceMax, err = parseOptionalMoney(query, "price_max"); err != nil {
+		return input, err
+	}
+	if input.MileageMin, err = parseOptionalInt(query, "mileage_min"); err != nil {
+		return input, err
+	}
+	if input.MileageMax, err = parseOptionalInt(query, "mileage_max"); err != nil {
+		return input, err
+	}
+	if input.Doors, err = parseOptionalInt(query, "doors"); err != nil {
+		return input, err
+	}
+	if input.EngineDisplacementMin, err = parseOptionalInt(query, "engine_min"); err != nil {
+		return input, err
+	}
+	if input.EngineDisplacementMax, err = parseOptionalInt(query, "engine_max"); err != nil {
+		return input, err
+	}
+
+	input.Limit, input.Offset, err = parsePagination(query)
+	if err != nil {
+		return input, err
+	}
+
+	return input, nil
+}
+
+// parseCurrency reads the optional currency parameter as an upper-case code.
+func parseCurrency(query url.Values) string {
+	return strings.ToUpper(strings.TrimSpace(query.Get("currency")))
+}
+
+// parsePagination reads the optional limit and offset parameters, leaving
+// zero for the use case to replace with its defaults.
+func parsePagination(query url.Values) (int, int, error) {
+	limit, err := parseOptionalInt(query, "limit")
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit != nil && *limit < 0 {
+		return 0, 0, fmt.Errorf("%w: invalid limit parameter", domain.ErrValidation)
+	}
+
+	offset, err := parseOptionalInt(query, "offset")
+	if err != nil {
+		return 0, 0, err
+	}
+	if offset != nil && *offset < 0 {
+		return 0, 0, fmt.Errorf("%w: invalid offset parameter", domain.ErrValidation)
+	}
+
+	var l, o int
+	if limit != nil {
+		l = *limit
+	}
+	if offset != nil {
+		o = *offset
+	}
+	return l, o, nil
+}
+
+func parseOptionalInt(query url.Values, key string) (*int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s parameter", domain.ErrValidation, key)
+	}
+	return &parsed, nil
+}
+
+func parseOptionalBool(query url.Values, key string) (bool, error) {
+	value := query.Get(key)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid %s parameter", domain.ErrValidation, key)
+	}
+	return parsed, nil
+}
+
+func parseOptionalMoney(query url.Values, key string) (*domain.Money, error) {
+	value := query.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := domain.ParseMoney(value, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s parameter", domain.ErrValidation, key)
+	}
+	return &parsed, nil
 }
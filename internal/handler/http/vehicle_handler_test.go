@@ -5,14 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
 	h "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
 	"github.com/go-chi/chi"
 	"github.com/stretchr/testify/suite"
@@ -48,7 +51,7 @@ func (suite *VehicleHandlerSuite) Test_Create() {
 			Model: "Corolla",
 			Year:  2022,
 			Color: "Blue",
-			Price: 20000.00,
+			Price: domain.NewMoney(2000000, ""),
 		}
 		expectedOutput := &dto.OutputCreateVehicleDTO{
 			ID:        "123",
@@ -83,8 +86,13 @@ func (suite *VehicleHandlerSuite) Test_Create() {
 		defer resp.Body.Close()
 
 		suite.Equal(http.StatusBadRequest, resp.StatusCode)
-		body, _ := io.ReadAll(resp.Body)
-		suite.Contains(string(body), "Invalid request body")
+		suite.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("/problems/bad-request", problem.Type)
+		suite.Equal("Invalid request body", problem.Detail)
+		suite.Empty(problem.Errors)
 	})
 
 	suite.T().Run("Create - Use Case Error", func(t *testing.T) {
@@ -111,6 +119,73 @@ func (suite *VehicleHandlerSuite) Test_Create() {
 		bodyResp, _ := io.ReadAll(resp.Body)
 		suite.Contains(string(bodyResp), "Failed to create vehicle")
 	})
+
+	suite.T().Run("Create - Validation Error", func(t *testing.T) {
+		input := dto.InputCreateVehicleDTO{
+			Brand: "",
+			Model: "Corolla",
+			Year:  2022,
+		}
+
+		suite.useCase.EXPECT().
+			Create(gomock.Any(), input).
+			Return(nil, domain.ValidationErrors{
+				{Field: "brand", Code: domain.CodeRequired, Message: "brand cannot be empty"},
+				{Field: "price", Code: domain.CodeMustBePositive, Message: "price must be greater than zero"},
+			})
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/add", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		suite.handler.Create(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		suite.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("/problems/validation-error", problem.Type)
+		suite.Equal(http.StatusBadRequest, problem.Status)
+		suite.Equal("/vehicles/add", problem.Instance)
+		suite.Equal([]dto.FieldErrorDTO{
+			{Field: "brand", Code: "required", Message: "brand cannot be empty"},
+			{Field: "price", Code: "must_be_positive", Message: "price must be greater than zero"},
+		}, problem.Errors)
+	})
+
+	suite.T().Run("Create - Duplicate Identifier", func(t *testing.T) {
+		input := dto.InputCreateVehicleDTO{
+			Brand: "Volkswagen",
+			Model: "Gol",
+			Year:  1997,
+			VIN:   "9BWZZZ377VT004251",
+		}
+
+		suite.useCase.EXPECT().
+			Create(gomock.Any(), input).
+			Return(nil, &domain.DuplicateVehicleError{Field: "vin", VehicleID: "existing-1"})
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/add", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		suite.handler.Create(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusConflict, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal(http.StatusConflict, problem.Status)
+		suite.Equal("existing-1", problem.ConflictingID)
+		suite.Contains(problem.Detail, "vin is already registered")
+	})
 }
 
 func (suite *VehicleHandlerSuite) Test_Update() {
@@ -121,12 +196,12 @@ func (suite *VehicleHandlerSuite) Test_Update() {
 			Model: "Civic",
 			Year:  2023,
 			Color: "Red",
-			Price: 25000.00,
+			Price: domain.NewMoney(2500000, ""),
 		}
 
 		suite.useCase.EXPECT().
-			Update(gomock.Any(), id, input).
-			Return(nil)
+			Update(gomock.Any(), id, 0, input).
+			Return(&dto.OutputVehicleDTO{ID: id, Version: 2}, nil)
 
 		body, _ := json.Marshal(input)
 		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
@@ -140,6 +215,88 @@ func (suite *VehicleHandlerSuite) Test_Update() {
 		defer resp.Body.Close()
 
 		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal(`"2"`, resp.Header.Get("ETag"))
+	})
+
+	suite.T().Run("Update - If-Match", func(t *testing.T) {
+		id := "123"
+		input := dto.InputUpdateVehicleDTO{Brand: "Honda", Model: "Civic", Year: 2023, Color: "Red", Price: domain.NewMoney(2500000, "")}
+
+		suite.useCase.EXPECT().
+			Update(gomock.Any(), id, 5, input).
+			Return(&dto.OutputVehicleDTO{ID: id, Version: 6}, nil)
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
+		req.Header.Set("If-Match", `"5"`)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Update(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal(`"6"`, resp.Header.Get("ETag"))
+	})
+
+	suite.T().Run("Update - Malformed If-Match", func(t *testing.T) {
+		id := "123"
+		for _, ifMatch := range []string{`W/"5"`, `5`, `"abc"`} {
+			req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, strings.NewReader(`{}`))
+			req.Header.Set("If-Match", ifMatch)
+			req = muxSetURLParam(req, "id", id)
+			w := httptest.NewRecorder()
+
+			suite.handler.Update(w, req)
+
+			suite.Equal(http.StatusPreconditionFailed, w.Result().StatusCode, ifMatch)
+		}
+	})
+
+	suite.T().Run("Update - Stale If-Match", func(t *testing.T) {
+		id := "123"
+		input := dto.InputUpdateVehicleDTO{Brand: "Honda"}
+
+		suite.useCase.EXPECT().
+			Update(gomock.Any(), id, 1, input).
+			Return(nil, fmt.Errorf("%w: stale version", domain.ErrPreconditionFailed))
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Update(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusPreconditionFailed, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("/problems/precondition-failed", problem.Type)
+	})
+
+	suite.T().Run("Update - Concurrent Modification", func(t *testing.T) {
+		id := "123"
+		input := dto.InputUpdateVehicleDTO{Brand: "Honda"}
+
+		suite.useCase.EXPECT().
+			Update(gomock.Any(), id, 0, input).
+			Return(nil, fmt.Errorf("%w: modified concurrently", domain.ErrConflict))
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Update(w, req)
+
+		suite.Equal(http.StatusConflict, w.Result().StatusCode)
 	})
 
 	suite.T().Run("Update - Missing ID", func(t *testing.T) {
@@ -148,7 +305,7 @@ func (suite *VehicleHandlerSuite) Test_Update() {
 			Model: "Civic",
 			Year:  2023,
 			Color: "Red",
-			Price: 25000.00,
+			Price: domain.NewMoney(2500000, ""),
 		}
 		body, _ := json.Marshal(input)
 		req := httptest.NewRequest(http.MethodPut, "/vehicles/", bytes.NewReader(body))
@@ -187,12 +344,12 @@ func (suite *VehicleHandlerSuite) Test_Update() {
 			Model: "Civic",
 			Year:  2023,
 			Color: "Red",
-			Price: 25000.00,
+			Price: domain.NewMoney(2500000, ""),
 		}
 
 		suite.useCase.EXPECT().
-			Update(gomock.Any(), id, input).
-			Return(errors.New("update error"))
+			Update(gomock.Any(), id, 0, input).
+			Return(nil, errors.New("update error"))
 
 		body, _ := json.Marshal(input)
 		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
@@ -206,7 +363,778 @@ func (suite *VehicleHandlerSuite) Test_Update() {
 
 		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
 		bodyResp, _ := io.ReadAll(resp.Body)
-		suite.Contains(string(bodyResp), "update error")
+		suite.Contains(string(bodyResp), "Failed to update vehicle")
+		suite.NotContains(string(bodyResp), "update error")
+	})
+
+	suite.T().Run("Update - Not Found", func(t *testing.T) {
+		id := "missing"
+		input := dto.InputUpdateVehicleDTO{
+			Brand: "Honda",
+			Model: "Civic",
+			Year:  2023,
+			Color: "Red",
+			Price: domain.NewMoney(2500000, ""),
+		}
+
+		suite.useCase.EXPECT().
+			Update(gomock.Any(), id, 0, input).
+			Return(nil, fmt.Errorf("vehicle %s: %w", id, domain.ErrNotFound))
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Update(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.T().Run("Update - Validation Error", func(t *testing.T) {
+		id := "123"
+		input := dto.InputUpdateVehicleDTO{Brand: "Honda"}
+
+		suite.useCase.EXPECT().
+			Update(gomock.Any(), id, 0, input).
+			Return(nil, fmt.Errorf("%w: model cannot be empty", domain.ErrValidation))
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/"+id, bytes.NewReader(body))
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Update(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		bodyResp, _ := io.ReadAll(resp.Body)
+		suite.Contains(string(bodyResp), "model cannot be empty")
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_Patch() {
+	newRequest := func(id, contentType, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/vehicles/"+id, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return muxSetURLParam(req, "id", id)
+	}
+
+	suite.T().Run("Patch - Success", func(t *testing.T) {
+		id := "123"
+		price := domain.NewMoney(2100050, "")
+		expectedOutput := &dto.OutputVehicleDTO{ID: id, Brand: "Honda", Price: price}
+
+		suite.useCase.EXPECT().
+			Patch(gomock.Any(), id, 0, dto.InputPatchVehicleDTO{Price: &price}).
+			Return(expectedOutput, nil)
+
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, newRequest(id, "application/merge-patch+json", `{"price": 21000.50}`))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputVehicleDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("Patch - Content Type With Charset", func(t *testing.T) {
+		id := "123"
+		color := "Black"
+
+		suite.useCase.EXPECT().
+			Patch(gomock.Any(), id, 0, dto.InputPatchVehicleDTO{Color: &color}).
+			Return(&dto.OutputVehicleDTO{ID: id}, nil)
+
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, newRequest(id, "application/merge-patch+json; charset=utf-8", `{"color": "Black"}`))
+
+		suite.Equal(http.StatusOK, w.Result().StatusCode)
+	})
+
+	suite.T().Run("Patch - If-Match", func(t *testing.T) {
+		id := "123"
+		color := "Black"
+
+		suite.useCase.EXPECT().
+			Patch(gomock.Any(), id, 9, dto.InputPatchVehicleDTO{Color: &color}).
+			Return(&dto.OutputVehicleDTO{ID: id, Version: 10}, nil)
+
+		req := newRequest(id, "application/merge-patch+json", `{"color": "Black"}`)
+		req.Header.Set("If-Match", `"9"`)
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal(`"10"`, resp.Header.Get("ETag"))
+	})
+
+	suite.T().Run("Patch - Unsupported Media Type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, newRequest("123", "application/json", `{"price": 1}`))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+		suite.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+	})
+
+	suite.T().Run("Patch - Null Members", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, newRequest("123", "application/merge-patch+json", `{"model": null, "color": null, "price": 1}`))
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("/problems/validation-error", problem.Type)
+		suite.Equal([]dto.FieldErrorDTO{
+			{Field: "color", Code: "required", Message: "color cannot be removed"},
+			{Field: "model", Code: "required", Message: "model cannot be removed"},
+		}, problem.Errors)
+	})
+
+	suite.T().Run("Patch - Invalid Body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, newRequest("123", "application/merge-patch+json", `{"price": "cheap"}`))
+
+		suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	suite.T().Run("Patch - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/vehicles/", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w := httptest.NewRecorder()
+
+		suite.handler.Patch(w, req)
+
+		suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	suite.T().Run("Patch - Use Case Error", func(t *testing.T) {
+		id := "missing"
+		suite.useCase.EXPECT().
+			Patch(gomock.Any(), id, 0, gomock.Any()).
+			Return(nil, fmt.Errorf("vehicle %s: %w", id, domain.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		suite.handler.Patch(w, newRequest(id, "application/merge-patch+json", `{"brand": "Fiat"}`))
+
+		suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_GetByID() {
+	suite.T().Run("GetByID - Success", func(t *testing.T) {
+		id := "123"
+		expectedOutput := &dto.OutputVehicleDTO{
+			ID:      id,
+			Brand:   "Honda",
+			Model:   "Civic",
+			Year:    2023,
+			Color:   "Red",
+			Price:   domain.NewMoney(2500000, ""),
+			Version: 3,
+		}
+
+		suite.useCase.EXPECT().GetByID(gomock.Any(), id, "").Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/"+id, nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal(`"3"`, resp.Header.Get("ETag"))
+		var got dto.OutputVehicleDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("GetByID - Converted Currency", func(t *testing.T) {
+		id := "123"
+		expectedOutput := &dto.OutputVehicleDTO{
+			ID:        id,
+			Price:     domain.NewMoney(2500000, ""),
+			Currency:  "BRL",
+			Converted: &dto.ConvertedPriceDTO{Price: domain.NewMoney(462500, ""), Currency: "USD"},
+		}
+
+		suite.useCase.EXPECT().GetByID(gomock.Any(), id, "USD").Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/"+id+"?currency=usd", nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		bodyResp, _ := io.ReadAll(resp.Body)
+		suite.Contains(string(bodyResp), `"converted":{"price":"4625.00","currency":"USD"}`)
+	})
+
+	suite.T().Run("GetByID - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("GetByID - Not Found", func(t *testing.T) {
+		id := "missing"
+		suite.useCase.EXPECT().GetByID(gomock.Any(), id, "").Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/"+id, nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("/problems/not-found", problem.Type)
+		suite.Equal(http.StatusNotFound, problem.Status)
+		suite.Equal("/vehicles/"+id, problem.Instance)
+	})
+
+	suite.T().Run("GetByID - Use Case Error", func(t *testing.T) {
+		id := "123"
+		suite.useCase.EXPECT().GetByID(gomock.Any(), id, "").Return(nil, errors.New("some error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/"+id, nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+		bodyResp, _ := io.ReadAll(resp.Body)
+		suite.Contains(string(bodyResp), "Failed to get vehicle")
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_List() {
+	suite.T().Run("List - Success", func(t *testing.T) {
+		expectedOutput := &dto.OutputListVehiclesDTO{
+			Items: []dto.OutputVehicleDTO{
+				{ID: "1", Brand: "Honda", Model: "Civic", Year: 2023, Price: domain.NewMoney(2500000, "")},
+			},
+			Total:  11,
+			Limit:  10,
+			Offset: 10,
+		}
+
+		suite.useCase.EXPECT().
+			List(gomock.Any(), dto.InputListVehiclesDTO{Limit: 10, Offset: 10}).
+			Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?limit=10&offset=10", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputListVehiclesDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("List - Invalid Limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?limit=abc", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Invalid Offset", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?offset=-1", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Filters And Sort", func(t *testing.T) {
+		yearMin, yearMax := 2018, 2022
+		priceMin, priceMax := domain.NewMoney(5000000, ""), domain.NewMoney(9999999, "")
+		expectedInput := dto.InputListVehiclesDTO{
+			Brand:     "Toyota",
+			Model:     "Corolla",
+			Color:     "White",
+			YearMin:   &yearMin,
+			YearMax:   &yearMax,
+			PriceMin:  &priceMin,
+			PriceMax:  &priceMax,
+			Currency:  "USD",
+			SortBy:    "price",
+			SortOrder: "asc",
+		}
+
+		suite.useCase.EXPECT().
+			List(gomock.Any(), expectedInput).
+			Return(&dto.OutputListVehiclesDTO{Items: []dto.OutputVehicleDTO{}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/vehicles?brand=Toyota&model=Corolla&color=White&year_min=2018&year_max=2022&price_min=50000&price_max=99999.99&currency=USD&sort=price&order=asc", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Specification Filters", func(t *testing.T) {
+		mileageMax, doors, engineMin, engineMax := 60000, 4, 1000, 2000
+		expectedInput := dto.InputListVehiclesDTO{
+			MileageMax:            &mileageMax,
+			FuelType:              "FLEX",
+			Transmission:          "MANUAL",
+			BodyType:              "HATCHBACK",
+			Doors:                 &doors,
+			EngineDisplacementMin: &engineMin,
+			EngineDisplacementMax: &engineMax,
+			Condition:             "USED",
+			SortBy:                "mileage",
+		}
+
+		suite.useCase.EXPECT().
+			List(gomock.Any(), expectedInput).
+			Return(&dto.OutputListVehiclesDTO{Items: []dto.OutputVehicleDTO{}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/vehicles?mileage_max=60000&fuel_type=FLEX&transmission=MANUAL&body_type=HATCHBACK&doors=4&engine_min=1000&engine_max=2000&condition=USED&sort=mileage", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Invalid Doors Filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?doors=four", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Invalid Year Filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?year_min=old", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		bodyResp, _ := io.ReadAll(resp.Body)
+		suite.Contains(string(bodyResp), "invalid year_min parameter")
+	})
+
+	suite.T().Run("List - Invalid Price Filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?price_max=cheap", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Invalid Sort", func(t *testing.T) {
+		suite.useCase.EXPECT().
+			List(gomock.Any(), dto.InputListVehiclesDTO{SortBy: "brand"}).
+			Return(nil, domain.ErrValidation)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles?sort=brand", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("List - Use Case Error", func(t *testing.T) {
+		suite.useCase.EXPECT().
+			List(gomock.Any(), dto.InputListVehiclesDTO{}).
+			Return(nil, errors.New("list error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_Delete() {
+	suite.T().Run("Delete - Success", func(t *testing.T) {
+		id := "123"
+		suite.useCase.EXPECT().Delete(gomock.Any(), id).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/"+id, nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.T().Run("Delete - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("Delete - Not Found", func(t *testing.T) {
+		id := "missing"
+		suite.useCase.EXPECT().Delete(gomock.Any(), id).Return(domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/"+id, nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.T().Run("Delete - Use Case Error", func(t *testing.T) {
+		id := "123"
+		suite.useCase.EXPECT().Delete(gomock.Any(), id).Return(errors.New("delete error"))
+
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/"+id, nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_StatusTransitions() {
+	suite.T().Run("Reserve - Success", func(t *testing.T) {
+		id := "123"
+		expectedOutput := &dto.OutputVehicleDTO{ID: id, Status: "RESERVED"}
+		suite.useCase.EXPECT().Reserve(gomock.Any(), id).Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/"+id+"/reserve", nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Reserve(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputVehicleDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("Sell - Success", func(t *testing.T) {
+		id := "123"
+		suite.useCase.EXPECT().Sell(gomock.Any(), id).Return(&dto.OutputVehicleDTO{ID: id, Status: "SOLD"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/"+id+"/sell", nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Sell(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+	})
+
+	suite.T().Run("Release - Invalid Transition", func(t *testing.T) {
+		id := "123"
+		suite.useCase.EXPECT().Release(gomock.Any(), id).Return(nil, usecase.ErrInvalidStatusTransition)
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/"+id+"/release", nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Release(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.T().Run("Reserve - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/vehicles//reserve", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.Reserve(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("Sell - Not Found", func(t *testing.T) {
+		id := "missing"
+		suite.useCase.EXPECT().Sell(gomock.Any(), id).Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/"+id+"/sell", nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Sell(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.T().Run("Reserve - Use Case Error", func(t *testing.T) {
+		id := "123"
+		suite.useCase.EXPECT().Reserve(gomock.Any(), id).Return(nil, errors.New("some error"))
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/"+id+"/reserve", nil)
+		req = muxSetURLParam(req, "id", id)
+		w := httptest.NewRecorder()
+
+		suite.handler.Reserve(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_History() {
+	suite.T().Run("History - Success", func(t *testing.T) {
+		expectedOutput := &dto.OutputListVehicleHistoryDTO{
+			Items: []dto.OutputVehicleHistoryDTO{{ID: "h1", Action: "UPDATE", Actor: "ana", After: json.RawMessage(`{"price":75000}`)}},
+			Total: 1, Limit: 10, Offset: 0,
+		}
+		suite.useCase.EXPECT().
+			History(gomock.Any(), "123", dto.InputListVehicleHistoryDTO{Limit: 10}).
+			Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/123/history?limit=10", nil)
+		req = muxSetURLParam(req, "id", "123")
+		w := httptest.NewRecorder()
+
+		suite.handler.History(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputListVehicleHistoryDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal("ana", got.Items[0].Actor)
+		suite.JSONEq(`{"price":75000}`, string(got.Items[0].After))
+	})
+
+	suite.T().Run("History - Invalid Offset", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/123/history?offset=abc", nil)
+		req = muxSetURLParam(req, "id", "123")
+		w := httptest.NewRecorder()
+
+		suite.handler.History(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("History - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().History(gomock.Any(), "missing", gomock.Any()).Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/missing/history", nil)
+		req = muxSetURLParam(req, "id", "missing")
+		w := httptest.NewRecorder()
+
+		suite.handler.History(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_Prices() {
+	suite.T().Run("Prices - Success", func(t *testing.T) {
+		previous := domain.NewMoney(8000000, "")
+		expectedOutput := &dto.OutputVehiclePricesDTO{
+			VehicleID:    "123",
+			InitialPrice: domain.NewMoney(8000000, ""),
+			CurrentPrice: domain.NewMoney(7500000, ""),
+			Currency:     "BRL",
+			Items: []dto.OutputPricePeriodDTO{
+				{Price: domain.NewMoney(8000000, ""), Currency: "BRL", From: "2024-05-01T12:00:00Z", Until: "2024-05-11T12:00:00Z", Days: 10},
+				{Price: domain.NewMoney(7500000, ""), PreviousPrice: &previous, Currency: "BRL", Actor: "ana", From: "2024-05-11T12:00:00Z", Days: 3.5},
+			},
+		}
+		suite.useCase.EXPECT().Prices(gomock.Any(), "123").Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/123/prices", nil)
+		req = muxSetURLParam(req, "id", "123")
+		w := httptest.NewRecorder()
+
+		suite.handler.Prices(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputVehiclePricesDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("Prices - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().Prices(gomock.Any(), "missing").Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/missing/prices", nil)
+		req = muxSetURLParam(req, "id", "missing")
+		w := httptest.NewRecorder()
+
+		suite.handler.Prices(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleHandlerSuite) Test_PriceStats() {
+	suite.T().Run("PriceStats - Success", func(t *testing.T) {
+		expectedOutput := &dto.OutputListPriceStatsDTO{
+			Items: []dto.OutputPriceStatsDTO{{Brand: "Ford", Model: "Focus", Currency: "BRL", Vehicles: 2, AvgDaysOnMarket: 12.5, AvgDiscount: domain.NewMoney(250000, ""), AvgDiscountPercent: 3.13}},
+		}
+		suite.useCase.EXPECT().PriceStats(gomock.Any(), dto.InputPriceStatsDTO{Brand: "Ford"}).Return(expectedOutput, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/price-stats?brand=Ford", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.PriceStats(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputListPriceStatsDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("PriceStats - Internal Error", func(t *testing.T) {
+		suite.useCase.EXPECT().PriceStats(gomock.Any(), gomock.Any()).Return(nil, errors.New("stats error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/price-stats", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.PriceStats(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
 	})
 }
 
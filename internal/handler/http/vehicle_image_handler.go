@@ -0,0 +1,140 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/go-chi/chi"
+)
+
+// maxMultipartMemory is how much of an upload is buffered in memory before
+// the rest spills to a temporary file. The size limit of the image itself is
+// enforced by the use case.
+const maxMultipartMemory = 8 << 20
+
+// multipartOverhead is how much an upload body may exceed the largest image
+// to make room for the boundaries and headers of the multipart form.
+const multipartOverhead = 1 << 20
+
+type VehicleImageHandler struct {
+	useCase usecase.VehicleImageUseCaseInterface
+}
+
+func NewVehicleImageHandler(useCase usecase.VehicleImageUseCaseInterface) *VehicleImageHandler {
+	return &VehicleImageHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *VehicleImageHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "id")
+	if vehicleID == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.useCase.MaxSize()+multipartOverhead)
+	err := r.ParseMultipartForm(maxMultipartMemory)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writePayloadTooLarge(w, r, fmt.Sprintf("request body must have at most %d bytes", tooLarge.Limit))
+			return
+		}
+		writeBadRequest(w, r, "Invalid multipart body")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		writeBadRequest(w, r, "The image file is required")
+		return
+	}
+	defer file.Close()
+
+	output, err := h.useCase.Upload(r.Context(), vehicleID, file)
+	if err != nil {
+		writeError(w, r, err, "Failed to upload image")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *VehicleImageHandler) List(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "id")
+	if vehicleID == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	output, err := h.useCase.List(r.Context(), vehicleID)
+	if err != nil {
+		writeError(w, r, err, "Failed to list images")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *VehicleImageHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "id")
+	if vehicleID == "" {
+		writeBadRequest(w, r, "VehicleID is required")
+		return
+	}
+
+	var input dto.InputReorderVehicleImagesDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.Reorder(r.Context(), vehicleID, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to reorder images")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *VehicleImageHandler) SetCover(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "id")
+	imageID := chi.URLParam(r, "imageId")
+	if vehicleID == "" || imageID == "" {
+		writeBadRequest(w, r, "VehicleID and ImageID are required")
+		return
+	}
+
+	output, err := h.useCase.SetCover(r.Context(), vehicleID, imageID)
+	if err != nil {
+		writeError(w, r, err, "Failed to set cover image")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *VehicleImageHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vehicleID := chi.URLParam(r, "id")
+	imageID := chi.URLParam(r, "imageId")
+	if vehicleID == "" || imageID == "" {
+		writeBadRequest(w, r, "VehicleID and ImageID are required")
+		return
+	}
+
+	err := h.useCase.Delete(r.Context(), vehicleID, imageID)
+	if err != nil {
+		writeError(w, r, err, "Failed to delete image")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
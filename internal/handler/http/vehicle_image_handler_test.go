@@ -0,0 +1,283 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/storage"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type VehicleImageHandlerSuite struct {
+	suite.Suite
+
+	useCase *mocks.MockVehicleImageUseCaseInterface
+	handler *h.VehicleImageHandler
+}
+
+func (suite *VehicleImageHandlerSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.useCase = mocks.NewMockVehicleImageUseCaseInterface(ctrl)
+	suite.useCase.EXPECT().MaxSize().Return(int64(1024)).AnyTimes()
+	suite.handler = h.NewVehicleImageHandler(suite.useCase)
+}
+
+func Test_VehicleImageHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(VehicleImageHandlerSuite))
+}
+
+// multipartImage returns a multipart body with content as the given form
+// field, and its content type.
+func multipartImage(field string, content []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile(field, "car.jpg")
+	_, _ = part.Write(content)
+	_ = writer.Close()
+	return &body, writer.FormDataContentType()
+}
+
+func withImageParams(r *http.Request, vehicleID, imageID string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"id", "imageId"},
+			Values: []string{vehicleID, imageID},
+		},
+	}))
+}
+
+func (suite *VehicleImageHandlerSuite) Test_Upload() {
+	suite.T().Run("Upload - Success", func(t *testing.T) {
+		expectedOutput := &dto.OutputVehicleImageDTO{ID: "image-1", URL: "http://localhost:8080/media/vehicles/vehicle-1/image-1.jpg", Cover: true}
+		suite.useCase.EXPECT().
+			Upload(gomock.Any(), "vehicle-1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, content io.Reader) (*dto.OutputVehicleImageDTO, error) {
+				data, err := io.ReadAll(content)
+				if err != nil || string(data) != "image-bytes" {
+					return nil, fmt.Errorf("unexpected content %q: %v", data, err)
+				}
+				return expectedOutput, nil
+			})
+
+		body, contentType := multipartImage("image", []byte("image-bytes"))
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/vehicle-1/images", body)
+		req.Header.Set("Content-Type", contentType)
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Upload(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusCreated, resp.StatusCode)
+		var got dto.OutputVehicleImageDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("Upload - Missing File", func(t *testing.T) {
+		body, contentType := multipartImage("photo", []byte("image-bytes"))
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/vehicle-1/images", body)
+		req.Header.Set("Content-Type", contentType)
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Upload(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("Upload - Not Multipart", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/vehicle-1/images", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Upload(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("Upload - Body Too Large", func(t *testing.T) {
+		body, contentType := multipartImage("image", bytes.Repeat([]byte("x"), 1024+(1<<20)))
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/vehicle-1/images", body)
+		req.Header.Set("Content-Type", contentType)
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Upload(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusRequestEntityTooLarge, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		suite.NoError(json.NewDecoder(resp.Body).Decode(&problem))
+		suite.Equal("/problems/payload-too-large", problem.Type)
+	})
+
+	suite.T().Run("Upload - Invalid Vehicle ID", func(t *testing.T) {
+		suite.useCase.EXPECT().Upload(gomock.Any(), "..", gomock.Any()).
+			Return(nil, fmt.Errorf("%w: %q", storage.ErrInvalidKey, "vehicles/../image-1.jpg"))
+
+		body, contentType := multipartImage("image", []byte("image-bytes"))
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/../images", body)
+		req.Header.Set("Content-Type", contentType)
+		req = muxSetURLParam(req, "id", "..")
+		w := httptest.NewRecorder()
+
+		suite.handler.Upload(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		suite.NoError(json.NewDecoder(resp.Body).Decode(&problem))
+		suite.Equal("Invalid ID", problem.Detail)
+	})
+
+	suite.T().Run("Upload - Invalid Image", func(t *testing.T) {
+		suite.useCase.EXPECT().Upload(gomock.Any(), "vehicle-1", gomock.Any()).
+			Return(nil, domain.ValidationErrors{{Field: "image", Code: domain.CodeInvalid, Message: "image must be a JPEG or PNG file"}})
+
+		body, contentType := multipartImage("image", []byte("not-an-image"))
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/vehicle-1/images", body)
+		req.Header.Set("Content-Type", contentType)
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Upload(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleImageHandlerSuite) Test_List() {
+	suite.T().Run("List - Vehicle Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().List(gomock.Any(), "vehicle-9").Return(nil, fmt.Errorf("vehicle vehicle-9: %w", domain.ErrNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/vehicle-9/images", nil)
+		req = muxSetURLParam(req, "id", "vehicle-9")
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleImageHandlerSuite) Test_Reorder() {
+	suite.T().Run("Reorder - Success", func(t *testing.T) {
+		input := dto.InputReorderVehicleImagesDTO{ImageIDs: []string{"image-2", "image-1"}}
+		suite.useCase.EXPECT().Reorder(gomock.Any(), "vehicle-1", input).
+			Return(&dto.OutputListVehicleImagesDTO{Items: []dto.OutputVehicleImageDTO{{ID: "image-2"}, {ID: "image-1", Position: 1}}}, nil)
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/vehicle-1/images/order", bytes.NewReader(body))
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Reorder(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputListVehicleImagesDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal("image-2", got.Items[0].ID)
+	})
+
+	suite.T().Run("Reorder - Invalid Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/vehicles/vehicle-1/images/order", strings.NewReader("invalid-json"))
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Reorder(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleImageHandlerSuite) Test_SetCover() {
+	suite.T().Run("SetCover - Image Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().SetCover(gomock.Any(), "vehicle-1", "image-9").
+			Return(nil, fmt.Errorf("image image-9: %w", domain.ErrNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/vehicle-1/images/image-9/cover", nil)
+		req = withImageParams(req, "vehicle-1", "image-9")
+		w := httptest.NewRecorder()
+
+		suite.handler.SetCover(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *VehicleImageHandlerSuite) Test_Delete() {
+	suite.T().Run("Delete - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().Delete(gomock.Any(), "vehicle-1", "image-1").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/vehicle-1/images/image-1", nil)
+		req = withImageParams(req, "vehicle-1", "image-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.T().Run("Delete - Missing Image ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/vehicles/vehicle-1/images/", nil)
+		req = muxSetURLParam(req, "id", "vehicle-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
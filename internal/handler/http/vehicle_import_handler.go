@@ -0,0 +1,83 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/go-chi/chi"
+)
+
+// importFormats maps the accepted Content-Types of an import to its format.
+var importFormats = map[string]string{
+	"text/csv":              usecase.ImportFormatCSV,
+	"application/x-ndjson":  usecase.ImportFormatJSONL,
+	"application/jsonl":     usecase.ImportFormatJSONL,
+	"application/x-jsonl":   usecase.ImportFormatJSONL,
+	"application/jsonlines": usecase.ImportFormatJSONL,
+}
+
+type VehicleImportHandler struct {
+	useCase usecase.VehicleImportUseCaseInterface
+}
+
+func NewVehicleImportHandler(useCase usecase.VehicleImportUseCaseInterface) *VehicleImportHandler {
+	return &VehicleImportHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *VehicleImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format, ok := importFormats[mediaType]
+	if err != nil || !ok {
+		writeUnsupportedMediaType(w, r, "Content-Type must be text/csv or application/x-ndjson")
+		return
+	}
+
+	dryRun, err := parseOptionalBool(r.URL.Query(), "dry_run")
+	if err != nil {
+		writeError(w, r, err, "Failed to import vehicles")
+		return
+	}
+	async, err := parseOptionalBool(r.URL.Query(), "async")
+	if err != nil {
+		writeError(w, r, err, "Failed to import vehicles")
+		return
+	}
+
+	input := dto.InputImportVehiclesDTO{
+		Format: format,
+		DryRun: dryRun,
+		Async:  async,
+	}
+	output, err := h.useCase.Import(r.Context(), input, r.Body)
+	if err != nil {
+		writeError(w, r, err, "Failed to import vehicles")
+		return
+	}
+
+	if output.Job != nil {
+		w.Header().Set("Location", "/vehicles/import/"+output.Job.ID)
+		writeJSON(w, http.StatusAccepted, output.Job)
+		return
+	}
+	writeJSON(w, http.StatusOK, output.Report)
+}
+
+func (h *VehicleImportHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "jobId")
+	if id == "" {
+		writeBadRequest(w, r, "JobID is required")
+		return
+	}
+
+	output, err := h.useCase.GetJob(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to get import job")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,169 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type VehicleImportHandlerSuite struct {
+	suite.Suite
+
+	useCase *mocks.MockVehicleImportUseCaseInterface
+	handler *h.VehicleImportHandler
+}
+
+func (suite *VehicleImportHandlerSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.useCase = mocks.NewMockVehicleImportUseCaseInterface(ctrl)
+	suite.handler = h.NewVehicleImportHandler(suite.useCase)
+}
+
+func Test_VehicleImportHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(VehicleImportHandlerSuite))
+}
+
+func (suite *VehicleImportHandlerSuite) Test_Import() {
+	suite.T().Run("Import - Report", func(t *testing.T) {
+		report := &dto.OutputImportReportDTO{
+			DryRun:    true,
+			Total:     1,
+			Succeeded: 1,
+			Rows:      []dto.ImportRowResultDTO{{Line: 2, Status: "VALID"}},
+		}
+		suite.useCase.EXPECT().
+			Import(gomock.Any(), dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV, DryRun: true}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ dto.InputImportVehiclesDTO, content io.Reader) (*dto.OutputImportVehiclesDTO, error) {
+				data, _ := io.ReadAll(content)
+				suite.Equal("brand\nToyota\n", string(data))
+				return &dto.OutputImportVehiclesDTO{Report: report}, nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/import?dry_run=true", strings.NewReader("brand\nToyota\n"))
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		w := httptest.NewRecorder()
+
+		suite.handler.Import(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputImportReportDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*report, got)
+	})
+
+	suite.T().Run("Import - Job", func(t *testing.T) {
+		job := &dto.OutputImportJobDTO{ID: "job-1", Status: "RUNNING", Total: 800, Rows: []dto.ImportRowResultDTO{}}
+		suite.useCase.EXPECT().
+			Import(gomock.Any(), dto.InputImportVehiclesDTO{Format: usecase.ImportFormatJSONL, Async: true}, gomock.Any()).
+			Return(&dto.OutputImportVehiclesDTO{Job: job}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/import?async=true", strings.NewReader(`{"brand":"Toyota"}`))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		w := httptest.NewRecorder()
+
+		suite.handler.Import(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusAccepted, resp.StatusCode)
+		suite.Equal("/vehicles/import/job-1", resp.Header.Get("Location"))
+		var got dto.OutputImportJobDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*job, got)
+	})
+
+	suite.T().Run("Import - Unsupported Media Type", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/import", strings.NewReader(`[]`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		suite.handler.Import(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	suite.T().Run("Import - Invalid Dry Run", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/import?dry_run=maybe", strings.NewReader("brand\nToyota\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		suite.handler.Import(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("Import - Invalid File", func(t *testing.T) {
+		suite.useCase.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, domain.ValidationErrors{{Field: "file", Code: domain.CodeInvalid, Message: `unknown CSV column "modle"`}})
+
+		req := httptest.NewRequest(http.MethodPost, "/vehicles/import", strings.NewReader("modle\nCorolla\n"))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+
+		suite.handler.Import(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("file", problem.Errors[0].Field)
+	})
+}
+
+func (suite *VehicleImportHandlerSuite) Test_GetJob() {
+	suite.T().Run("GetJob - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().GetJob(gomock.Any(), "job-9").Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/import/job-9", nil)
+		req = muxSetURLParam(req, "jobId", "job-9")
+		w := httptest.NewRecorder()
+
+		suite.handler.GetJob(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.T().Run("GetJob - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/vehicles/import/", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetJob(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/go-chi/chi"
+)
+
+type WebhookHandler struct {
+	useCase usecase.WebhookUseCaseInterface
+}
+
+func NewWebhookHandler(useCase usecase.WebhookUseCaseInterface) *WebhookHandler {
+	return &WebhookHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.InputCreateWebhookDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		writeBadRequest(w, r, "Invalid request body")
+		return
+	}
+
+	output, err := h.useCase.Create(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err, "Failed to create webhook")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, output)
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	output, err := h.useCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to list webhooks")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "WebhookID is required")
+		return
+	}
+
+	output, err := h.useCase.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to get webhook")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "WebhookID is required")
+		return
+	}
+
+	err := h.useCase.Delete(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err, "Failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeBadRequest(w, r, "WebhookID is required")
+		return
+	}
+
+	query := r.URL.Query()
+	input := dto.InputListWebhookDeliveriesDTO{Status: query.Get("status")}
+
+	var err error
+	input.Limit, input.Offset, err = parsePagination(query)
+	if err != nil {
+		writeError(w, r, err, "Invalid query parameters")
+		return
+	}
+
+	output, err := h.useCase.ListDeliveries(r.Context(), id, input)
+	if err != nil {
+		writeError(w, r, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	deliveryID := chi.URLParam(r, "deliveryId")
+	if id == "" || deliveryID == "" {
+		writeBadRequest(w, r, "WebhookID and DeliveryID are required")
+		return
+	}
+
+	output, err := h.useCase.Redeliver(r.Context(), id, deliveryID)
+	if err != nil {
+		writeError(w, r, err, "Failed to redeliver webhook")
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, output)
+}
@@ -0,0 +1,252 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	h "github.com/NicolasNSC/catalog-service-fiap/internal/handler/http"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookHandlerSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	useCase *mocks.MockWebhookUseCaseInterface
+	handler *h.WebhookHandler
+}
+
+func (suite *WebhookHandlerSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.useCase = mocks.NewMockWebhookUseCaseInterface(ctrl)
+	suite.handler = h.NewWebhookHandler(suite.useCase)
+}
+
+func Test_WebhookHandlerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WebhookHandlerSuite))
+}
+
+func (suite *WebhookHandlerSuite) Test_Create() {
+	suite.T().Run("Create - Success", func(t *testing.T) {
+		input := dto.InputCreateWebhookDTO{URL: "https://example.com/hook", EventTypes: []string{"vehicle.sold"}}
+		expectedOutput := &dto.OutputCreateWebhookDTO{
+			OutputWebhookDTO: dto.OutputWebhookDTO{ID: "wh-1", URL: input.URL, EventTypes: input.EventTypes},
+			Secret:           "generated-secret",
+		}
+		suite.useCase.EXPECT().Create(suite.ctx, input).Return(expectedOutput, nil)
+
+		body, _ := json.Marshal(input)
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		suite.handler.Create(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusCreated, resp.StatusCode)
+		var got dto.OutputCreateWebhookDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(*expectedOutput, got)
+	})
+
+	suite.T().Run("Create - Invalid Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader("invalid-json"))
+		w := httptest.NewRecorder()
+
+		suite.handler.Create(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.T().Run("Create - Validation Error", func(t *testing.T) {
+		suite.useCase.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil, domain.ValidationErrors{
+			{Field: "url", Code: domain.CodeInvalid, Message: "url must be an absolute http or https URL"},
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(`{"url":"nope"}`))
+		w := httptest.NewRecorder()
+
+		suite.handler.Create(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+		var problem dto.ProblemDetailsDTO
+		err := json.NewDecoder(resp.Body).Decode(&problem)
+		suite.NoError(err)
+		suite.Equal("/problems/validation-error", problem.Type)
+		suite.Equal("url", problem.Errors[0].Field)
+	})
+}
+
+func (suite *WebhookHandlerSuite) Test_ListAndGet() {
+	suite.T().Run("List - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().List(suite.ctx).Return(&dto.OutputListWebhooksDTO{Items: []dto.OutputWebhookDTO{{ID: "wh-1"}}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.List(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal("application/json", resp.Header.Get("Content-Type"))
+	})
+
+	suite.T().Run("GetByID - Not Found", func(t *testing.T) {
+		suite.useCase.EXPECT().GetByID(gomock.Any(), "missing").Return(nil, domain.ErrNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/missing", nil)
+		req = muxSetURLParam(req, "id", "missing")
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.T().Run("GetByID - Missing ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/", nil)
+		w := httptest.NewRecorder()
+
+		suite.handler.GetByID(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *WebhookHandlerSuite) Test_Delete() {
+	suite.T().Run("Delete - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().Delete(gomock.Any(), "wh-1").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/wh-1", nil)
+		req = muxSetURLParam(req, "id", "wh-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.T().Run("Delete - Use Case Error", func(t *testing.T) {
+		suite.useCase.EXPECT().Delete(gomock.Any(), "wh-1").Return(assert.AnError)
+
+		req := httptest.NewRequest(http.MethodDelete, "/webhooks/wh-1", nil)
+		req = muxSetURLParam(req, "id", "wh-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Delete(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func (suite *WebhookHandlerSuite) Test_ListDeliveries() {
+	suite.T().Run("ListDeliveries - Success", func(t *testing.T) {
+		suite.useCase.EXPECT().
+			ListDeliveries(gomock.Any(), "wh-1", dto.InputListWebhookDeliveriesDTO{Status: "FAILED", Limit: 5, Offset: 10}).
+			Return(&dto.OutputListWebhookDeliveriesDTO{Items: []dto.OutputWebhookDeliveryDTO{}, Total: 11, Limit: 5, Offset: 10}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/wh-1/deliveries?status=FAILED&limit=5&offset=10", nil)
+		req = muxSetURLParam(req, "id", "wh-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.ListDeliveries(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		var got dto.OutputListWebhookDeliveriesDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal(11, got.Total)
+	})
+
+	suite.T().Run("ListDeliveries - Invalid Limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/wh-1/deliveries?limit=-1", nil)
+		req = muxSetURLParam(req, "id", "wh-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.ListDeliveries(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *WebhookHandlerSuite) Test_Redeliver() {
+	suite.T().Run("Redeliver - Accepted", func(t *testing.T) {
+		suite.useCase.EXPECT().Redeliver(gomock.Any(), "wh-1", "dlv-1").
+			Return(&dto.OutputWebhookDeliveryDTO{ID: "dlv-2", Status: "PENDING", RedeliveredFrom: "dlv-1"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/wh-1/deliveries/dlv-1/redeliver", nil)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"id", "deliveryId"},
+				Values: []string{"wh-1", "dlv-1"},
+			},
+		}))
+		w := httptest.NewRecorder()
+
+		suite.handler.Redeliver(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusAccepted, resp.StatusCode)
+		var got dto.OutputWebhookDeliveryDTO
+		err := json.NewDecoder(resp.Body).Decode(&got)
+		suite.NoError(err)
+		suite.Equal("dlv-1", got.RedeliveredFrom)
+	})
+
+	suite.T().Run("Redeliver - Missing Delivery ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/wh-1/deliveries//redeliver", nil)
+		req = muxSetURLParam(req, "id", "wh-1")
+		w := httptest.NewRecorder()
+
+		suite.handler.Redeliver(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
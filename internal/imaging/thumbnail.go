@@ -0,0 +1,166 @@
+// Package imaging decodes uploaded pictures and derives their thumbnails.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const thumbnailQuality = 80
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image")
+	ErrImageTooLarge    = errors.New("image too large")
+)
+
+// Thumbnail decodes a JPEG or PNG image and returns its dimensions along
+// with a JPEG copy scaled down to at most maxWidth pixels wide, keeping the
+// aspect ratio. Images already that narrow are re-encoded at their size.
+// Images with more than maxPixels pixels are rejected with ErrImageTooLarge
+// before their pixels are decoded.
+func Thumbnail(data []byte, maxWidth, maxPixels int) (thumbnail []byte, width, height int, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if int64(config.Width)*int64(config.Height) > int64(maxPixels) {
+		return nil, 0, 0, fmt.Errorf("%w: %dx%d pixels", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	bounds := img.Bounds()
+	width, height = bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, 0, 0, fmt.Errorf("%w: empty image", ErrUnsupportedImage)
+	}
+
+	scaled := img
+	if width > maxWidth {
+		scaled = downscale(img, maxWidth, max(1, height*maxWidth/width))
+	}
+
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: thumbnailQuality})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), width, height, nil
+}
+
+// downscale averages the source pixels covered by each destination pixel.
+// The source is read a row at a time and the sums of the destination row are
+// accumulated in place, so every source pixel is visited once.
+func downscale(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	x0s := make([]int, width)
+	x1s := make([]int, width)
+	for x := 0; x < width; x++ {
+		x0s[x] = x * bounds.Dx() / width
+		x1s[x] = max(x0s[x]+1, (x+1)*bounds.Dx()/width)
+	}
+
+	readRow := rowReader(src)
+	row := make([]uint32, 4*bounds.Dx())
+	sums := make([]uint64, 4*width)
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		clear(sums)
+		for sy := y0; sy < y1; sy++ {
+			readRow(sy, row)
+			for x := 0; x < width; x++ {
+				sum := sums[4*x : 4*x+4]
+				for sx := x0s[x]; sx < x1s[x]; sx++ {
+					pixel := row[4*sx : 4*sx+4]
+					sum[0] += uint64(pixel[0])
+					sum[1] += uint64(pixel[1])
+					sum[2] += uint64(pixel[2])
+					sum[3] += uint64(pixel[3])
+				}
+			}
+		}
+
+		pix := dst.Pix[dst.PixOffset(0, y):]
+		for x := 0; x < width; x++ {
+			n := uint64((x1s[x] - x0s[x]) * (y1 - y0))
+			for c := 0; c < 4; c++ {
+				pix[4*x+c] = uint8(sums[4*x+c] / n >> 8)
+			}
+		}
+	}
+
+	return dst
+}
+
+// rowReader returns a function storing the premultiplied 16-bit RGBA values
+// of row y of src in row. The image types produced by the JPEG and PNG
+// decoders are read straight from their pixel buffers; any other falls back
+// to At.
+func rowReader(src image.Image) func(y int, row []uint32) {
+	bounds := src.Bounds()
+	put := func(row []uint32, x int, r, g, b, a uint32) {
+		row[4*x], row[4*x+1], row[4*x+2], row[4*x+3] = r, g, b, a
+	}
+
+	switch img := src.(type) {
+	case *image.YCbCr:
+		return func(y int, row []uint32) {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				yi, ci := img.YOffset(x, y), img.COffset(x, y)
+				r, g, b, a := color.YCbCr{Y: img.Y[yi], Cb: img.Cb[ci], Cr: img.Cr[ci]}.RGBA()
+				put(row, x-bounds.Min.X, r, g, b, a)
+			}
+		}
+	case *image.RGBA:
+		return func(y int, row []uint32) {
+			pix := img.Pix[img.PixOffset(bounds.Min.X, y):]
+			for x := 0; x < bounds.Dx(); x++ {
+				r, g, b, a := color.RGBA{R: pix[4*x], G: pix[4*x+1], B: pix[4*x+2], A: pix[4*x+3]}.RGBA()
+				put(row, x, r, g, b, a)
+			}
+		}
+	case *image.NRGBA:
+		return func(y int, row []uint32) {
+			pix := img.Pix[img.PixOffset(bounds.Min.X, y):]
+			for x := 0; x < bounds.Dx(); x++ {
+				r, g, b, a := color.NRGBA{R: pix[4*x], G: pix[4*x+1], B: pix[4*x+2], A: pix[4*x+3]}.RGBA()
+				put(row, x, r, g, b, a)
+			}
+		}
+	case *image.Gray:
+		return func(y int, row []uint32) {
+			pix := img.Pix[img.PixOffset(bounds.Min.X, y):]
+			for x := 0; x < bounds.Dx(); x++ {
+				r, g, b, a := color.Gray{Y: pix[x]}.RGBA()
+				put(row, x, r, g, b, a)
+			}
+		}
+	case *image.Paletted:
+		return func(y int, row []uint32) {
+			pix := img.Pix[img.PixOffset(bounds.Min.X, y):]
+			for x := 0; x < bounds.Dx(); x++ {
+				r, g, b, a := img.Palette[pix[x]].RGBA()
+				put(row, x, r, g, b, a)
+			}
+		}
+	default:
+		return func(y int, row []uint32) {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				r, g, b, a := src.At(x, y).RGBA()
+				put(row, x-bounds.Min.X, r, g, b, a)
+			}
+		}
+	}
+}
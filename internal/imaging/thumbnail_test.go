@@ -0,0 +1,143 @@
+package imaging_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/imaging"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 30, B: 30, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encoding png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		thumbWidth    int
+		thumbHeight   int
+	}{
+		{name: "wide image is scaled down", width: 800, height: 600, thumbWidth: 320, thumbHeight: 240},
+		{name: "narrow image keeps its size", width: 200, height: 100, thumbWidth: 200, thumbHeight: 100},
+		{name: "thin image keeps one row", width: 1000, height: 2, thumbWidth: 320, thumbHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail, width, height, err := imaging.Thumbnail(encodePNG(t, tt.width, tt.height), 320, 1<<20)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if width != tt.width || height != tt.height {
+				t.Errorf("got dimensions %dx%d, want %dx%d", width, height, tt.width, tt.height)
+			}
+
+			decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail is not a JPEG: %v", err)
+			}
+			bounds := decoded.Bounds()
+			if bounds.Dx() != tt.thumbWidth || bounds.Dy() != tt.thumbHeight {
+				t.Errorf("got thumbnail %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), tt.thumbWidth, tt.thumbHeight)
+			}
+		})
+	}
+}
+
+func TestThumbnail_Unsupported(t *testing.T) {
+	_, _, _, err := imaging.Thumbnail([]byte("GIF89a not really"), 320, 1<<20)
+	if !errors.Is(err, imaging.ErrUnsupportedImage) {
+		t.Errorf("expected ErrUnsupportedImage, got %v", err)
+	}
+}
+
+// near reports whether the 16-bit channel got is within the JPEG compression
+// error of the 8-bit channel want.
+func near(got uint32, want uint8) bool {
+	return max(got>>8, uint32(want))-min(got>>8, uint32(want)) <= 8
+}
+
+func TestThumbnail_Colors(t *testing.T) {
+	red := color.NRGBA{R: 200, G: 30, B: 30, A: 255}
+	fill := func(img draw.Image) image.Image {
+		bounds := img.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				img.Set(x, y, red)
+			}
+		}
+		return img
+	}
+	encode := func(t *testing.T, img image.Image, asJPEG bool) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		var err error
+		if asJPEG {
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100})
+		} else {
+			err = png.Encode(&buf, img)
+		}
+		if err != nil {
+			t.Fatalf("encoding image: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		data func(t *testing.T) []byte
+	}{
+		{name: "jpeg", data: func(t *testing.T) []byte { return encode(t, fill(image.NewRGBA(image.Rect(0, 0, 640, 480))), true) }},
+		{name: "translucent png", data: func(t *testing.T) []byte {
+			img := fill(image.NewNRGBA(image.Rect(0, 0, 640, 480))).(*image.NRGBA)
+			img.SetNRGBA(0, 0, color.NRGBA{R: 200, G: 30, B: 30, A: 254})
+			return encode(t, img, false)
+		}},
+		{name: "paletted png", data: func(t *testing.T) []byte {
+			return encode(t, fill(image.NewPaletted(image.Rect(0, 0, 640, 480), color.Palette{color.Black, red})), false)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail, _, _, err := imaging.Thumbnail(tt.data(t), 320, 1<<20)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail is not a JPEG: %v", err)
+			}
+			r, g, b, _ := decoded.At(160, 120).RGBA()
+			if !near(r, red.R) || !near(g, red.G) || !near(b, red.B) {
+				t.Errorf("got color %d,%d,%d, want about %d,%d,%d", r>>8, g>>8, b>>8, red.R, red.G, red.B)
+			}
+		})
+	}
+}
+
+func TestThumbnail_TooLarge(t *testing.T) {
+	_, _, _, err := imaging.Thumbnail(encodePNG(t, 200, 100), 320, 200*100-1)
+	if !errors.Is(err, imaging.ErrImageTooLarge) {
+		t.Errorf("expected ErrImageTooLarge, got %v", err)
+	}
+}
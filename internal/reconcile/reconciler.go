@@ -0,0 +1,337 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/client"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+)
+
+const (
+	defaultPageSize    = 100
+	defaultGracePeriod = time.Minute
+)
+
+type Action string
+
+const (
+	ActionCreateListing       Action = "create_listing"
+	ActionUpdateListing       Action = "update_listing"
+	ActionUpdateListingStatus Action = "update_listing_status"
+	ActionDeleteListing       Action = "delete_listing"
+)
+
+type Config struct {
+	// PageSize is the number of vehicles and listings read per request.
+	PageSize int
+	// GracePeriod skips vehicles changed this recently, since their events
+	// may still be waiting in the outbox. A negative value disables it.
+	GracePeriod time.Duration
+	// DryRun only reports the differences without repairing them.
+	DryRun bool
+	// Currencies are the ones repaired listings also carry converted prices
+	// in, as the showcase publisher sends them.
+	Currencies []string
+}
+
+// Discrepancy is a listing that does not match the catalog, with the actions
+// needed to fix it.
+type Discrepancy struct {
+	VehicleID string   `json:"vehicle_id"`
+	Fields    []string `json:"fields,omitempty"`
+	Actions   []Action `json:"actions"`
+	Repaired  bool     `json:"repaired"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// Report summarizes a reconciliation run. Missing vehicles have no listing,
+// stale listings differ from their vehicle and orphaned listings point at a
+// vehicle that no longer exists in the catalog.
+type Report struct {
+	DryRun          bool          `json:"dry_run"`
+	StartedAt       time.Time     `json:"started_at"`
+	FinishedAt      time.Time     `json:"finished_at"`
+	VehiclesScanned int           `json:"vehicles_scanned"`
+	ListingsScanned int           `json:"listings_scanned"`
+	Skipped         int           `json:"skipped"`
+	Repaired        int           `json:"repaired"`
+	Failed          int           `json:"failed"`
+	Missing         []Discrepancy `json:"missing"`
+	Stale           []Discrepancy `json:"stale"`
+	Orphaned        []Discrepancy `json:"orphaned"`
+}
+
+// Reconciler compares the catalog with the showcase listings and, unless
+// running dry, issues the showcase calls that bring them back in sync.
+type Reconciler struct {
+	repo     repository.VehicleRepository
+	showcase client.ShowcaseClientInterface
+	rates    usecase.ExchangeRateProvider
+	images   usecase.VehicleImageLister
+	config   Config
+}
+
+func NewReconciler(repo repository.VehicleRepository, showcase client.ShowcaseClientInterface, rates usecase.ExchangeRateProvider, images usecase.VehicleImageLister, config Config) *Reconciler {
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.GracePeriod == 0 {
+		config.GracePeriod = defaultGracePeriod
+	}
+
+	return &Reconciler{
+		repo:     repo,
+		showcase: showcase,
+		rates:    rates,
+		images:   images,
+		config:   config,
+	}
+}
+
+// Run performs a full reconciliation. Failing repairs are recorded in the
+// report instead of aborting the run; only failures to read either side
+// return an error.
+func (r *Reconciler) Run(ctx context.Context) (*Report, error) {
+	report := &Report{
+		DryRun:    r.config.DryRun,
+		StartedAt: time.Now().UTC(),
+		Missing:   []Discrepancy{},
+		Stale:     []Discrepancy{},
+		Orphaned:  []Discrepancy{},
+	}
+
+	vehicles, err := r.loadVehicles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading vehicles: %w", err)
+	}
+	report.VehiclesScanned = len(vehicles)
+
+	listings, err := r.loadListings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading showcase listings: %w", err)
+	}
+	report.ListingsScanned = len(listings)
+
+	cutoff := report.StartedAt.Add(-r.config.GracePeriod)
+	for _, vehicle := range vehicles {
+		if vehicle.UpdatedAt.After(cutoff) {
+			report.Skipped++
+			continue
+		}
+
+		images, err := r.images.Images(ctx, vehicle.ID)
+		if err != nil {
+			return nil, fmt.Errorf("loading images of vehicle %s: %w", vehicle.ID, err)
+		}
+		pictures := usecase.ListingImages(images)
+
+		listing, ok := listings[vehicle.ID]
+		if !ok {
+			discrepancy := Discrepancy{VehicleID: vehicle.ID, Actions: []Action{ActionCreateListing}}
+			r.repair(ctx, report, &discrepancy, vehicle, pictures)
+			report.Missing = append(report.Missing, discrepancy)
+			continue
+		}
+
+		discrepancy, stale := diff(vehicle, pictures, listing)
+		if stale {
+			r.repair(ctx, report, &discrepancy, vehicle, pictures)
+			report.Stale = append(report.Stale, discrepancy)
+		}
+	}
+
+	for vehicleID := range listings {
+		if _, ok := vehicles[vehicleID]; ok {
+			continue
+		}
+
+		// The vehicle may have been created after the catalog was read.
+		_, err := r.repo.GetByID(ctx, vehicleID)
+		if err == nil {
+			report.Skipped++
+			continue
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("checking vehicle %s: %w", vehicleID, err)
+		}
+
+		discrepancy := Discrepancy{VehicleID: vehicleID, Actions: []Action{ActionDeleteListing}}
+		r.repair(ctx, report, &discrepancy, nil, nil)
+		report.Orphaned = append(report.Orphaned, discrepancy)
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	return report, nil
+}
+
+func (r *Reconciler) loadVehicles(ctx context.Context) (map[string]*domain.Vehicle, error) {
+	vehicles := make(map[string]*domain.Vehicle)
+	params := repository.VehicleListParams{
+		Sort:  repository.VehicleSort{Field: repository.SortByCreatedAt, Order: repository.SortAsc},
+		Limit: r.config.PageSize,
+	}
+
+	for {
+		page, total, err := r.repo.List(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, vehicle := range page {
+			vehicles[vehicle.ID] = vehicle
+		}
+
+		params.Offset += len(page)
+		if len(page) == 0 || params.Offset >= total {
+			return vehicles, nil
+		}
+	}
+}
+
+func (r *Reconciler) loadListings(ctx context.Context) (map[string]dto.ListingDTO, error) {
+	listings := make(map[string]dto.ListingDTO)
+	offset := 0
+
+	for {
+		page, err := r.showcase.ListListings(ctx, r.config.PageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, listing := range page.Items {
+			listings[listing.VehicleID] = listing
+		}
+
+		offset += len(page.Items)
+		if len(page.Items) == 0 || offset >= page.Total {
+			return listings, nil
+		}
+	}
+}
+
+// diff lists the listing fields that differ from the vehicle and its pictures.
+func diff(vehicle *domain.Vehicle, pictures []dto.ListingImageDTO, listing dto.ListingDTO) (Discrepancy, bool) {
+	discrepancy := Discrepancy{VehicleID: vehicle.ID}
+
+	if listing.Brand != vehicle.Brand {
+		discrepancy.Fields = append(discrepancy.Fields, "brand")
+	}
+	if listing.Model != vehicle.Model {
+		discrepancy.Fields = append(discrepancy.Fields, "model")
+	}
+	if listing.Price.Cmp(vehicle.Price) != 0 || listing.Currency != vehicle.Price.Currency() {
+		discrepancy.Fields = append(discrepancy.Fields, "price")
+	}
+	if listing.Mileage != vehicle.Mileage {
+		discrepancy.Fields = append(discrepancy.Fields, "mileage")
+	}
+	if listing.FuelType != string(vehicle.FuelType) {
+		discrepancy.Fields = append(discrepancy.Fields, "fuel_type")
+	}
+	if listing.Transmission != string(vehicle.Transmission) {
+		discrepancy.Fields = append(discrepancy.Fields, "transmission")
+	}
+	if listing.BodyType != string(vehicle.BodyType) {
+		discrepancy.Fields = append(discrepancy.Fields, "body_type")
+	}
+	if listing.Doors != vehicle.Doors {
+		discrepancy.Fields = append(discrepancy.Fields, "doors")
+	}
+	if listing.EngineDisplacement != vehicle.EngineDisplacement {
+		discrepancy.Fields = append(discrepancy.Fields, "engine_displacement")
+	}
+	if listing.Condition != string(vehicle.Condition) {
+		discrepancy.Fields = append(discrepancy.Fields, "condition")
+	}
+	if !slices.Equal(listing.Images, pictures) {
+		discrepancy.Fields = append(discrepancy.Fields, "images")
+	}
+	if len(discrepancy.Fields) > 0 {
+		discrepancy.Actions = append(discrepancy.Actions, ActionUpdateListing)
+	}
+	if listing.Status != string(vehicle.Status) {
+		discrepancy.Fields = append(discrepancy.Fields, "status")
+		discrepancy.Actions = append(discrepancy.Actions, ActionUpdateListingStatus)
+	}
+
+	return discrepancy, len(discrepancy.Actions) > 0
+}
+
+// repair runs the actions of discrepancy, stopping at the first failure.
+// vehicle and its pictures are nil for orphaned listings.
+func (r *Reconciler) repair(ctx context.Context, report *Report, discrepancy *Discrepancy, vehicle *domain.Vehicle, pictures []dto.ListingImageDTO) {
+	if r.config.DryRun {
+		return
+	}
+
+	for _, action := range discrepancy.Actions {
+		var err error
+		switch action {
+		case ActionCreateListing:
+			var converted []dto.ConvertedPriceDTO
+			converted, err = usecase.ListingPrices(ctx, r.rates, r.config.Currencies, vehicle.ID, vehicle.Price)
+			if err != nil {
+				break
+			}
+			err = r.showcase.CreateListing(ctx, dto.CreateListingDTO{
+				VehicleID:       vehicle.ID,
+				Brand:           vehicle.Brand,
+				Model:           vehicle.Model,
+				Price:           vehicle.Price,
+				Currency:        vehicle.Price.Currency(),
+				ConvertedPrices: converted,
+				Status:          string(vehicle.Status),
+
+				Mileage:            vehicle.Mileage,
+				FuelType:           string(vehicle.FuelType),
+				Transmission:       string(vehicle.Transmission),
+				BodyType:           string(vehicle.BodyType),
+				Doors:              vehicle.Doors,
+				EngineDisplacement: vehicle.EngineDisplacement,
+				Condition:          string(vehicle.Condition),
+				Images:             pictures,
+			})
+		case ActionUpdateListing:
+			var converted []dto.ConvertedPriceDTO
+			converted, err = usecase.ListingPrices(ctx, r.rates, r.config.Currencies, vehicle.ID, vehicle.Price)
+			if err != nil {
+				break
+			}
+			err = r.showcase.UpdateListing(ctx, vehicle.ID, dto.UpdateListingDTO{
+				Brand:           vehicle.Brand,
+				Model:           vehicle.Model,
+				Price:           vehicle.Price,
+				Currency:        vehicle.Price.Currency(),
+				ConvertedPrices: converted,
+
+				Mileage:            vehicle.Mileage,
+				FuelType:           string(vehicle.FuelType),
+				Transmission:       string(vehicle.Transmission),
+				BodyType:           string(vehicle.BodyType),
+				Doors:              vehicle.Doors,
+				EngineDisplacement: vehicle.EngineDisplacement,
+				Condition:          string(vehicle.Condition),
+				Images:             pictures,
+			})
+		case ActionUpdateListingStatus:
+			err = r.showcase.UpdateListingStatus(ctx, vehicle.ID, dto.UpdateListingStatusDTO{
+				Status: string(vehicle.Status),
+			})
+		case ActionDeleteListing:
+			err = r.showcase.DeleteListing(ctx, discrepancy.VehicleID)
+		}
+		if err != nil {
+			discrepancy.Error = fmt.Sprintf("%s: %v", action, err)
+			report.Failed++
+			return
+		}
+	}
+
+	discrepancy.Repaired = true
+	report.Repaired++
+}
@@ -0,0 +1,246 @@
+package reconcile_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	mclient "github.com/NicolasNSC/catalog-service-fiap/internal/client/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/reconcile"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	musecase "github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ReconcilerSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	repo     *mocks.MockVehicleRepository
+	showcase *mclient.MockShowcaseClientInterface
+	rates    *musecase.MockExchangeRateProvider
+	images   *musecase.MockVehicleImageLister
+}
+
+func (suite *ReconcilerSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.repo = mocks.NewMockVehicleRepository(ctrl)
+	suite.showcase = mclient.NewMockShowcaseClientInterface(ctrl)
+	suite.rates = musecase.NewMockExchangeRateProvider(ctrl)
+	suite.images = musecase.NewMockVehicleImageLister(ctrl)
+}
+
+func Test_ReconcilerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ReconcilerSuite))
+}
+
+var lastWeek = time.Now().Add(-7 * 24 * time.Hour)
+
+// brl returns an amount in whole reais.
+func brl(amount int64) domain.Money {
+	return domain.NewMoney(amount*100, "BRL")
+}
+
+func vehicle(id string, price int64, status domain.VehicleStatus) *domain.Vehicle {
+	return &domain.Vehicle{ID: id, Brand: "Toyota", Model: "Corolla", Price: brl(price), Status: status, UpdatedAt: lastWeek}
+}
+
+func listing(id string, price int64, status string) dto.ListingDTO {
+	return dto.ListingDTO{VehicleID: id, Brand: "Toyota", Model: "Corolla", Price: brl(price), Currency: "BRL", Status: status}
+}
+
+// expectCatalog serves vehicles in pages of two.
+func (suite *ReconcilerSuite) expectCatalog(vehicles ...*domain.Vehicle) {
+	for offset := 0; offset == 0 || offset < len(vehicles); offset += 2 {
+		end := min(offset+2, len(vehicles))
+		suite.repo.EXPECT().
+			List(suite.ctx, repository.VehicleListParams{
+				Sort:   repository.VehicleSort{Field: repository.SortByCreatedAt, Order: repository.SortAsc},
+				Limit:  2,
+				Offset: offset,
+			}).
+			Return(vehicles[offset:end], len(vehicles), nil)
+	}
+}
+
+// expectImages serves no images for each of vehicleIDs.
+func (suite *ReconcilerSuite) expectImages(vehicleIDs ...string) {
+	for _, id := range vehicleIDs {
+		suite.images.EXPECT().Images(suite.ctx, id).Return(nil, nil)
+	}
+}
+
+// expectListings serves listings in pages of two.
+func (suite *ReconcilerSuite) expectListings(listings ...dto.ListingDTO) {
+	for offset := 0; offset == 0 || offset < len(listings); offset += 2 {
+		end := min(offset+2, len(listings))
+		suite.showcase.EXPECT().
+			ListListings(suite.ctx, 2, offset).
+			Return(&dto.ListingPageDTO{Items: listings[offset:end], Total: len(listings), Limit: 2, Offset: offset}, nil)
+	}
+}
+
+func (suite *ReconcilerSuite) Test_Run() {
+	suite.T().Run("should repair missing, stale and orphaned listings", func(t *testing.T) {
+		suite.expectCatalog(
+			vehicle("v1", 100000, domain.StatusAvailable),
+			vehicle("v2", 90000, domain.StatusSold),
+			vehicle("v3", 80000, domain.StatusReserved),
+		)
+		suite.expectListings(
+			listing("v1", 100000, "AVAILABLE"),
+			listing("v2", 95000, "AVAILABLE"),
+			listing("gone", 50000, "AVAILABLE"),
+		)
+		suite.images.EXPECT().Images(suite.ctx, "v3").Return([]dto.OutputVehicleImageDTO{{ID: "i1", URL: "http://media/i1.jpg", ThumbnailURL: "http://media/i1_thumb.jpg", Cover: true}}, nil)
+		suite.expectImages("v1", "v2")
+		suite.repo.EXPECT().GetByID(suite.ctx, "gone").Return(nil, domain.ErrNotFound)
+
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "USD").Return(big.NewRat(1, 5), nil).Times(2)
+
+		suite.showcase.EXPECT().CreateListing(suite.ctx, dto.CreateListingDTO{
+			VehicleID: "v3", Brand: "Toyota", Model: "Corolla", Price: brl(80000), Currency: "BRL", Status: "RESERVED",
+			ConvertedPrices: []dto.ConvertedPriceDTO{{Price: domain.NewMoney(1600000, "USD"), Currency: "USD"}},
+			Images:          []dto.ListingImageDTO{{URL: "http://media/i1.jpg", ThumbnailURL: "http://media/i1_thumb.jpg", Cover: true}},
+		}).Return(nil)
+		suite.showcase.EXPECT().UpdateListing(suite.ctx, "v2", dto.UpdateListingDTO{
+			Brand: "Toyota", Model: "Corolla", Price: brl(90000), Currency: "BRL",
+			ConvertedPrices: []dto.ConvertedPriceDTO{{Price: domain.NewMoney(1800000, "USD"), Currency: "USD"}},
+			Images:          []dto.ListingImageDTO{},
+		}).Return(nil)
+		suite.showcase.EXPECT().UpdateListingStatus(suite.ctx, "v2", dto.UpdateListingStatusDTO{Status: "SOLD"}).Return(nil)
+		suite.showcase.EXPECT().DeleteListing(suite.ctx, "gone").Return(nil)
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2, Currencies: []string{"BRL", "USD"}})
+		report, err := reconciler.Run(suite.ctx)
+		suite.NoError(err)
+
+		suite.Equal(3, report.VehiclesScanned)
+		suite.Equal(3, report.ListingsScanned)
+		suite.Equal(3, report.Repaired)
+		suite.Equal(0, report.Failed)
+		suite.Equal([]reconcile.Discrepancy{{VehicleID: "v3", Actions: []reconcile.Action{reconcile.ActionCreateListing}, Repaired: true}}, report.Missing)
+		suite.Equal([]reconcile.Discrepancy{{
+			VehicleID: "v2",
+			Fields:    []string{"price", "status"},
+			Actions:   []reconcile.Action{reconcile.ActionUpdateListing, reconcile.ActionUpdateListingStatus},
+			Repaired:  true,
+		}}, report.Stale)
+		suite.Equal([]reconcile.Discrepancy{{VehicleID: "gone", Actions: []reconcile.Action{reconcile.ActionDeleteListing}, Repaired: true}}, report.Orphaned)
+	})
+
+	suite.T().Run("should update listings whose attributes or images differ", func(t *testing.T) {
+		used := vehicle("v1", 100000, domain.StatusAvailable)
+		used.Mileage = 42000
+		used.Condition = domain.ConditionUsed
+		stale := listing("v1", 100000, "AVAILABLE")
+		stale.Images = []dto.ListingImageDTO{{URL: "http://media/old.jpg", ThumbnailURL: "http://media/old_thumb.jpg", Cover: true}}
+		suite.expectCatalog(used)
+		suite.expectListings(stale)
+		suite.expectImages("v1")
+
+		suite.showcase.EXPECT().UpdateListing(suite.ctx, "v1", dto.UpdateListingDTO{
+			Brand: "Toyota", Model: "Corolla", Price: brl(100000), Currency: "BRL",
+			Mileage: 42000, Condition: "USED", Images: []dto.ListingImageDTO{},
+		}).Return(nil)
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2})
+		report, err := reconciler.Run(suite.ctx)
+		suite.NoError(err)
+
+		suite.Equal([]reconcile.Discrepancy{{
+			VehicleID: "v1",
+			Fields:    []string{"mileage", "condition", "images"},
+			Actions:   []reconcile.Action{reconcile.ActionUpdateListing},
+			Repaired:  true,
+		}}, report.Stale)
+	})
+
+	suite.T().Run("should only report differences in dry-run mode", func(t *testing.T) {
+		suite.expectCatalog(vehicle("v1", 100000, domain.StatusAvailable))
+		suite.expectListings()
+		suite.expectImages("v1")
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2, DryRun: true})
+		report, err := reconciler.Run(suite.ctx)
+		suite.NoError(err)
+
+		suite.True(report.DryRun)
+		suite.Len(report.Missing, 1)
+		suite.False(report.Missing[0].Repaired)
+		suite.Equal(0, report.Repaired)
+	})
+
+	suite.T().Run("should skip recently changed vehicles and listings of newly created ones", func(t *testing.T) {
+		recent := vehicle("v1", 100000, domain.StatusAvailable)
+		recent.UpdatedAt = time.Now()
+		suite.expectCatalog(recent)
+		suite.expectListings(listing("new", 70000, "AVAILABLE"))
+		suite.repo.EXPECT().GetByID(suite.ctx, "new").Return(vehicle("new", 70000, domain.StatusAvailable), nil)
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2})
+		report, err := reconciler.Run(suite.ctx)
+		suite.NoError(err)
+
+		suite.Equal(2, report.Skipped)
+		suite.Empty(report.Missing)
+		suite.Empty(report.Orphaned)
+	})
+
+	suite.T().Run("should record failed repairs and carry on", func(t *testing.T) {
+		suite.expectCatalog(vehicle("v1", 100000, domain.StatusAvailable), vehicle("v2", 90000, domain.StatusAvailable))
+		suite.expectListings()
+		suite.expectImages("v1", "v2")
+		suite.showcase.EXPECT().CreateListing(suite.ctx, gomock.Any()).Return(assert.AnError)
+		suite.showcase.EXPECT().CreateListing(suite.ctx, gomock.Any()).Return(nil)
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2})
+		report, err := reconciler.Run(suite.ctx)
+		suite.NoError(err)
+
+		suite.Equal(1, report.Failed)
+		suite.Equal(1, report.Repaired)
+		suite.Len(report.Missing, 2)
+	})
+
+	suite.T().Run("should return error when the showcase cannot be listed", func(t *testing.T) {
+		suite.expectCatalog()
+		suite.showcase.EXPECT().ListListings(suite.ctx, 2, 0).Return(nil, assert.AnError)
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2})
+		report, err := reconciler.Run(suite.ctx)
+		suite.Nil(report)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *ReconcilerSuite) Test_ReportJSON() {
+	suite.T().Run("should encode empty categories as arrays", func(t *testing.T) {
+		suite.expectCatalog()
+		suite.expectListings()
+
+		reconciler := reconcile.NewReconciler(suite.repo, suite.showcase, suite.rates, suite.images, reconcile.Config{PageSize: 2, DryRun: true})
+		report, err := reconciler.Run(suite.ctx)
+		suite.NoError(err)
+
+		encoded, err := json.Marshal(report)
+		suite.NoError(err)
+
+		var summary map[string]any
+		suite.NoError(json.Unmarshal(encoded, &summary))
+		suite.Equal(true, summary["dry_run"])
+		suite.Equal([]any{}, summary["missing"])
+		suite.Equal([]any{}, summary["stale"])
+		suite.Equal([]any{}, summary["orphaned"])
+	})
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+//go:generate mockgen -source=catalog_repository.go -destination=./mocks/catalog_repository_mock.go -package=mocks
+type CatalogRepository interface {
+	CreateBrand(ctx context.Context, brand *domain.Brand) error
+	GetBrand(ctx context.Context, id string) (*domain.Brand, error)
+	// FindBrand returns the brand whose name or alias has the given
+	// domain.CatalogKey, or ErrNotFound.
+	FindBrand(ctx context.Context, key string) (*domain.Brand, error)
+	ListBrands(ctx context.Context) ([]*domain.Brand, error)
+	// UpdateBrand replaces the name and aliases of the brand.
+	UpdateBrand(ctx context.Context, brand *domain.Brand) error
+	// DeleteBrand removes the brand together with its models.
+	DeleteBrand(ctx context.Context, id string) error
+
+	CreateModel(ctx context.Context, model *domain.Model) error
+	GetModel(ctx context.Context, brandID, id string) (*domain.Model, error)
+	// FindModel returns the model of the brand whose name or alias has the
+	// given domain.CatalogKey, or ErrNotFound.
+	FindModel(ctx context.Context, brandID, key string) (*domain.Model, error)
+	ListModels(ctx context.Context, brandID string) ([]*domain.Model, error)
+	// UpdateModel replaces the name and aliases of the model.
+	UpdateModel(ctx context.Context, model *domain.Model) error
+	DeleteModel(ctx context.Context, brandID, id string) error
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalog_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=catalog_repository.go -destination=./mocks/catalog_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogRepository is a mock of CatalogRepository interface.
+type MockCatalogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogRepositoryMockRecorder is the mock recorder for MockCatalogRepository.
+type MockCatalogRepositoryMockRecorder struct {
+	mock *MockCatalogRepository
+}
+
+// NewMockCatalogRepository creates a new mock instance.
+func NewMockCatalogRepository(ctrl *gomock.Controller) *MockCatalogRepository {
+	mock := &MockCatalogRepository{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepository) EXPECT() *MockCatalogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogRepository) CreateBrand(ctx context.Context, brand *domain.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", ctx, brand)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogRepositoryMockRecorder) CreateBrand(ctx, brand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogRepository)(nil).CreateBrand), ctx, brand)
+}
+
+// CreateModel mocks base method.
+func (m *MockCatalogRepository) CreateModel(ctx context.Context, model *domain.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogRepositoryMockRecorder) CreateModel(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogRepository)(nil).CreateModel), ctx, model)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockCatalogRepository) DeleteBrand(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockCatalogRepositoryMockRecorder) DeleteBrand(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockCatalogRepository)(nil).DeleteBrand), ctx, id)
+}
+
+// DeleteModel mocks base method.
+func (m *MockCatalogRepository) DeleteModel(ctx context.Context, brandID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", ctx, brandID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel.
+func (mr *MockCatalogRepositoryMockRecorder) DeleteModel(ctx, brandID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockCatalogRepository)(nil).DeleteModel), ctx, brandID, id)
+}
+
+// FindBrand mocks base method.
+func (m *MockCatalogRepository) FindBrand(ctx context.Context, key string) (*domain.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBrand", ctx, key)
+	ret0, _ := ret[0].(*domain.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBrand indicates an expected call of FindBrand.
+func (mr *MockCatalogRepositoryMockRecorder) FindBrand(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBrand", reflect.TypeOf((*MockCatalogRepository)(nil).FindBrand), ctx, key)
+}
+
+// FindModel mocks base method.
+func (m *MockCatalogRepository) FindModel(ctx context.Context, brandID, key string) (*domain.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindModel", ctx, brandID, key)
+	ret0, _ := ret[0].(*domain.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindModel indicates an expected call of FindModel.
+func (mr *MockCatalogRepositoryMockRecorder) FindModel(ctx, brandID, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindModel", reflect.TypeOf((*MockCatalogRepository)(nil).FindModel), ctx, brandID, key)
+}
+
+// GetBrand mocks base method.
+func (m *MockCatalogRepository) GetBrand(ctx context.Context, id string) (*domain.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrand", ctx, id)
+	ret0, _ := ret[0].(*domain.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrand indicates an expected call of GetBrand.
+func (mr *MockCatalogRepositoryMockRecorder) GetBrand(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrand", reflect.TypeOf((*MockCatalogRepository)(nil).GetBrand), ctx, id)
+}
+
+// GetModel mocks base method.
+func (m *MockCatalogRepository) GetModel(ctx context.Context, brandID, id string) (*domain.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModel", ctx, brandID, id)
+	ret0, _ := ret[0].(*domain.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModel indicates an expected call of GetModel.
+func (mr *MockCatalogRepositoryMockRecorder) GetModel(ctx, brandID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockCatalogRepository)(nil).GetModel), ctx, brandID, id)
+}
+
+// ListBrands mocks base method.
+func (m *MockCatalogRepository) ListBrands(ctx context.Context) ([]*domain.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrands", ctx)
+	ret0, _ := ret[0].([]*domain.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrands indicates an expected call of ListBrands.
+func (mr *MockCatalogRepositoryMockRecorder) ListBrands(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrands", reflect.TypeOf((*MockCatalogRepository)(nil).ListBrands), ctx)
+}
+
+// ListModels mocks base method.
+func (m *MockCatalogRepository) ListModels(ctx context.Context, brandID string) ([]*domain.Model, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", ctx, brandID)
+	ret0, _ := ret[0].([]*domain.Model)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels.
+func (mr *MockCatalogRepositoryMockRecorder) ListModels(ctx, brandID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockCatalogRepository)(nil).ListModels), ctx, brandID)
+}
+
+// UpdateBrand mocks base method.
+func (m *MockCatalogRepository) UpdateBrand(ctx context.Context, brand *domain.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrand", ctx, brand)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockCatalogRepositoryMockRecorder) UpdateBrand(ctx, brand any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockCatalogRepository)(nil).UpdateBrand), ctx, brand)
+}
+
+// UpdateModel mocks base method.
+func (m *MockCatalogRepository) UpdateModel(ctx context.Context, model *domain.Model) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModel", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockCatalogRepositoryMockRecorder) UpdateModel(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockCatalogRepository)(nil).UpdateModel), ctx, model)
+}
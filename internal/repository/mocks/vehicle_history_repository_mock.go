@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_history_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vehicle_history_repository.go -destination=./mocks/vehicle_history_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVehicleHistoryRepository is a mock of VehicleHistoryRepository interface.
+type MockVehicleHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleHistoryRepositoryMockRecorder is the mock recorder for MockVehicleHistoryRepository.
+type MockVehicleHistoryRepositoryMockRecorder struct {
+	mock *MockVehicleHistoryRepository
+}
+
+// NewMockVehicleHistoryRepository creates a new mock instance.
+func NewMockVehicleHistoryRepository(ctrl *gomock.Controller) *MockVehicleHistoryRepository {
+	mock := &MockVehicleHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockVehicleHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleHistoryRepository) EXPECT() *MockVehicleHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockVehicleHistoryRepository) Add(ctx context.Context, entry *domain.VehicleHistoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockVehicleHistoryRepositoryMockRecorder) Add(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockVehicleHistoryRepository)(nil).Add), ctx, entry)
+}
+
+// ListByVehicle mocks base method.
+func (m *MockVehicleHistoryRepository) ListByVehicle(ctx context.Context, vehicleID string, limit, offset int) ([]*domain.VehicleHistoryEntry, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByVehicle", ctx, vehicleID, limit, offset)
+	ret0, _ := ret[0].([]*domain.VehicleHistoryEntry)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByVehicle indicates an expected call of ListByVehicle.
+func (mr *MockVehicleHistoryRepositoryMockRecorder) ListByVehicle(ctx, vehicleID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByVehicle", reflect.TypeOf((*MockVehicleHistoryRepository)(nil).ListByVehicle), ctx, vehicleID, limit, offset)
+}
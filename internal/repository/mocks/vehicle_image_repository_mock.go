@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_image_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vehicle_image_repository.go -destination=./mocks/vehicle_image_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVehicleImageRepository is a mock of VehicleImageRepository interface.
+type MockVehicleImageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleImageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleImageRepositoryMockRecorder is the mock recorder for MockVehicleImageRepository.
+type MockVehicleImageRepositoryMockRecorder struct {
+	mock *MockVehicleImageRepository
+}
+
+// NewMockVehicleImageRepository creates a new mock instance.
+func NewMockVehicleImageRepository(ctrl *gomock.Controller) *MockVehicleImageRepository {
+	mock := &MockVehicleImageRepository{ctrl: ctrl}
+	mock.recorder = &MockVehicleImageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleImageRepository) EXPECT() *MockVehicleImageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockVehicleImageRepository) Add(ctx context.Context, image *domain.VehicleImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockVehicleImageRepositoryMockRecorder) Add(ctx, image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockVehicleImageRepository)(nil).Add), ctx, image)
+}
+
+// Delete mocks base method.
+func (m *MockVehicleImageRepository) Delete(ctx context.Context, vehicleID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, vehicleID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleImageRepositoryMockRecorder) Delete(ctx, vehicleID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleImageRepository)(nil).Delete), ctx, vehicleID, id)
+}
+
+// ListByVehicle mocks base method.
+func (m *MockVehicleImageRepository) ListByVehicle(ctx context.Context, vehicleID string) ([]*domain.VehicleImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByVehicle", ctx, vehicleID)
+	ret0, _ := ret[0].([]*domain.VehicleImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByVehicle indicates an expected call of ListByVehicle.
+func (mr *MockVehicleImageRepositoryMockRecorder) ListByVehicle(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByVehicle", reflect.TypeOf((*MockVehicleImageRepository)(nil).ListByVehicle), ctx, vehicleID)
+}
+
+// Update mocks base method.
+func (m *MockVehicleImageRepository) Update(ctx context.Context, image *domain.VehicleImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleImageRepositoryMockRecorder) Update(ctx, image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleImageRepository)(nil).Update), ctx, image)
+}
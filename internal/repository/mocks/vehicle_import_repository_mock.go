@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_import_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vehicle_import_repository.go -destination=./mocks/vehicle_import_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVehicleImportJobRepository is a mock of VehicleImportJobRepository interface.
+type MockVehicleImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleImportJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleImportJobRepositoryMockRecorder is the mock recorder for MockVehicleImportJobRepository.
+type MockVehicleImportJobRepositoryMockRecorder struct {
+	mock *MockVehicleImportJobRepository
+}
+
+// NewMockVehicleImportJobRepository creates a new mock instance.
+func NewMockVehicleImportJobRepository(ctrl *gomock.Controller) *MockVehicleImportJobRepository {
+	mock := &MockVehicleImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockVehicleImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleImportJobRepository) EXPECT() *MockVehicleImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVehicleImportJobRepository) Create(ctx context.Context, job *domain.VehicleImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVehicleImportJobRepositoryMockRecorder) Create(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicleImportJobRepository)(nil).Create), ctx, job)
+}
+
+// FailStale mocks base method.
+func (m *MockVehicleImportJobRepository) FailStale(ctx context.Context, message string, staleBefore, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailStale", ctx, message, staleBefore, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailStale indicates an expected call of FailStale.
+func (mr *MockVehicleImportJobRepositoryMockRecorder) FailStale(ctx, message, staleBefore, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailStale", reflect.TypeOf((*MockVehicleImportJobRepository)(nil).FailStale), ctx, message, staleBefore, at)
+}
+
+// GetByID mocks base method.
+func (m *MockVehicleImportJobRepository) GetByID(ctx context.Context, id string) (*domain.VehicleImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.VehicleImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVehicleImportJobRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicleImportJobRepository)(nil).GetByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockVehicleImportJobRepository) Update(ctx context.Context, job *domain.VehicleImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleImportJobRepositoryMockRecorder) Update(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleImportJobRepository)(nil).Update), ctx, job)
+}
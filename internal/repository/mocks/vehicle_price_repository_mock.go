@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_price_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vehicle_price_repository.go -destination=./mocks/vehicle_price_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	repository "github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVehiclePriceRepository is a mock of VehiclePriceRepository interface.
+type MockVehiclePriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehiclePriceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockVehiclePriceRepositoryMockRecorder is the mock recorder for MockVehiclePriceRepository.
+type MockVehiclePriceRepositoryMockRecorder struct {
+	mock *MockVehiclePriceRepository
+}
+
+// NewMockVehiclePriceRepository creates a new mock instance.
+func NewMockVehiclePriceRepository(ctrl *gomock.Controller) *MockVehiclePriceRepository {
+	mock := &MockVehiclePriceRepository{ctrl: ctrl}
+	mock.recorder = &MockVehiclePriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehiclePriceRepository) EXPECT() *MockVehiclePriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockVehiclePriceRepository) Add(ctx context.Context, change *domain.PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockVehiclePriceRepositoryMockRecorder) Add(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockVehiclePriceRepository)(nil).Add), ctx, change)
+}
+
+// ListByVehicle mocks base method.
+func (m *MockVehiclePriceRepository) ListByVehicle(ctx context.Context, vehicleID string) ([]*domain.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByVehicle", ctx, vehicleID)
+	ret0, _ := ret[0].([]*domain.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByVehicle indicates an expected call of ListByVehicle.
+func (mr *MockVehiclePriceRepositoryMockRecorder) ListByVehicle(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByVehicle", reflect.TypeOf((*MockVehiclePriceRepository)(nil).ListByVehicle), ctx, vehicleID)
+}
+
+// Stats mocks base method.
+func (m *MockVehiclePriceRepository) Stats(ctx context.Context, filter repository.PriceStatsFilter, now time.Time) ([]*domain.PriceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, filter, now)
+	ret0, _ := ret[0].([]*domain.PriceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockVehiclePriceRepositoryMockRecorder) Stats(ctx, filter, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockVehiclePriceRepository)(nil).Stats), ctx, filter, now)
+}
@@ -12,8 +12,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	repository "github.com/NicolasNSC/catalog-service-fiap/internal/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,35 @@ func (m *MockVehicleRepository) EXPECT() *MockVehicleRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockVehicleRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleRepositoryMockRecorder) Delete(ctx, id, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleRepository)(nil).Delete), ctx, id, deletedAt)
+}
+
+// FindDuplicate mocks base method.
+func (m *MockVehicleRepository) FindDuplicate(ctx context.Context, vehicle *domain.Vehicle) (*domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicate", ctx, vehicle)
+	ret0, _ := ret[0].(*domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicate indicates an expected call of FindDuplicate.
+func (mr *MockVehicleRepositoryMockRecorder) FindDuplicate(ctx, vehicle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicate", reflect.TypeOf((*MockVehicleRepository)(nil).FindDuplicate), ctx, vehicle)
+}
+
 // GetByID mocks base method.
 func (m *MockVehicleRepository) GetByID(ctx context.Context, id string) (*domain.Vehicle, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +87,37 @@ func (mr *MockVehicleRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicleRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockVehicleRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domain.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockVehicleRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockVehicleRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockVehicleRepository) List(ctx context.Context, params repository.VehicleListParams) ([]*domain.Vehicle, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, params)
+	ret0, _ := ret[0].([]*domain.Vehicle)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockVehicleRepositoryMockRecorder) List(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVehicleRepository)(nil).List), ctx, params)
+}
+
 // Save mocks base method.
 func (m *MockVehicleRepository) Save(ctx context.Context, vehicle *domain.Vehicle) error {
 	m.ctrl.T.Helper()
@@ -83,3 +145,17 @@ func (mr *MockVehicleRepositoryMockRecorder) Update(ctx, vehicle any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleRepository)(nil).Update), ctx, vehicle)
 }
+
+// UpdateStatus mocks base method.
+func (m *MockVehicleRepository) UpdateStatus(ctx context.Context, vehicle *domain.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockVehicleRepositoryMockRecorder) UpdateStatus(ctx, vehicle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockVehicleRepository)(nil).UpdateStatus), ctx, vehicle)
+}
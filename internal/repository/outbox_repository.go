@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+//go:generate mockgen -source=outbox_repository.go -destination=./mocks/outbox_repository_mock.go -package=mocks
+type OutboxRepository interface {
+	Add(ctx context.Context, event *domain.OutboxEvent) error
+	// ClaimPending leases up to limit events that are due at now to the caller
+	// until leaseUntil, skipping events leased by another dispatcher. Only the
+	// oldest pending event of each vehicle and target is returned so
+	// notifications are delivered in order; sent and dead events no longer
+	// hold the others back. The lease outlives the transaction, so deliveries can
+	// run outside of it.
+	ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.OutboxEvent, error)
+	// MarkSent, MarkFailed and MarkDead record the outcome of a delivery and
+	// release the lease of the event. MarkFailed schedules another attempt;
+	// MarkDead abandons the event, leaving it in the outbox as a dead letter.
+	MarkSent(ctx context.Context, id string, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, id string, lastError string, failedAt time.Time) error
+}
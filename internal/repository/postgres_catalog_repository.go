@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+// Brands and models keep, next to their name and aliases, the
+// domain.CatalogKey of each, which is what lookups match against.
+const (
+	brandColumns = `id, name, aliases, created_at, updated_at`
+	modelColumns = `id, brand_id, name, aliases, created_at, updated_at`
+)
+
+type postgresCatalogRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCatalogRepository(db *sql.DB) CatalogRepository {
+	return &postgresCatalogRepository{
+		db: db,
+	}
+}
+
+func (r *postgresCatalogRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresCatalogRepository) CreateBrand(ctx context.Context, brand *domain.Brand) error {
+	aliases, aliasKeys, err := encodeAliases(brand.Aliases)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO brands (id, name, name_key, aliases, alias_keys, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = r.executor(ctx).ExecContext(ctx, query,
+		brand.ID,
+		brand.Name,
+		domain.CatalogKey(brand.Name),
+		aliases,
+		aliasKeys,
+		brand.CreatedAt,
+		brand.UpdatedAt,
+	)
+
+	return checkUniqueViolation(err)
+}
+
+func (r *postgresCatalogRepository) GetBrand(ctx context.Context, id string) (*domain.Brand, error) {
+	query := `SELECT ` + brandColumns + ` FROM brands WHERE id = $1`
+
+	brand, err := scanBrand(r.executor(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("brand %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+func (r *postgresCatalogRepository) FindBrand(ctx context.Context, key string) (*domain.Brand, error) {
+	query := `SELECT ` + brandColumns + ` FROM brands
+	          WHERE name_key = $1 OR alias_keys ? $1
+	          ORDER BY name_key = $1 DESC, created_at
+	          LIMIT 1`
+
+	brand, err := scanBrand(r.executor(ctx).QueryRowContext(ctx, query, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("brand %q: %w", key, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+func (r *postgresCatalogRepository) ListBrands(ctx context.Context) ([]*domain.Brand, error) {
+	query := `SELECT ` + brandColumns + ` FROM brands ORDER BY name_key`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	brands := make([]*domain.Brand, 0)
+	for rows.Next() {
+		brand, err := scanBrand(rows)
+		if err != nil {
+			return nil, err
+		}
+		brands = append(brands, brand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return brands, nil
+}
+
+func (r *postgresCatalogRepository) UpdateBrand(ctx context.Context, brand *domain.Brand) error {
+	aliases, aliasKeys, err := encodeAliases(brand.Aliases)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE brands SET name = $1, name_key = $2, aliases = $3, alias_keys = $4, updated_at = $5 WHERE id = $6`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query,
+		brand.Name,
+		domain.CatalogKey(brand.Name),
+		aliases,
+		aliasKeys,
+		brand.UpdatedAt,
+		brand.ID,
+	)
+	if err != nil {
+		return checkUniqueViolation(err)
+	}
+
+	return checkAffected(result, "brand", brand.ID)
+}
+
+func (r *postgresCatalogRepository) DeleteBrand(ctx context.Context, id string) error {
+	result, err := r.executor(ctx).ExecContext(ctx, `DELETE FROM brands WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, "brand", id)
+}
+
+func (r *postgresCatalogRepository) CreateModel(ctx context.Context, model *domain.Model) error {
+	aliases, aliasKeys, err := encodeAliases(model.Aliases)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO models (id, brand_id, name, name_key, aliases, alias_keys, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = r.executor(ctx).ExecContext(ctx, query,
+		model.ID,
+		model.BrandID,
+		model.Name,
+		domain.CatalogKey(model.Name),
+		aliases,
+		aliasKeys,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+
+	return checkUniqueViolation(err)
+}
+
+func (r *postgresCatalogRepository) GetModel(ctx context.Context, brandID, id string) (*domain.Model, error) {
+	query := `SELECT ` + modelColumns + ` FROM models WHERE brand_id = $1 AND id = $2`
+
+	model, err := scanModel(r.executor(ctx).QueryRowContext(ctx, query, brandID, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("model %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (r *postgresCatalogRepository) FindModel(ctx context.Context, brandID, key string) (*domain.Model, error) {
+	query := `SELECT ` + modelColumns + ` FROM models
+	          WHERE brand_id = $1 AND (name_key = $2 OR alias_keys ? $2)
+	          ORDER BY name_key = $2 DESC, created_at
+	          LIMIT 1`
+
+	model, err := scanModel(r.executor(ctx).QueryRowContext(ctx, query, brandID, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("model %q: %w", key, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (r *postgresCatalogRepository) ListModels(ctx context.Context, brandID string) ([]*domain.Model, error) {
+	query := `SELECT ` + modelColumns + ` FROM models WHERE brand_id = $1 ORDER BY name_key`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	models := make([]*domain.Model, 0)
+	for rows.Next() {
+		model, err := scanModel(rows)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, model)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (r *postgresCatalogRepository) UpdateModel(ctx context.Context, model *domain.Model) error {
+	aliases, aliasKeys, err := encodeAliases(model.Aliases)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE models SET name = $1, name_key = $2, aliases = $3, alias_keys = $4, updated_at = $5
+	          WHERE brand_id = $6 AND id = $7`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query,
+		model.Name,
+		domain.CatalogKey(model.Name),
+		aliases,
+		aliasKeys,
+		model.UpdatedAt,
+		model.BrandID,
+		model.ID,
+	)
+	if err != nil {
+		return checkUniqueViolation(err)
+	}
+
+	return checkAffected(result, "model", model.ID)
+}
+
+func (r *postgresCatalogRepository) DeleteModel(ctx context.Context, brandID, id string) error {
+	result, err := r.executor(ctx).ExecContext(ctx, `DELETE FROM models WHERE brand_id = $1 AND id = $2`, brandID, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, "model", id)
+}
+
+// encodeAliases returns the JSON arrays stored in the aliases and alias_keys
+// columns.
+func encodeAliases(aliases []string) ([]byte, []byte, error) {
+	keys := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		keys = append(keys, domain.CatalogKey(alias))
+	}
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	encodedAliases, err := json.Marshal(aliases)
+	if err != nil {
+		return nil, nil, err
+	}
+	encodedKeys, err := json.Marshal(keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodedAliases, encodedKeys, nil
+}
+
+func checkAffected(result sql.Result, kind, id string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s %s: %w", kind, id, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func scanBrand(row rowScanner) (*domain.Brand, error) {
+	var b domain.Brand
+	var aliases []byte
+	err := row.Scan(&b.ID, &b.Name, &aliases, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(aliases, &b.Aliases)
+	if err != nil {
+		return nil, fmt.Errorf("decoding aliases of brand %s: %w", b.ID, err)
+	}
+
+	return &b, nil
+}
+
+func scanModel(row rowScanner) (*domain.Model, error) {
+	var m domain.Model
+	var aliases []byte
+	err := row.Scan(&m.ID, &m.BrandID, &m.Name, &aliases, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(aliases, &m.Aliases)
+	if err != nil {
+		return nil, fmt.Errorf("decoding aliases of model %s: %w", m.ID, err)
+	}
+
+	return &m, nil
+}
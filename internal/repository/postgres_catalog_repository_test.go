@@ -0,0 +1,186 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresCatalogRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresCatalogRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresCatalogRepositoryTestSuite))
+}
+
+func (suite *PostgresCatalogRepositoryTestSuite) Test_CreateBrand() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresCatalogRepository(db)
+	now := time.Now()
+
+	brand := &domain.Brand{
+		ID:        "brand-1",
+		Name:      "Volkswagen",
+		Aliases:   []string{"VW", "Volks Wagen"},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	suite.T().Run("should store the keys of the name and aliases", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO brands").
+			WithArgs("brand-1", "Volkswagen", "volkswagen", []byte(`["VW","Volks Wagen"]`), []byte(`["vw","volks wagen"]`), now, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.CreateBrand(context.Background(), brand)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return ErrConflict when the name is taken", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO brands").
+			WillReturnError(&pgconn.PgError{Code: "23505", Detail: "Key (name_key)=(volkswagen) already exists."})
+
+		err := repo.CreateBrand(context.Background(), brand)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresCatalogRepositoryTestSuite) Test_FindBrand() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresCatalogRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should match the name or an alias", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "aliases", "created_at", "updated_at"}).
+			AddRow("brand-1", "Volkswagen", []byte(`["VW"]`), now, now)
+		mock.ExpectQuery("SELECT (.+) FROM brands\\s+WHERE name_key = \\$1 OR alias_keys \\? \\$1").
+			WithArgs("vw").
+			WillReturnRows(rows)
+
+		brand, err := repo.FindBrand(context.Background(), "vw")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if brand.Name != "Volkswagen" || len(brand.Aliases) != 1 || brand.Aliases[0] != "VW" {
+			t.Errorf("unexpected brand %+v", brand)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when nothing matches", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM brands").
+			WithArgs("fusca").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "aliases", "created_at", "updated_at"}))
+
+		_, err := repo.FindBrand(context.Background(), "fusca")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresCatalogRepositoryTestSuite) Test_UpdateBrand() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresCatalogRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should return ErrNotFound when the brand does not exist", func(t *testing.T) {
+		mock.ExpectExec("UPDATE brands SET").
+			WithArgs("Fiat", "fiat", []byte(`[]`), []byte(`[]`), now, "missing").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateBrand(context.Background(), &domain.Brand{ID: "missing", Name: "Fiat", UpdatedAt: now})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresCatalogRepositoryTestSuite) Test_FindModel() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresCatalogRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should match within the brand", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "brand_id", "name", "aliases", "created_at", "updated_at"}).
+			AddRow("model-1", "brand-1", "Gol", []byte(`[]`), now, now)
+		mock.ExpectQuery("SELECT (.+) FROM models\\s+WHERE brand_id = \\$1 AND \\(name_key = \\$2 OR alias_keys \\? \\$2\\)").
+			WithArgs("brand-1", "gol").
+			WillReturnRows(rows)
+
+		model, err := repo.FindModel(context.Background(), "brand-1", "gol")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if model.Name != "Gol" || model.BrandID != "brand-1" {
+			t.Errorf("unexpected model %+v", model)
+		}
+	})
+}
+
+func (suite *PostgresCatalogRepositoryTestSuite) Test_DeleteModel() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresCatalogRepository(db)
+
+	suite.T().Run("should delete the model of the brand", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM models WHERE brand_id = \\$1 AND id = \\$2").
+			WithArgs("brand-1", "model-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.DeleteModel(context.Background(), "brand-1", "model-1")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when the model does not exist", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM models").
+			WithArgs("brand-1", "missing").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.DeleteModel(context.Background(), "brand-1", "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type postgresOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxRepository(db *sql.DB) OutboxRepository {
+	return &postgresOutboxRepository{
+		db: db,
+	}
+}
+
+func (r *postgresOutboxRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresOutboxRepository) Add(ctx context.Context, event *domain.OutboxEvent) error {
+	query := `INSERT INTO outbox (id, target, event_type, aggregate_id, payload, attempts, next_attempt_at, created_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query,
+		event.ID,
+		event.Target,
+		event.Type,
+		event.AggregateID,
+		event.Payload,
+		event.Attempts,
+		event.NextAttemptAt,
+		event.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *postgresOutboxRepository) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.OutboxEvent, error) {
+	query := `WITH claimed AS (
+	              UPDATE outbox SET claimed_until = $2
+	              WHERE id IN (
+	                  SELECT o.id FROM outbox o
+	                  WHERE o.sent_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= $1
+	                    AND (o.claimed_until IS NULL OR o.claimed_until <= $1)
+	                    AND NOT EXISTS (
+	                        SELECT 1 FROM outbox p
+	                        WHERE p.aggregate_id = o.aggregate_id AND p.target = o.target
+	                          AND p.sent_at IS NULL AND p.failed_at IS NULL AND p.created_at < o.created_at
+	                    )
+	                  ORDER BY o.created_at
+	                  LIMIT $3
+	                  FOR UPDATE SKIP LOCKED
+	              )
+	              RETURNING id, target, event_type, aggregate_id, payload, attempts, last_error, next_attempt_at, created_at
+	          )
+	          SELECT id, target, event_type, aggregate_id, payload, attempts, COALESCE(last_error, ''), next_attempt_at, created_at
+	          FROM claimed
+	          ORDER BY created_at`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*domain.OutboxEvent, 0, limit)
+	for rows.Next() {
+		var e domain.OutboxEvent
+		err := rows.Scan(&e.ID, &e.Target, &e.Type, &e.AggregateID, &e.Payload, &e.Attempts, &e.LastError, &e.NextAttemptAt, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *postgresOutboxRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	query := `UPDATE outbox SET sent_at = $1, attempts = attempts + 1, last_error = NULL, claimed_until = NULL WHERE id = $2`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query, sentAt, id)
+
+	return err
+}
+
+func (r *postgresOutboxRepository) MarkFailed(ctx context.Context, id string, lastError string, nextAttemptAt time.Time) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2, claimed_until = NULL WHERE id = $3`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query, lastError, nextAttemptAt, id)
+
+	return err
+}
+
+func (r *postgresOutboxRepository) MarkDead(ctx context.Context, id string, lastError string, failedAt time.Time) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = $1, failed_at = $2, claimed_until = NULL WHERE id = $3`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query, lastError, failedAt, id)
+
+	return err
+}
@@ -0,0 +1,180 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresOutboxRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresOutboxRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresOutboxRepositoryTestSuite))
+}
+
+func (suite *PostgresOutboxRepositoryTestSuite) Test_Add() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresOutboxRepository(db)
+	transactor := repository.NewPostgresTransactor(db)
+	now := time.Now()
+
+	event := &domain.OutboxEvent{
+		ID:            "evt-1",
+		Target:        "showcase",
+		Type:          domain.EventVehicleCreated,
+		AggregateID:   "123",
+		Payload:       []byte(`{"vehicle_id":"123"}`),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	suite.T().Run("should insert the event inside the caller's transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO outbox").
+			WithArgs(event.ID, event.Target, event.Type, event.AggregateID, event.Payload, 0, now, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			return repo.Add(ctx, event)
+		})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when insert fails", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO outbox").
+			WillReturnError(errors.New("insert error"))
+
+		err := repo.Add(context.Background(), event)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}
+
+func (suite *PostgresOutboxRepositoryTestSuite) Test_ClaimPending() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresOutboxRepository(db)
+	now := time.Now()
+	lease := now.Add(time.Minute)
+
+	suite.T().Run("should lease due events skipping the ones leased by other dispatchers", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"id", "target", "event_type", "aggregate_id", "payload", "attempts", "last_error", "next_attempt_at", "created_at",
+		}).
+			AddRow("evt-1", "showcase", "vehicle.created", "123", []byte(`{}`), 0, "", now, now).
+			AddRow("evt-2", "webhook:https://a.example.com", "vehicle.deleted", "456", []byte(`null`), 2, "timeout", now, now)
+
+		mock.ExpectQuery("UPDATE outbox SET claimed_until = \\$2(.+)WHERE o.sent_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= \\$1\\s+"+
+			"AND \\(o.claimed_until IS NULL OR o.claimed_until <= \\$1\\)(.+)p.target = o.target(.+)LIMIT \\$3\\s+FOR UPDATE SKIP LOCKED(.+)FROM claimed\\s+ORDER BY created_at").
+			WithArgs(now, lease, 10).
+			WillReturnRows(rows)
+
+		events, err := repo.ClaimPending(context.Background(), now, lease, 10)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(events))
+		}
+		if events[1].Target != "webhook:https://a.example.com" || events[1].Type != domain.EventVehicleDeleted || events[1].Attempts != 2 || events[1].LastError != "timeout" {
+			t.Errorf("unexpected event %+v", events[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when query fails", func(t *testing.T) {
+		mock.ExpectQuery("UPDATE outbox").
+			WillReturnError(errors.New("query error"))
+
+		events, err := repo.ClaimPending(context.Background(), now, lease, 10)
+		if err == nil || events != nil {
+			t.Errorf("expected error and nil events, got err=%v, events=%+v", err, events)
+		}
+	})
+}
+
+func (suite *PostgresOutboxRepositoryTestSuite) Test_MarkOutcome() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresOutboxRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should mark event as sent", func(t *testing.T) {
+		mock.ExpectExec("UPDATE outbox SET sent_at = \\$1, attempts = attempts \\+ 1, last_error = NULL, claimed_until = NULL WHERE id = \\$2").
+			WithArgs(now, "evt-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.MarkSent(context.Background(), "evt-1", now)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should record the failure and schedule the next attempt", func(t *testing.T) {
+		next := now.Add(time.Minute)
+		mock.ExpectExec("UPDATE outbox SET attempts = attempts \\+ 1, last_error = \\$1, next_attempt_at = \\$2, claimed_until = NULL WHERE id = \\$3").
+			WithArgs("showcase down", next, "evt-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.MarkFailed(context.Background(), "evt-1", "showcase down", next)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should abandon the event as a dead letter", func(t *testing.T) {
+		mock.ExpectExec("UPDATE outbox SET attempts = attempts \\+ 1, last_error = \\$1, failed_at = \\$2, claimed_until = NULL WHERE id = \\$3").
+			WithArgs("listing not found", now, "evt-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.MarkDead(context.Background(), "evt-1", "listing not found", now)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
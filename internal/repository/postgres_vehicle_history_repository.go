@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type postgresVehicleHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresVehicleHistoryRepository(db *sql.DB) VehicleHistoryRepository {
+	return &postgresVehicleHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *postgresVehicleHistoryRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresVehicleHistoryRepository) Add(ctx context.Context, entry *domain.VehicleHistoryEntry) error {
+	query := `INSERT INTO vehicle_history (id, vehicle_id, action, before, after, actor, changed_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query,
+		entry.ID,
+		entry.VehicleID,
+		entry.Action,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		entry.Actor,
+		entry.ChangedAt,
+	)
+
+	return err
+}
+
+func (r *postgresVehicleHistoryRepository) ListByVehicle(ctx context.Context, vehicleID string, limit, offset int) ([]*domain.VehicleHistoryEntry, int, error) {
+	var total int
+	err := r.executor(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM vehicle_history WHERE vehicle_id = $1`, vehicleID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT id, vehicle_id, action, before, after, actor, changed_at
+	          FROM vehicle_history
+	          WHERE vehicle_id = $1
+	          ORDER BY changed_at DESC, id
+	          LIMIT $2 OFFSET $3`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, vehicleID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.VehicleHistoryEntry, 0, limit)
+	for rows.Next() {
+		var e domain.VehicleHistoryEntry
+		err := rows.Scan(&e.ID, &e.VehicleID, &e.Action, &e.Before, &e.After, &e.Actor, &e.ChangedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// nullableJSON stores an absent snapshot as NULL rather than an empty string,
+// which is not valid JSONB.
+func nullableJSON(data []byte) any {
+	if data == nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,136 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresVehicleHistoryRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresVehicleHistoryRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresVehicleHistoryRepositoryTestSuite))
+}
+
+func (suite *PostgresVehicleHistoryRepositoryTestSuite) Test_Add() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleHistoryRepository(db)
+	transactor := repository.NewPostgresTransactor(db)
+	now := time.Now()
+
+	suite.T().Run("should insert the entry inside the caller's transaction", func(t *testing.T) {
+		entry := &domain.VehicleHistoryEntry{
+			ID:        "h1",
+			VehicleID: "v1",
+			Action:    domain.HistoryUpdate,
+			Before:    []byte(`{"price":80000}`),
+			After:     []byte(`{"price":75000}`),
+			Actor:     "ana",
+			ChangedAt: now,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO vehicle_history").
+			WithArgs("h1", "v1", domain.HistoryUpdate, entry.Before, entry.After, "ana", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			return repo.Add(ctx, entry)
+		})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should store a missing snapshot as NULL", func(t *testing.T) {
+		entry := &domain.VehicleHistoryEntry{
+			ID:        "h2",
+			VehicleID: "v1",
+			Action:    domain.HistoryCreate,
+			After:     []byte(`{"price":80000}`),
+			Actor:     "anonymous",
+			ChangedAt: now,
+		}
+
+		mock.ExpectExec("INSERT INTO vehicle_history").
+			WithArgs("h2", "v1", domain.HistoryCreate, nil, entry.After, "anonymous", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Add(context.Background(), entry)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleHistoryRepositoryTestSuite) Test_ListByVehicle() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleHistoryRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should return the requested page with the total", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicle_history WHERE vehicle_id = \\$1").
+			WithArgs("v1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_history\\s+WHERE vehicle_id = \\$1\\s+ORDER BY changed_at DESC, id\\s+LIMIT \\$2 OFFSET \\$3").
+			WithArgs("v1", 2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "vehicle_id", "action", "before", "after", "actor", "changed_at"}).
+				AddRow("h2", "v1", "UPDATE", []byte(`{}`), []byte(`{}`), "ana", now).
+				AddRow("h1", "v1", "CREATE", nil, []byte(`{}`), "anonymous", now))
+
+		entries, total, err := repo.ListByVehicle(context.Background(), "v1", 2, 1)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if total != 3 || len(entries) != 2 {
+			t.Errorf("expected 2 of 3 entries, got %d of %d", len(entries), total)
+		}
+		if entries[1].Action != domain.HistoryCreate || entries[1].Before != nil {
+			t.Errorf("unexpected entry %+v", entries[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when query fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicle_history").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_history").
+			WillReturnError(errors.New("query error"))
+
+		entries, _, err := repo.ListByVehicle(context.Background(), "v1", 20, 0)
+		if err == nil || entries != nil {
+			t.Errorf("expected error and nil entries, got err=%v, entries=%+v", err, entries)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type postgresVehicleImageRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresVehicleImageRepository(db *sql.DB) VehicleImageRepository {
+	return &postgresVehicleImageRepository{
+		db: db,
+	}
+}
+
+func (r *postgresVehicleImageRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresVehicleImageRepository) Add(ctx context.Context, image *domain.VehicleImage) error {
+	query := `INSERT INTO vehicle_images (id, vehicle_id, blob_key, thumbnail_key, content_type, size, width, height, position, is_cover, created_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query,
+		image.ID,
+		image.VehicleID,
+		image.Key,
+		image.ThumbnailKey,
+		image.ContentType,
+		image.Size,
+		image.Width,
+		image.Height,
+		image.Position,
+		image.Cover,
+		image.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *postgresVehicleImageRepository) ListByVehicle(ctx context.Context, vehicleID string) ([]*domain.VehicleImage, error) {
+	query := `SELECT id, vehicle_id, blob_key, thumbnail_key, content_type, size, width, height, position, is_cover, created_at
+	          FROM vehicle_images
+	          WHERE vehicle_id = $1
+	          ORDER BY position, created_at`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := []*domain.VehicleImage{}
+	for rows.Next() {
+		var i domain.VehicleImage
+		err := rows.Scan(&i.ID, &i.VehicleID, &i.Key, &i.ThumbnailKey, &i.ContentType, &i.Size, &i.Width, &i.Height, &i.Position, &i.Cover, &i.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *postgresVehicleImageRepository) Update(ctx context.Context, image *domain.VehicleImage) error {
+	query := `UPDATE vehicle_images SET position = $1, is_cover = $2 WHERE vehicle_id = $3 AND id = $4`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query, image.Position, image.Cover, image.VehicleID, image.ID)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, "image", image.ID)
+}
+
+func (r *postgresVehicleImageRepository) Delete(ctx context.Context, vehicleID, id string) error {
+	result, err := r.executor(ctx).ExecContext(ctx, `DELETE FROM vehicle_images WHERE vehicle_id = $1 AND id = $2`, vehicleID, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, "image", id)
+}
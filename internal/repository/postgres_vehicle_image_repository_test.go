@@ -0,0 +1,172 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresVehicleImageRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresVehicleImageRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresVehicleImageRepositoryTestSuite))
+}
+
+var vehicleImageColumns = []string{"id", "vehicle_id", "blob_key", "thumbnail_key", "content_type", "size", "width", "height", "position", "is_cover", "created_at"}
+
+func (suite *PostgresVehicleImageRepositoryTestSuite) Test_Add() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImageRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should insert the image", func(t *testing.T) {
+		image := &domain.VehicleImage{
+			ID:           "image-1",
+			VehicleID:    "vehicle-1",
+			Key:          "vehicles/vehicle-1/image-1.jpg",
+			ThumbnailKey: "vehicles/vehicle-1/image-1_thumb.jpg",
+			ContentType:  "image/jpeg",
+			Size:         2048,
+			Width:        800,
+			Height:       600,
+			Position:     0,
+			Cover:        true,
+			CreatedAt:    now,
+		}
+
+		mock.ExpectExec("INSERT INTO vehicle_images").
+			WithArgs("image-1", "vehicle-1", image.Key, image.ThumbnailKey, "image/jpeg", int64(2048), 800, 600, 0, true, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Add(context.Background(), image)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleImageRepositoryTestSuite) Test_ListByVehicle() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImageRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should return the images in display order", func(t *testing.T) {
+		rows := sqlmock.NewRows(vehicleImageColumns).
+			AddRow("image-1", "vehicle-1", "vehicles/vehicle-1/image-1.jpg", "vehicles/vehicle-1/image-1_thumb.jpg", "image/jpeg", 2048, 800, 600, 0, true, now).
+			AddRow("image-2", "vehicle-1", "vehicles/vehicle-1/image-2.png", "vehicles/vehicle-1/image-2_thumb.jpg", "image/png", 4096, 640, 480, 1, false, now)
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_images WHERE vehicle_id = \\$1 ORDER BY position").
+			WithArgs("vehicle-1").
+			WillReturnRows(rows)
+
+		images, err := repo.ListByVehicle(context.Background(), "vehicle-1")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(images) != 2 {
+			t.Fatalf("expected 2 images, got %d", len(images))
+		}
+		if images[0].ID != "image-1" || !images[0].Cover || images[1].Position != 1 || images[1].ContentType != "image/png" {
+			t.Errorf("unexpected images: %+v, %+v", images[0], images[1])
+		}
+	})
+
+	suite.T().Run("should return an empty list for a vehicle without images", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_images").
+			WithArgs("vehicle-2").
+			WillReturnRows(sqlmock.NewRows(vehicleImageColumns))
+
+		images, err := repo.ListByVehicle(context.Background(), "vehicle-2")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if images == nil || len(images) != 0 {
+			t.Errorf("expected an empty list, got %v", images)
+		}
+	})
+}
+
+func (suite *PostgresVehicleImageRepositoryTestSuite) Test_Update() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImageRepository(db)
+	image := &domain.VehicleImage{ID: "image-1", VehicleID: "vehicle-1", Position: 2, Cover: false}
+
+	suite.T().Run("should update the position and cover flag", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicle_images SET position = \\$1, is_cover = \\$2").
+			WithArgs(2, false, "vehicle-1", "image-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Update(context.Background(), image)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when no image matches", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicle_images").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Update(context.Background(), image)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleImageRepositoryTestSuite) Test_Delete() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImageRepository(db)
+
+	suite.T().Run("should delete the image of the vehicle", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM vehicle_images WHERE vehicle_id = \\$1 AND id = \\$2").
+			WithArgs("vehicle-1", "image-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Delete(context.Background(), "vehicle-1", "image-1")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when no image matches", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM vehicle_images").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Delete(context.Background(), "vehicle-1", "image-9")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type postgresVehicleImportJobRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresVehicleImportJobRepository(db *sql.DB) VehicleImportJobRepository {
+	return &postgresVehicleImportJobRepository{
+		db: db,
+	}
+}
+
+func (r *postgresVehicleImportJobRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresVehicleImportJobRepository) Create(ctx context.Context, job *domain.VehicleImportJob) error {
+	results, err := encodeImportResults(job.Results)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO vehicle_import_jobs (id, status, dry_run, total, processed, succeeded, failed, results, error, created_at, updated_at, finished_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12)`
+
+	_, err = r.executor(ctx).ExecContext(ctx, query,
+		job.ID,
+		job.Status,
+		job.DryRun,
+		job.Total,
+		job.Processed,
+		job.Succeeded,
+		job.Failed,
+		results,
+		job.Error,
+		job.CreatedAt,
+		job.UpdatedAt,
+		job.FinishedAt,
+	)
+
+	return err
+}
+
+func (r *postgresVehicleImportJobRepository) GetByID(ctx context.Context, id string) (*domain.VehicleImportJob, error) {
+	query := `SELECT id, status, dry_run, total, processed, succeeded, failed, results, COALESCE(error, ''), created_at, updated_at, finished_at
+	          FROM vehicle_import_jobs WHERE id = $1`
+
+	var job domain.VehicleImportJob
+	var results []byte
+	var finishedAt sql.NullTime
+	err := r.executor(ctx).QueryRowContext(ctx, query, id).Scan(
+		&job.ID,
+		&job.Status,
+		&job.DryRun,
+		&job.Total,
+		&job.Processed,
+		&job.Succeeded,
+		&job.Failed,
+		&results,
+		&job.Error,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("import job %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(results, &job.Results)
+	if err != nil {
+		return nil, fmt.Errorf("decoding results of import job %s: %w", job.ID, err)
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return &job, nil
+}
+
+func (r *postgresVehicleImportJobRepository) Update(ctx context.Context, job *domain.VehicleImportJob) error {
+	results, err := encodeImportResults(job.Results)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE vehicle_import_jobs
+	          SET status = $1, processed = $2, succeeded = $3, failed = $4, results = $5, error = NULLIF($6, ''), updated_at = $7, finished_at = $8
+	          WHERE id = $9`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query,
+		job.Status,
+		job.Processed,
+		job.Succeeded,
+		job.Failed,
+		results,
+		job.Error,
+		job.UpdatedAt,
+		job.FinishedAt,
+		job.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, "import job", job.ID)
+}
+
+func (r *postgresVehicleImportJobRepository) FailStale(ctx context.Context, message string, staleBefore, at time.Time) (int64, error) {
+	query := `UPDATE vehicle_import_jobs
+	          SET status = $1, error = $2, updated_at = $3, finished_at = $3
+	          WHERE status = $4 AND updated_at < $5`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query, domain.ImportFailed, message, at, domain.ImportRunning, staleBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func encodeImportResults(results []domain.ImportRowResult) ([]byte, error) {
+	if results == nil {
+		results = []domain.ImportRowResult{}
+	}
+	return json.Marshal(results)
+}
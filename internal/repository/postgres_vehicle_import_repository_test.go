@@ -0,0 +1,151 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresVehicleImportJobRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresVehicleImportJobRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresVehicleImportJobRepositoryTestSuite))
+}
+
+var importJobColumns = []string{"id", "status", "dry_run", "total", "processed", "succeeded", "failed", "results", "error", "created_at", "updated_at", "finished_at"}
+
+func (suite *PostgresVehicleImportJobRepositoryTestSuite) Test_Create() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImportJobRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should store a new job with no results", func(t *testing.T) {
+		job := &domain.VehicleImportJob{ID: "job-1", Status: domain.ImportRunning, Total: 800, CreatedAt: now, UpdatedAt: now}
+
+		mock.ExpectExec("INSERT INTO vehicle_import_jobs").
+			WithArgs("job-1", domain.ImportRunning, false, 800, 0, 0, 0, []byte(`[]`), "", now, now, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Create(context.Background(), job)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleImportJobRepositoryTestSuite) Test_GetByID() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImportJobRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should decode the results of the job", func(t *testing.T) {
+		results := `[{"line":2,"status":"CREATED","vehicle_id":"vehicle-1"},{"line":3,"status":"FAILED","error":"validation failed: year is required","errors":[{"field":"year","code":"required","message":"year is required"}]}]`
+		rows := sqlmock.NewRows(importJobColumns).
+			AddRow("job-1", "COMPLETED", false, 2, 2, 1, 1, []byte(results), "", now, now, now)
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_import_jobs WHERE id = \\$1").
+			WithArgs("job-1").
+			WillReturnRows(rows)
+
+		job, err := repo.GetByID(context.Background(), "job-1")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if job.Status != domain.ImportCompleted || job.FinishedAt == nil || len(job.Results) != 2 {
+			t.Fatalf("unexpected job: %+v", job)
+		}
+		if job.Results[1].Errors[0] != (domain.FieldError{Field: "year", Code: domain.CodeRequired, Message: "year is required"}) {
+			t.Errorf("unexpected field error: %+v", job.Results[1].Errors[0])
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound for an unknown job", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_import_jobs").
+			WithArgs("job-9").
+			WillReturnRows(sqlmock.NewRows(importJobColumns))
+
+		_, err := repo.GetByID(context.Background(), "job-9")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleImportJobRepositoryTestSuite) Test_Update() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImportJobRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should store the progress of the job", func(t *testing.T) {
+		job := &domain.VehicleImportJob{
+			ID:        "job-1",
+			Status:    domain.ImportRunning,
+			Processed: 1,
+			Succeeded: 1,
+			Results:   []domain.ImportRowResult{{Line: 2, Status: domain.ImportRowCreated, VehicleID: "vehicle-1"}},
+			UpdatedAt: now,
+		}
+
+		mock.ExpectExec("UPDATE vehicle_import_jobs").
+			WithArgs(domain.ImportRunning, 1, 1, 0, []byte(`[{"line":2,"status":"CREATED","vehicle_id":"vehicle-1"}]`), "", now, nil, "job-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Update(context.Background(), job)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleImportJobRepositoryTestSuite) Test_FailStale() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleImportJobRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should fail the running jobs without recent progress", func(t *testing.T) {
+		staleBefore := now.Add(-10 * time.Minute)
+		mock.ExpectExec("UPDATE vehicle_import_jobs SET status = \\$1, error = \\$2, updated_at = \\$3, finished_at = \\$3 WHERE status = \\$4 AND updated_at < \\$5").
+			WithArgs(domain.ImportFailed, "interrupted", now, domain.ImportRunning, staleBefore).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		failed, err := repo.FailStale(context.Background(), "interrupted", staleBefore, now)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if failed != 2 {
+			t.Errorf("expected 2 jobs, got %d", failed)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type postgresVehiclePriceRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresVehiclePriceRepository(db *sql.DB) VehiclePriceRepository {
+	return &postgresVehiclePriceRepository{
+		db: db,
+	}
+}
+
+func (r *postgresVehiclePriceRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresVehiclePriceRepository) Add(ctx context.Context, change *domain.PriceChange) error {
+	query := `INSERT INTO vehicle_price_history (id, vehicle_id, previous_price, previous_currency, price, currency, actor, changed_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query,
+		change.ID,
+		change.VehicleID,
+		change.PreviousPrice,
+		change.PreviousPrice.Currency(),
+		change.Price,
+		change.Price.Currency(),
+		change.Actor,
+		change.ChangedAt,
+	)
+
+	return err
+}
+
+func (r *postgresVehiclePriceRepository) ListByVehicle(ctx context.Context, vehicleID string) ([]*domain.PriceChange, error) {
+	query := `SELECT id, vehicle_id, previous_price, previous_currency, price, currency, actor, changed_at
+	          FROM vehicle_price_history
+	          WHERE vehicle_id = $1
+	          ORDER BY changed_at, id`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*domain.PriceChange{}
+	for rows.Next() {
+		var c domain.PriceChange
+		var previousCurrency, currency string
+		err := rows.Scan(&c.ID, &c.VehicleID, &c.PreviousPrice, &previousCurrency, &c.Price, &currency, &c.Actor, &c.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		c.PreviousPrice = c.PreviousPrice.WithCurrency(previousCurrency)
+		c.Price = c.Price.WithCurrency(currency)
+		changes = append(changes, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// Stats takes the first asking price from the earliest price change made in
+// the current currency of the vehicle, falling back to the current price of
+// vehicles that were never repriced in it, and the sale date from the first
+// history entry that moved the vehicle to SOLD, falling back to its last update
+// for sales older than the history.
+func (r *postgresVehiclePriceRepository) Stats(ctx context.Context, filter PriceStatsFilter, now time.Time) ([]*domain.PriceStats, error) {
+	conditions := []string{"v.deleted_at IS NULL"}
+	args := []any{now}
+
+	if filter.Brand != "" {
+		args = append(args, filter.Brand)
+		conditions = append(conditions, fmt.Sprintf("LOWER(v.brand) = LOWER($%d)", len(args)))
+	}
+	if filter.Model != "" {
+		args = append(args, filter.Model)
+		conditions = append(conditions, fmt.Sprintf("LOWER(v.model) = LOWER($%d)", len(args)))
+	}
+
+	query := `SELECT v.brand, v.model, v.currency,
+	                 COUNT(*),
+	                 COUNT(*) FILTER (WHERE v.status = 'SOLD'),
+	                 COALESCE(SUM(p.changes), 0)::int,
+	                 AVG(EXTRACT(EPOCH FROM (COALESCE(s.sold_at, $1) - v.created_at)) / 86400),
+	                 ROUND(AVG(COALESCE(p.first_price, v.price) - v.price), 2),
+	                 AVG(CASE WHEN COALESCE(p.first_price, v.price) > 0
+	                          THEN (COALESCE(p.first_price, v.price) - v.price) / COALESCE(p.first_price, v.price) * 100
+	                          ELSE 0 END)
+	          FROM vehicles v
+	          LEFT JOIN LATERAL (
+	              SELECT COUNT(*) AS changes,
+	                     (ARRAY_AGG(ph.previous_price ORDER BY ph.changed_at, ph.id)
+	                          FILTER (WHERE ph.previous_currency = v.currency))[1] AS first_price
+	              FROM vehicle_price_history ph
+	              WHERE ph.vehicle_id = v.id
+	          ) p ON true
+	          LEFT JOIN LATERAL (
+	              SELECT COALESCE(MIN(h.changed_at), v.updated_at) AS sold_at
+	              FROM vehicle_history h
+	              WHERE h.vehicle_id = v.id AND h.after->>'status' = 'SOLD'
+	          ) s ON v.status = 'SOLD'
+	          WHERE ` + strings.Join(conditions, " AND ") + `
+	          GROUP BY v.brand, v.model, v.currency
+	          ORDER BY v.brand, v.model, v.currency`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []*domain.PriceStats{}
+	for rows.Next() {
+		var s domain.PriceStats
+		err := rows.Scan(&s.Brand, &s.Model, &s.Currency, &s.Vehicles, &s.Sold, &s.PriceChanges, &s.AvgDaysOnMarket, &s.AvgDiscount, &s.AvgDiscountPercent)
+		if err != nil {
+			return nil, err
+		}
+		s.AvgDiscount = s.AvgDiscount.WithCurrency(s.Currency)
+		stats = append(stats, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,201 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresVehiclePriceRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresVehiclePriceRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresVehiclePriceRepositoryTestSuite))
+}
+
+func (suite *PostgresVehiclePriceRepositoryTestSuite) Test_Add() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehiclePriceRepository(db)
+	transactor := repository.NewPostgresTransactor(db)
+	now := time.Now()
+
+	change := &domain.PriceChange{
+		ID:            "p1",
+		VehicleID:     "v1",
+		PreviousPrice: domain.NewMoney(1600000, "USD"),
+		Price:         domain.NewMoney(7500000, "BRL"),
+		Actor:         "ana",
+		ChangedAt:     now,
+	}
+
+	suite.T().Run("should insert the change inside the caller's transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO vehicle_price_history").
+			WithArgs("p1", "v1", "16000.00", "USD", "75000.00", "BRL", "ana", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			return repo.Add(ctx, change)
+		})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when insert fails", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO vehicle_price_history").
+			WillReturnError(errors.New("insert error"))
+
+		err := repo.Add(context.Background(), change)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}
+
+func (suite *PostgresVehiclePriceRepositoryTestSuite) Test_ListByVehicle() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehiclePriceRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should return the changes oldest first", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_price_history\\s+WHERE vehicle_id = \\$1\\s+ORDER BY changed_at, id").
+			WithArgs("v1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "vehicle_id", "previous_price", "previous_currency", "price", "currency", "actor", "changed_at"}).
+				AddRow("p1", "v1", "16000.00", "USD", "75000.00", "BRL", "ana", now.Add(-time.Hour)).
+				AddRow("p2", "v1", "75000.00", "BRL", "70000.00", "BRL", "bia", now))
+
+		changes, err := repo.ListByVehicle(context.Background(), "v1")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(changes) != 2 {
+			t.Fatalf("expected 2 changes, got %d", len(changes))
+		}
+		if changes[0].PreviousPrice != domain.NewMoney(1600000, "USD") || changes[0].Price != domain.NewMoney(7500000, "BRL") {
+			t.Errorf("expected the currency change to keep both currencies, got %+v", changes[0])
+		}
+		if changes[1].PreviousPrice != domain.NewMoney(7500000, "BRL") || changes[1].Price != domain.NewMoney(7000000, "BRL") || changes[1].Actor != "bia" {
+			t.Errorf("unexpected change %+v", changes[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when query fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM vehicle_price_history").
+			WillReturnError(errors.New("query error"))
+
+		changes, err := repo.ListByVehicle(context.Background(), "v1")
+		if err == nil || changes != nil {
+			t.Errorf("expected error and nil changes, got err=%v, changes=%+v", err, changes)
+		}
+	})
+}
+
+func (suite *PostgresVehiclePriceRepositoryTestSuite) Test_Stats() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehiclePriceRepository(db)
+	now := time.Now()
+	columns := []string{"brand", "model", "currency", "count", "sold", "changes", "days", "discount", "discount_percent"}
+
+	suite.T().Run("should group the vehicles that are not deleted by brand, model and currency", func(t *testing.T) {
+		mock.ExpectQuery("SELECT v.brand, v.model, v.currency,(.+)FROM vehicles v(.+)WHERE v.deleted_at IS NULL\\s+GROUP BY v.brand, v.model, v.currency").
+			WithArgs(now).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("Ford", "Focus", "BRL", 3, 1, 4, 12.5, "2500.00", 3.125).
+				AddRow("Honda", "Civic", "BRL", 1, 0, 0, 2.0, "0.00", 0.0))
+
+		stats, err := repo.Stats(context.Background(), repository.PriceStatsFilter{}, now)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(stats) != 2 {
+			t.Fatalf("expected 2 groups, got %d", len(stats))
+		}
+		expected := domain.PriceStats{
+			Brand: "Ford", Model: "Focus", Currency: "BRL", Vehicles: 3, Sold: 1, PriceChanges: 4,
+			AvgDaysOnMarket: 12.5, AvgDiscount: domain.NewMoney(250000, "BRL"), AvgDiscountPercent: 3.125,
+		}
+		if *stats[0] != expected {
+			t.Errorf("unexpected stats %+v", stats[0])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should take the first price only from changes in the current currency", func(t *testing.T) {
+		mock.ExpectQuery("ARRAY_AGG\\(ph.previous_price ORDER BY ph.changed_at, ph.id\\)\\s+FILTER \\(WHERE ph.previous_currency = v.currency\\)").
+			WithArgs(now).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.Stats(context.Background(), repository.PriceStatsFilter{}, now)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should filter by brand and model ignoring case", func(t *testing.T) {
+		mock.ExpectQuery("WHERE v.deleted_at IS NULL AND LOWER\\(v.brand\\) = LOWER\\(\\$2\\) AND LOWER\\(v.model\\) = LOWER\\(\\$3\\)").
+			WithArgs(now, "ford", "focus").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		stats, err := repo.Stats(context.Background(), repository.PriceStatsFilter{Brand: "ford", Model: "focus"}, now)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if stats == nil || len(stats) != 0 {
+			t.Errorf("expected empty stats, got %+v", stats)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when query fails", func(t *testing.T) {
+		mock.ExpectQuery("FROM vehicles v").
+			WillReturnError(errors.New("query error"))
+
+		stats, err := repo.Stats(context.Background(), repository.PriceStatsFilter{}, now)
+		if err == nil || stats != nil {
+			t.Errorf("expected error and nil stats, got err=%v, stats=%+v", err, stats)
+		}
+	})
+}
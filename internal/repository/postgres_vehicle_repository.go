@@ -4,10 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// vehicleColumns are the columns read by scanVehicle. Identifiers missing
+// from a vehicle are stored as NULL so that their unique indexes ignore them,
+// and so are the specification attributes that were not informed.
+const vehicleColumns = `id, brand, model, year, color, price, currency, COALESCE(vin, ''), COALESCE(plate, ''), COALESCE(renavam, ''),
+	mileage, COALESCE(fuel_type, ''), COALESCE(transmission, ''), COALESCE(body_type, ''), COALESCE(doors, 0), COALESCE(engine_displacement, 0), COALESCE(condition, ''),
+	status, version, created_at, updated_at`
+
+// uniqueViolation is the Postgres error code of a unique index violation.
+const uniqueViolation = "23505"
+
 type postgresVehicleRepository struct {
 	db *sql.DB
 }
@@ -18,56 +32,331 @@ func NewPostgresVehicleRepository(db *sql.DB) VehicleRepository {
 	}
 }
 
+func (r *postgresVehicleRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
 func (r *postgresVehicleRepository) Save(ctx context.Context, vehicle *domain.Vehicle) error {
-	query := `INSERT INTO vehicles (id, brand, model, year, color, price, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	query := `INSERT INTO vehicles (id, brand, model, year, color, price, currency, vin, plate, renavam,
+	              mileage, fuel_type, transmission, body_type, doors, engine_displacement, condition, status, version, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''),
+	              $11, NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, 0), NULLIF($16, 0), NULLIF($17, ''), $18, $19, $20, $21)`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.executor(ctx).ExecContext(ctx, query,
 		vehicle.ID,
 		vehicle.Brand,
 		vehicle.Model,
 		vehicle.Year,
 		vehicle.Color,
 		vehicle.Price,
+		vehicle.Price.Currency(),
+		vehicle.VIN,
+		vehicle.Plate,
+		vehicle.Renavam,
+		vehicle.Mileage,
+		vehicle.FuelType,
+		vehicle.Transmission,
+		vehicle.BodyType,
+		vehicle.Doors,
+		vehicle.EngineDisplacement,
+		vehicle.Condition,
+		vehicle.Status,
+		vehicle.Version,
 		vehicle.CreatedAt,
 		vehicle.UpdatedAt,
 	)
 
-	return err
+	return checkUniqueViolation(err)
 }
 
 func (r *postgresVehicleRepository) GetByID(ctx context.Context, id string) (*domain.Vehicle, error) {
-	query := `SELECT id, brand, model, year, color, price, created_at, updated_at FROM vehicles WHERE id = $1`
+	query := `SELECT ` + vehicleColumns + ` FROM vehicles WHERE id = $1 AND deleted_at IS NULL`
 
-	var v domain.Vehicle
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&v.ID, &v.Brand, &v.Model, &v.Year, &v.Color, &v.Price, &v.CreatedAt, &v.UpdatedAt,
-	)
+	return r.getVehicle(ctx, query, id)
+}
+
+func (r *postgresVehicleRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.Vehicle, error) {
+	query := `SELECT ` + vehicleColumns + ` FROM vehicles WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+
+	return r.getVehicle(ctx, query, id)
+}
 
+func (r *postgresVehicleRepository) FindDuplicate(ctx context.Context, vehicle *domain.Vehicle) (*domain.Vehicle, error) {
+	query := `SELECT ` + vehicleColumns + ` FROM vehicles
+	          WHERE deleted_at IS NULL AND id <> $1
+	            AND (vin = NULLIF($2, '') OR plate = NULLIF($3, '') OR renavam = NULLIF($4, ''))
+	          ORDER BY created_at, id
+	          LIMIT 1`
+
+	duplicate, err := scanVehicle(r.executor(ctx).QueryRowContext(ctx, query, vehicle.ID, vehicle.VIN, vehicle.Plate, vehicle.Renavam))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("vehicle not found")
+			return nil, fmt.Errorf("duplicate of vehicle %s: %w", vehicle.ID, domain.ErrNotFound)
 		}
 		return nil, err
 	}
 
+	return duplicate, nil
+}
+
+func (r *postgresVehicleRepository) getVehicle(ctx context.Context, query string, id string) (*domain.Vehicle, error) {
+	v, err := scanVehicle(r.executor(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("vehicle %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// scanVehicle reads the columns selected by the vehicle queries, attaching
+// the currency column to the price.
+func scanVehicle(row rowScanner) (*domain.Vehicle, error) {
+	var v domain.Vehicle
+	var currency string
+	err := row.Scan(&v.ID, &v.Brand, &v.Model, &v.Year, &v.Color, &v.Price, &currency, &v.VIN, &v.Plate, &v.Renavam,
+		&v.Mileage, &v.FuelType, &v.Transmission, &v.BodyType, &v.Doors, &v.EngineDisplacement, &v.Condition, &v.Status, &v.Version, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Price = v.Price.WithCurrency(currency)
 	return &v, nil
 }
 
+func (r *postgresVehicleRepository) List(ctx context.Context, params VehicleListParams) ([]*domain.Vehicle, int, error) {
+	orderBy, err := buildVehicleOrderBy(params.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args := buildVehicleWhere(params.Filter)
+
+	var total int
+	err = r.executor(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM vehicles`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM vehicles%s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		vehicleColumns, where, orderBy, len(args)+1, len(args)+2)
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	vehicles := make([]*domain.Vehicle, 0, params.Limit)
+	for rows.Next() {
+		v, err := scanVehicle(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		vehicles = append(vehicles, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return vehicles, total, nil
+}
+
 func (r *postgresVehicleRepository) Update(ctx context.Context, vehicle *domain.Vehicle) error {
 	query := `UPDATE vehicles 
-	          SET brand = $1, model = $2, year = $3, color = $4, price = $5, updated_at = $6
-	          WHERE id = $7`
+	          SET brand = $1, model = $2, year = $3, color = $4, price = $5, currency = $6,
+	              vin = NULLIF($7, ''), plate = NULLIF($8, ''), renavam = NULLIF($9, ''),
+	              mileage = $10, fuel_type = NULLIF($11, ''), transmission = NULLIF($12, ''), body_type = NULLIF($13, ''),
+	              doors = NULLIF($14, 0), engine_displacement = NULLIF($15, 0), condition = NULLIF($16, ''),
+	              updated_at = $17, version = version + 1
+	          WHERE id = $18 AND version = $19 AND deleted_at IS NULL`
 
-	_, err := r.db.ExecContext(ctx, query,
+	result, err := r.executor(ctx).ExecContext(ctx, query,
 		vehicle.Brand,
 		vehicle.Model,
 		vehicle.Year,
 		vehicle.Color,
 		vehicle.Price,
+		vehicle.Price.Currency(),
+		vehicle.VIN,
+		vehicle.Plate,
+		vehicle.Renavam,
+		vehicle.Mileage,
+		vehicle.FuelType,
+		vehicle.Transmission,
+		vehicle.BodyType,
+		vehicle.Doors,
+		vehicle.EngineDisplacement,
+		vehicle.Condition,
 		vehicle.UpdatedAt,
 		vehicle.ID,
+		vehicle.Version,
 	)
+	if err != nil {
+		return checkUniqueViolation(err)
+	}
+
+	return r.checkVersionedUpdate(ctx, result, vehicle)
+}
+
+func (r *postgresVehicleRepository) UpdateStatus(ctx context.Context, vehicle *domain.Vehicle) error {
+	query := `UPDATE vehicles SET status = $1, updated_at = $2, version = version + 1
+	          WHERE id = $3 AND version = $4 AND deleted_at IS NULL`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query, vehicle.Status, vehicle.UpdatedAt, vehicle.ID, vehicle.Version)
+	if err != nil {
+		return err
+	}
+
+	return r.checkVersionedUpdate(ctx, result, vehicle)
+}
 
+func (r *postgresVehicleRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	query := `UPDATE vehicles SET deleted_at = $1, updated_at = $1 WHERE id = $2 AND deleted_at IS NULL`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query, deletedAt, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("vehicle %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// checkVersionedUpdate bumps the in-memory version after a successful
+// optimistic update. When no row matched it tells apart a vehicle that was
+// deleted in the meantime from one whose version moved on.
+func (r *postgresVehicleRepository) checkVersionedUpdate(ctx context.Context, result sql.Result, vehicle *domain.Vehicle) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		vehicle.Version++
+		return nil
+	}
+
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM vehicles WHERE id = $1 AND deleted_at IS NULL)`
+	err = r.executor(ctx).QueryRowContext(ctx, query, vehicle.ID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("vehicle %s: %w", vehicle.ID, domain.ErrNotFound)
+	}
+
+	return fmt.Errorf("%w: vehicle %s was modified concurrently (version %d is stale)", domain.ErrConflict, vehicle.ID, vehicle.Version)
+}
+
+// checkUniqueViolation turns the violation of a unique identifier index,
+// which a concurrent registration can cause after the duplicate check, into
+// ErrConflict.
+func checkUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: %s", domain.ErrConflict, pgErr.Detail)
+	}
 	return err
 }
+
+var vehicleSortColumns = map[VehicleSortField]string{
+	SortByPrice:     "price",
+	SortByYear:      "year",
+	SortByMileage:   "mileage",
+	SortByCreatedAt: "created_at",
+}
+
+func buildVehicleOrderBy(sort VehicleSort) (string, error) {
+	field := sort.Field
+	if field == "" {
+		field = SortByCreatedAt
+	}
+	column, ok := vehicleSortColumns[field]
+	if !ok {
+		return "", fmt.Errorf("%w: invalid sort field %q", domain.ErrValidation, field)
+	}
+
+	direction := "DESC"
+	switch sort.Order {
+	case SortAsc:
+		direction = "ASC"
+	case SortDesc, "":
+	default:
+		return "", fmt.Errorf("%w: invalid sort order %q", domain.ErrValidation, sort.Order)
+	}
+
+	return column + " " + direction + ", id", nil
+}
+
+func buildVehicleWhere(filter VehicleFilter) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []any
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Brand != "" {
+		add("LOWER(brand) = LOWER($%d)", filter.Brand)
+	}
+	if filter.Model != "" {
+		add("LOWER(model) = LOWER($%d)", filter.Model)
+	}
+	if filter.Color != "" {
+		add("LOWER(color) = LOWER($%d)", filter.Color)
+	}
+	if filter.YearMin != nil {
+		add("year >= $%d", *filter.YearMin)
+	}
+	if filter.YearMax != nil {
+		add("year <= $%d", *filter.YearMax)
+	}
+	if filter.PriceMin != nil {
+		add("price >= $%d", *filter.PriceMin)
+	}
+	if filter.PriceMax != nil {
+		add("price <= $%d", *filter.PriceMax)
+	}
+	if filter.Currency != "" {
+		add("currency = $%d", filter.Currency)
+	}
+	if filter.MileageMin != nil {
+		add("mileage >= $%d", *filter.MileageMin)
+	}
+	if filter.MileageMax != nil {
+		add("mileage <= $%d", *filter.MileageMax)
+	}
+	if filter.FuelType != "" {
+		add("fuel_type = $%d", filter.FuelType)
+	}
+	if filter.Transmission != "" {
+		add("transmission = $%d", filter.Transmission)
+	}
+	if filter.BodyType != "" {
+		add("body_type = $%d", filter.BodyType)
+	}
+	if filter.Doors != nil {
+		add("doors = $%d", *filter.Doors)
+	}
+	if filter.EngineDisplacementMin != nil {
+		add("engine_displacement >= $%d", *filter.EngineDisplacementMin)
+	}
+	if filter.EngineDisplacementMax != nil {
+		add("engine_displacement <= $%d", *filter.EngineDisplacementMax)
+	}
+	if filter.Condition != "" {
+		add("condition = $%d", filter.Condition)
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
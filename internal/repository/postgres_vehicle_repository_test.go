@@ -3,6 +3,7 @@ package repository_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -37,7 +39,9 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Save() {
 		Model:     "Corolla",
 		Year:      2022,
 		Color:     "Blue",
-		Price:     25000.0,
+		Price:     domain.NewMoney(2500000, "BRL"),
+		Status:    domain.StatusAvailable,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -51,6 +55,19 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Save() {
 				vehicle.Year,
 				vehicle.Color,
 				vehicle.Price,
+				vehicle.Price.Currency(),
+				vehicle.VIN,
+				vehicle.Plate,
+				vehicle.Renavam,
+				vehicle.Mileage,
+				vehicle.FuelType,
+				vehicle.Transmission,
+				vehicle.BodyType,
+				vehicle.Doors,
+				vehicle.EngineDisplacement,
+				vehicle.Condition,
+				vehicle.Status,
+				vehicle.Version,
 				vehicle.CreatedAt,
 				vehicle.UpdatedAt,
 			).
@@ -75,6 +92,19 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Save() {
 				vehicle.Year,
 				vehicle.Color,
 				vehicle.Price,
+				vehicle.Price.Currency(),
+				vehicle.VIN,
+				vehicle.Plate,
+				vehicle.Renavam,
+				vehicle.Mileage,
+				vehicle.FuelType,
+				vehicle.Transmission,
+				vehicle.BodyType,
+				vehicle.Doors,
+				vehicle.EngineDisplacement,
+				vehicle.Condition,
+				vehicle.Status,
+				vehicle.Version,
 				vehicle.CreatedAt,
 				vehicle.UpdatedAt,
 			).
@@ -85,6 +115,60 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Save() {
 			t.Errorf("expected error, got nil")
 		}
 	})
+
+	suite.T().Run("should return conflict on unique violation", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO vehicles").
+			WillReturnError(&pgconn.PgError{Code: "23505", Detail: "Key (vin)=(9BWZZZ377VT004251) already exists."})
+
+		err = repo.Save(context.Background(), vehicle)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleRepositoryTestSuite) Test_FindDuplicate() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+	columns := []string{"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at"}
+	vehicle := &domain.Vehicle{ID: "new", VIN: "9BWZZZ377VT004251", Plate: "BRA2E19"}
+	now := time.Now()
+
+	suite.T().Run("should return the vehicle holding an identifier", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow("123", "VW", "Gol", 1997, "Red", "15000.00", "BRL", "9BWZZZ377VT004251", "", "", 0, "", "", "", 0, 0, "", "AVAILABLE", 1, now, now)
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE deleted_at IS NULL AND id <> \\$1").
+			WithArgs("new", "9BWZZZ377VT004251", "BRA2E19", "").
+			WillReturnRows(rows)
+
+		duplicate, err := repo.FindDuplicate(context.Background(), vehicle)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if duplicate.ID != "123" || duplicate.VIN != "9BWZZZ377VT004251" || duplicate.Plate != "" {
+			t.Errorf("unexpected duplicate %+v", duplicate)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return not found when no vehicle matches", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM vehicles").
+			WithArgs("new", "9BWZZZ377VT004251", "BRA2E19", "").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.FindDuplicate(context.Background(), vehicle)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
 }
 
 func (suite *PostgresVehicleRepositoryTestSuite) Test_GetByID() {
@@ -102,26 +186,41 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_GetByID() {
 		Model:     "Corolla",
 		Year:      2022,
 		Color:     "Blue",
-		Price:     25000.0,
+		Price:     domain.NewMoney(2500000, "BRL"),
+		Status:    domain.StatusAvailable,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	suite.T().Run("should get vehicle by id successfully", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
-			"id", "brand", "model", "year", "color", "price", "created_at", "updated_at",
+			"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at",
 		}).AddRow(
 			vehicle.ID,
 			vehicle.Brand,
 			vehicle.Model,
 			vehicle.Year,
 			vehicle.Color,
-			vehicle.Price,
+			vehicle.Price.String(),
+			vehicle.Price.Currency(),
+			vehicle.VIN,
+			vehicle.Plate,
+			vehicle.Renavam,
+			vehicle.Mileage,
+			vehicle.FuelType,
+			vehicle.Transmission,
+			vehicle.BodyType,
+			vehicle.Doors,
+			vehicle.EngineDisplacement,
+			vehicle.Condition,
+			vehicle.Status,
+			vehicle.Version,
 			vehicle.CreatedAt,
 			vehicle.UpdatedAt,
 		)
 
-		mock.ExpectQuery("SELECT id, brand, model, year, color, price, created_at, updated_at FROM vehicles WHERE id = \\$1").
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE id = \\$1 AND deleted_at IS NULL").
 			WithArgs(vehicle.ID).
 			WillReturnRows(rows)
 
@@ -139,18 +238,18 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_GetByID() {
 	})
 
 	suite.T().Run("should return error when vehicle not found", func(t *testing.T) {
-		mock.ExpectQuery("SELECT id, brand, model, year, color, price, created_at, updated_at FROM vehicles WHERE id = \\$1").
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE id = \\$1 AND deleted_at IS NULL").
 			WithArgs("notfound").
 			WillReturnError(sql.ErrNoRows)
 
 		got, err := repo.GetByID(context.Background(), "notfound")
-		if err == nil || got != nil {
-			t.Errorf("expected error and nil vehicle, got err=%v, got=%+v", err, got)
+		if !errors.Is(err, domain.ErrNotFound) || got != nil {
+			t.Errorf("expected not found error and nil vehicle, got err=%v, got=%+v", err, got)
 		}
 	})
 
 	suite.T().Run("should return error on query failure", func(t *testing.T) {
-		mock.ExpectQuery("SELECT id, brand, model, year, color, price, created_at, updated_at FROM vehicles WHERE id = \\$1").
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE id = \\$1 AND deleted_at IS NULL").
 			WithArgs("fail").
 			WillReturnError(errors.New("query error"))
 
@@ -159,6 +258,28 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_GetByID() {
 			t.Errorf("expected error and nil vehicle, got err=%v, got=%+v", err, got)
 		}
 	})
+
+	suite.T().Run("should lock the row when reading for update", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{
+			"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at",
+		}).AddRow(
+			vehicle.ID, vehicle.Brand, vehicle.Model, vehicle.Year, vehicle.Color,
+			vehicle.Price.String(), vehicle.Price.Currency(), vehicle.VIN, vehicle.Plate, vehicle.Renavam, vehicle.Mileage, vehicle.FuelType, vehicle.Transmission, vehicle.BodyType, vehicle.Doors, vehicle.EngineDisplacement, vehicle.Condition, vehicle.Status, vehicle.Version, vehicle.CreatedAt, vehicle.UpdatedAt,
+		)
+
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs(vehicle.ID).
+			WillReturnRows(rows)
+
+		got, err := repo.GetByIDForUpdate(context.Background(), vehicle.ID)
+		if err != nil || got == nil || got.ID != vehicle.ID {
+			t.Errorf("expected vehicle with ID %s, got err=%v, got=%+v", vehicle.ID, err, got)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
 }
 
 func (suite *PostgresVehicleRepositoryTestSuite) Test_Update() {
@@ -170,27 +291,43 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Update() {
 
 	repo := repository.NewPostgresVehicleRepository(db)
 
-	vehicle := &domain.Vehicle{
-		ID:        "123",
-		Brand:     "Toyota",
-		Model:     "Corolla",
-		Year:      2022,
-		Color:     "Blue",
-		Price:     25000.0,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	newVehicle := func() *domain.Vehicle {
+		return &domain.Vehicle{
+			ID:        "123",
+			Brand:     "Toyota",
+			Model:     "Corolla",
+			Year:      2022,
+			Color:     "Blue",
+			Price:     domain.NewMoney(2500000, "BRL"),
+			Version:   4,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
 	}
 
 	suite.T().Run("should update vehicle successfully", func(t *testing.T) {
-		mock.ExpectExec("UPDATE vehicles").
+		vehicle := newVehicle()
+		mock.ExpectExec("UPDATE vehicles (.+) version = version \\+ 1 WHERE id = \\$18 AND version = \\$19 AND deleted_at IS NULL").
 			WithArgs(
 				vehicle.Brand,
 				vehicle.Model,
 				vehicle.Year,
 				vehicle.Color,
 				vehicle.Price,
+				vehicle.Price.Currency(),
+				vehicle.VIN,
+				vehicle.Plate,
+				vehicle.Renavam,
+				vehicle.Mileage,
+				vehicle.FuelType,
+				vehicle.Transmission,
+				vehicle.BodyType,
+				vehicle.Doors,
+				vehicle.EngineDisplacement,
+				vehicle.Condition,
 				vehicle.UpdatedAt,
 				vehicle.ID,
+				4,
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -198,6 +335,72 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Update() {
 		if err != nil {
 			t.Errorf("expected no error, got %v", err)
 		}
+		if vehicle.Version != 5 {
+			t.Errorf("expected version to be bumped to 5, got %d", vehicle.Version)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return conflict when version is stale", func(t *testing.T) {
+		vehicle := newVehicle()
+		mock.ExpectExec("UPDATE vehicles").
+			WithArgs(
+				vehicle.Brand,
+				vehicle.Model,
+				vehicle.Year,
+				vehicle.Color,
+				vehicle.Price,
+				vehicle.Price.Currency(),
+				vehicle.VIN,
+				vehicle.Plate,
+				vehicle.Renavam,
+				vehicle.Mileage,
+				vehicle.FuelType,
+				vehicle.Transmission,
+				vehicle.BodyType,
+				vehicle.Doors,
+				vehicle.EngineDisplacement,
+				vehicle.Condition,
+				vehicle.UpdatedAt,
+				vehicle.ID,
+				4,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM vehicles WHERE id = \\$1 AND deleted_at IS NULL\\)").
+			WithArgs(vehicle.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := repo.Update(context.Background(), vehicle)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+		if vehicle.Version != 4 {
+			t.Errorf("expected version to stay at 4, got %d", vehicle.Version)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return not found when vehicle no longer exists", func(t *testing.T) {
+		vehicle := newVehicle()
+		mock.ExpectExec("UPDATE vehicles").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(vehicle.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := repo.Update(context.Background(), vehicle)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+		if vehicle.Version != 4 {
+			t.Errorf("expected version to stay at 4, got %d", vehicle.Version)
+		}
 
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("there were unfulfilled expectations: %v", err)
@@ -205,6 +408,7 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Update() {
 	})
 
 	suite.T().Run("should return error when update fails", func(t *testing.T) {
+		vehicle := newVehicle()
 		mock.ExpectExec("UPDATE vehicles").
 			WithArgs(
 				vehicle.Brand,
@@ -212,8 +416,20 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Update() {
 				vehicle.Year,
 				vehicle.Color,
 				vehicle.Price,
+				vehicle.Price.Currency(),
+				vehicle.VIN,
+				vehicle.Plate,
+				vehicle.Renavam,
+				vehicle.Mileage,
+				vehicle.FuelType,
+				vehicle.Transmission,
+				vehicle.BodyType,
+				vehicle.Doors,
+				vehicle.EngineDisplacement,
+				vehicle.Condition,
 				vehicle.UpdatedAt,
 				vehicle.ID,
+				4,
 			).
 			WillReturnError(errors.New("update error"))
 
@@ -223,3 +439,361 @@ func (suite *PostgresVehicleRepositoryTestSuite) Test_Update() {
 		}
 	})
 }
+
+func (suite *PostgresVehicleRepositoryTestSuite) Test_List() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+
+	params := repository.VehicleListParams{Limit: 10, Offset: 20}
+	now := time.Now()
+
+	suite.T().Run("should list vehicles with total count", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles WHERE deleted_at IS NULL").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+		rows := sqlmock.NewRows([]string{
+			"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at",
+		}).
+			AddRow("1", "Toyota", "Corolla", 2022, "Blue", "25000.00", "BRL", "", "", "", 0, "", "", "", 0, 0, "", "AVAILABLE", 1, now, now).
+			AddRow("2", "Honda", "Civic", 2021, "Red", "22000.50", "USD", "9BWZZZ377VT004251", "BRA2E19", "00639884962", 42000, "FLEX", "MANUAL", "HATCHBACK", 4, 999, "USED", "SOLD", 3, now, now)
+
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE deleted_at IS NULL ORDER BY created_at DESC, id LIMIT \\$1 OFFSET \\$2").
+			WithArgs(params.Limit, params.Offset).
+			WillReturnRows(rows)
+
+		got, total, err := repo.List(context.Background(), params)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if total != 42 {
+			t.Errorf("expected total 42, got %d", total)
+		}
+		if len(got) != 2 || got[0].ID != "1" || got[1].ID != "2" {
+			t.Errorf("unexpected vehicles: %+v", got)
+		}
+		if got[1].Price != domain.NewMoney(2200050, "USD") {
+			t.Errorf("expected the exact price with its currency, got %v %s", got[1].Price, got[1].Price.Currency())
+		}
+		if got[1].FuelType != domain.FuelFlex || got[1].Doors != 4 || got[1].Condition != domain.ConditionUsed || got[0].FuelType != "" {
+			t.Errorf("unexpected specs: %+v, %+v", got[0], got[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when count fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles").
+			WillReturnError(errors.New("count error"))
+
+		got, _, err := repo.List(context.Background(), params)
+		if err == nil || got != nil {
+			t.Errorf("expected error and nil vehicles, got err=%v, got=%+v", err, got)
+		}
+	})
+
+	suite.T().Run("should return error when query fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT id, brand, model, year, color, price, currency, status, version, created_at, updated_at FROM vehicles").
+			WithArgs(params.Limit, params.Offset).
+			WillReturnError(errors.New("query error"))
+
+		got, _, err := repo.List(context.Background(), params)
+		if err == nil || got != nil {
+			t.Errorf("expected error and nil vehicles, got err=%v, got=%+v", err, got)
+		}
+	})
+
+	suite.T().Run("should return error when scan fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT id, brand, model, year, color, price, currency, status, version, created_at, updated_at FROM vehicles").
+			WithArgs(params.Limit, params.Offset).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+		got, _, err := repo.List(context.Background(), params)
+		if err == nil || got != nil {
+			t.Errorf("expected error and nil vehicles, got err=%v, got=%+v", err, got)
+		}
+	})
+}
+
+func (suite *PostgresVehicleRepositoryTestSuite) Test_List_FilterAndSort() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+
+	yearMin, yearMax := 2018, 2022
+	priceMin, priceMax := domain.NewMoney(5000000, ""), domain.NewMoney(15000000, "")
+
+	suite.T().Run("should build parameterized filters and allow-listed sort", func(t *testing.T) {
+		params := repository.VehicleListParams{
+			Filter: repository.VehicleFilter{
+				Brand:    "Toyota",
+				Model:    "Corolla",
+				Color:    "White",
+				YearMin:  &yearMin,
+				YearMax:  &yearMax,
+				PriceMin: &priceMin,
+				PriceMax: &priceMax,
+			},
+			Sort:   repository.VehicleSort{Field: repository.SortByPrice, Order: repository.SortAsc},
+			Limit:  5,
+			Offset: 0,
+		}
+
+		where := "WHERE deleted_at IS NULL AND LOWER\\(brand\\) = LOWER\\(\\$1\\) AND LOWER\\(model\\) = LOWER\\(\\$2\\) AND LOWER\\(color\\) = LOWER\\(\\$3\\) " +
+			"AND year >= \\$4 AND year <= \\$5 AND price >= \\$6 AND price <= \\$7"
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles "+where).
+			WithArgs("Toyota", "Corolla", "White", yearMin, yearMax, priceMin, priceMax).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("FROM vehicles "+where+" ORDER BY price ASC, id LIMIT \\$8 OFFSET \\$9").
+			WithArgs("Toyota", "Corolla", "White", yearMin, yearMax, priceMin, priceMax, 5, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at",
+			}))
+
+		got, total, err := repo.List(context.Background(), params)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if total != 0 || len(got) != 0 {
+			t.Errorf("expected empty result, got total=%d, vehicles=%+v", total, got)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should compare prices only within the filtered currency", func(t *testing.T) {
+		usdMin := domain.NewMoney(1000000, "USD")
+		params := repository.VehicleListParams{
+			Filter: repository.VehicleFilter{PriceMin: &usdMin, Currency: "USD"},
+			Sort:   repository.VehicleSort{Field: repository.SortByPrice, Order: repository.SortAsc},
+			Limit:  5,
+		}
+		now := time.Now()
+
+		where := "WHERE deleted_at IS NULL AND price >= \\$1 AND currency = \\$2"
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles "+where).
+			WithArgs(usdMin, "USD").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("FROM vehicles "+where+" ORDER BY price ASC, id LIMIT \\$3 OFFSET \\$4").
+			WithArgs(usdMin, "USD", 5, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at",
+			}).AddRow("2", "Honda", "Civic", 2021, "Black", "15000.00", "USD", "", "", "", 0, "", "", "", 0, 0, "", "AVAILABLE", 1, now, now))
+
+		got, total, err := repo.List(context.Background(), params)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if total != 1 || len(got) != 1 || got[0].Price != domain.NewMoney(1500000, "USD") {
+			t.Errorf("expected only the USD vehicle, got total=%d, vehicles=%+v", total, got)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should filter by specification attributes", func(t *testing.T) {
+		mileageMax, doors, engineMin := 60000, 4, 1000
+		params := repository.VehicleListParams{
+			Filter: repository.VehicleFilter{
+				MileageMax:            &mileageMax,
+				FuelType:              domain.FuelFlex,
+				Transmission:          domain.TransmissionAutomatic,
+				BodyType:              domain.BodySUV,
+				Doors:                 &doors,
+				EngineDisplacementMin: &engineMin,
+				Condition:             domain.ConditionUsed,
+			},
+			Sort:  repository.VehicleSort{Field: repository.SortByMileage},
+			Limit: 5,
+		}
+
+		where := "WHERE deleted_at IS NULL AND mileage <= \\$1 AND fuel_type = \\$2 AND transmission = \\$3 AND body_type = \\$4 " +
+			"AND doors = \\$5 AND engine_displacement >= \\$6 AND condition = \\$7"
+		args := []driver.Value{mileageMax, "FLEX", "AUTOMATIC", "SUV", doors, engineMin, "USED"}
+
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM vehicles " + where).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("FROM vehicles " + where + " ORDER BY mileage DESC, id LIMIT \\$8 OFFSET \\$9").
+			WithArgs(append(args, 5, 0)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.List(context.Background(), params)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should reject sort field outside the allow-list", func(t *testing.T) {
+		params := repository.VehicleListParams{
+			Sort:  repository.VehicleSort{Field: "brand; DROP TABLE vehicles"},
+			Limit: 5,
+		}
+
+		_, _, err := repo.List(context.Background(), params)
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("expected invalid sort error, got %v", err)
+		}
+	})
+
+	suite.T().Run("should reject unknown sort order", func(t *testing.T) {
+		params := repository.VehicleListParams{
+			Sort:  repository.VehicleSort{Field: repository.SortByYear, Order: "sideways"},
+			Limit: 5,
+		}
+
+		_, _, err := repo.List(context.Background(), params)
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("expected invalid sort error, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleRepositoryTestSuite) Test_UpdateStatus() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+
+	vehicle := &domain.Vehicle{
+		ID:        "123",
+		Status:    domain.StatusReserved,
+		Version:   2,
+		UpdatedAt: time.Now(),
+	}
+
+	suite.T().Run("should update vehicle status successfully", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET status = \\$1, updated_at = \\$2, version = version \\+ 1\\s+WHERE id = \\$3 AND version = \\$4 AND deleted_at IS NULL").
+			WithArgs(vehicle.Status, vehicle.UpdatedAt, vehicle.ID, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateStatus(context.Background(), vehicle)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if vehicle.Version != 3 {
+			t.Errorf("expected version to be bumped to 3, got %d", vehicle.Version)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when update fails", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET status").
+			WithArgs(vehicle.Status, vehicle.UpdatedAt, vehicle.ID, vehicle.Version).
+			WillReturnError(errors.New("update error"))
+
+		err := repo.UpdateStatus(context.Background(), vehicle)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	suite.T().Run("should return conflict when version is stale", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET status").
+			WithArgs(vehicle.Status, vehicle.UpdatedAt, vehicle.ID, vehicle.Version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(vehicle.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := repo.UpdateStatus(context.Background(), vehicle)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+	})
+
+	suite.T().Run("should return not found when vehicle no longer exists", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET status").
+			WithArgs(vehicle.Status, vehicle.UpdatedAt, vehicle.ID, vehicle.Version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(vehicle.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := repo.UpdateStatus(context.Background(), vehicle)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func (suite *PostgresVehicleRepositoryTestSuite) Test_Delete() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+	deletedAt := time.Now()
+
+	suite.T().Run("should soft delete vehicle successfully", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET deleted_at = \\$1, updated_at = \\$1 WHERE id = \\$2 AND deleted_at IS NULL").
+			WithArgs(deletedAt, "123").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Delete(context.Background(), "123", deletedAt)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when delete fails", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET deleted_at").
+			WithArgs(deletedAt, "123").
+			WillReturnError(errors.New("delete error"))
+
+		err := repo.Delete(context.Background(), "123", deletedAt)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+
+	suite.T().Run("should return not found when nothing was deleted", func(t *testing.T) {
+		mock.ExpectExec("UPDATE vehicles SET deleted_at").
+			WithArgs(deletedAt, "123").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Delete(context.Background(), "123", deletedAt)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, url, payload, status, attempts, COALESCE(last_error, ''),
+	COALESCE(response_status, 0), next_attempt_at, created_at, delivered_at, COALESCE(redelivered_from, '')`
+
+type postgresWebhookRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWebhookRepository(db *sql.DB) WebhookRepository {
+	return &postgresWebhookRepository{
+		db: db,
+	}
+}
+
+func (r *postgresWebhookRepository) executor(ctx context.Context) executor {
+	return executorFromContext(ctx, r.db)
+}
+
+func (r *postgresWebhookRepository) CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO webhook_subscriptions (id, url, event_types, secret, created_at)
+	          VALUES ($1, $2, $3, $4, $5)`
+
+	_, err = r.executor(ctx).ExecContext(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		eventTypes,
+		subscription.Secret,
+		subscription.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *postgresWebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	query := `SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions WHERE id = $1`
+
+	subscription, err := scanSubscription(r.executor(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("webhook %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *postgresWebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	query := `SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions ORDER BY created_at`
+
+	return r.listSubscriptions(ctx, query)
+}
+
+func (r *postgresWebhookRepository) ListSubscriptionsForEvent(ctx context.Context, eventType domain.EventType) ([]*domain.WebhookSubscription, error) {
+	query := `SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions WHERE event_types ? $1 ORDER BY created_at`
+
+	return r.listSubscriptions(ctx, query, eventType)
+}
+
+func (r *postgresWebhookRepository) listSubscriptions(ctx context.Context, query string, args ...any) ([]*domain.WebhookSubscription, error) {
+	rows, err := r.executor(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*domain.WebhookSubscription, 0)
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *postgresWebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("webhook %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *postgresWebhookRepository) AddDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, url, payload, status, attempts, next_attempt_at, created_at, redelivered_from)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))
+	          ON CONFLICT (subscription_id, event_id) WHERE redelivered_from IS NULL DO NOTHING`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.URL,
+		delivery.Payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+		delivery.RedeliveredFrom,
+	)
+
+	return err
+}
+
+func (r *postgresWebhookRepository) GetDelivery(ctx context.Context, subscriptionID, id string) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND subscription_id = $2`
+
+	delivery, err := scanDelivery(r.executor(ctx).QueryRowContext(ctx, query, id, subscriptionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("webhook delivery %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *postgresWebhookRepository) ListDeliveries(ctx context.Context, params WebhookDeliveryListParams) ([]*domain.WebhookDelivery, int, error) {
+	where := ` WHERE subscription_id = $1`
+	args := []any{params.SubscriptionID}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	var total int
+	err := r.executor(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_deliveries`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM webhook_deliveries%s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		webhookDeliveryColumns, where, len(args)+1, len(args)+2)
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.WebhookDelivery, 0, params.Limit)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *postgresWebhookRepository) ClaimPendingDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*PendingWebhookDelivery, error) {
+	query := `WITH claimed AS (
+	              UPDATE webhook_deliveries SET claimed_until = $2
+	              WHERE id IN (
+	                  SELECT id FROM webhook_deliveries
+	                  WHERE status = 'PENDING' AND next_attempt_at <= $1
+	                    AND (claimed_until IS NULL OR claimed_until <= $1)
+	                  ORDER BY created_at
+	                  LIMIT $3
+	                  FOR UPDATE SKIP LOCKED
+	              )
+	              RETURNING id, subscription_id, event_id, event_type, url, payload, status, attempts, last_error,
+	                        response_status, next_attempt_at, created_at, delivered_at, redelivered_from
+	          )
+	          SELECT d.id, d.subscription_id, d.event_id, d.event_type, d.url, d.payload, d.status, d.attempts, COALESCE(d.last_error, ''),
+	                 COALESCE(d.response_status, 0), d.next_attempt_at, d.created_at, d.delivered_at, COALESCE(d.redelivered_from, ''), s.secret
+	          FROM claimed d
+	          JOIN webhook_subscriptions s ON s.id = d.subscription_id
+	          ORDER BY d.created_at`
+
+	rows, err := r.executor(ctx).QueryContext(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pending := make([]*PendingWebhookDelivery, 0, limit)
+	for rows.Next() {
+		var p PendingWebhookDelivery
+		p.Delivery, err = scanDelivery(rows, &p.Secret)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (r *postgresWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `UPDATE webhook_deliveries
+	          SET status = $1, attempts = $2, last_error = NULLIF($3, ''), response_status = NULLIF($4, 0), next_attempt_at = $5, delivered_at = $6,
+	              claimed_until = NULL
+	          WHERE id = $7`
+
+	_, err := r.executor(ctx).ExecContext(ctx, query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.ResponseStatus,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.ID,
+	)
+
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(row rowScanner) (*domain.WebhookSubscription, error) {
+	var s domain.WebhookSubscription
+	var eventTypes []byte
+	err := row.Scan(&s.ID, &s.URL, &eventTypes, &s.Secret, &s.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(eventTypes, &s.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("decoding event types of webhook %s: %w", s.ID, err)
+	}
+
+	return &s, nil
+}
+
+// scanDelivery reads the webhookDeliveryColumns followed by any extra
+// destinations selected after them.
+func scanDelivery(row rowScanner, extra ...any) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var deliveredAt sql.NullTime
+	dest := []any{
+		&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.URL, &d.Payload, &d.Status, &d.Attempts, &d.LastError,
+		&d.ResponseStatus, &d.NextAttemptAt, &d.CreatedAt, &deliveredAt, &d.RedeliveredFrom,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,382 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresWebhookRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresWebhookRepository(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresWebhookRepositoryTestSuite))
+}
+
+var deliveryColumns = []string{
+	"id", "subscription_id", "event_id", "event_type", "url", "payload", "status", "attempts", "last_error",
+	"response_status", "next_attempt_at", "created_at", "delivered_at", "redelivered_from",
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_CreateSubscription() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	subscription := &domain.WebhookSubscription{
+		ID:         "wh-1",
+		URL:        "https://example.com/hook",
+		EventTypes: []domain.EventType{domain.EventVehicleCreated, domain.EventVehicleSold},
+		Secret:     "0123456789abcdef",
+		CreatedAt:  now,
+	}
+
+	suite.T().Run("should store the event types as a JSON array", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO webhook_subscriptions").
+			WithArgs("wh-1", subscription.URL, []byte(`["vehicle.created","vehicle.sold"]`), subscription.Secret, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.CreateSubscription(context.Background(), subscription)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when insert fails", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO webhook_subscriptions").
+			WillReturnError(errors.New("insert error"))
+
+		err := repo.CreateSubscription(context.Background(), subscription)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_GetSubscription() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should decode the stored subscription", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "url", "event_types", "secret", "created_at"}).
+			AddRow("wh-1", "https://example.com/hook", []byte(`["vehicle.sold"]`), "0123456789abcdef", now)
+		mock.ExpectQuery("SELECT (.+) FROM webhook_subscriptions WHERE id = \\$1").
+			WithArgs("wh-1").
+			WillReturnRows(rows)
+
+		subscription, err := repo.GetSubscription(context.Background(), "wh-1")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(subscription.EventTypes) != 1 || subscription.EventTypes[0] != domain.EventVehicleSold {
+			t.Errorf("unexpected event types %v", subscription.EventTypes)
+		}
+		if subscription.Secret != "0123456789abcdef" {
+			t.Errorf("expected secret to be loaded, got %q", subscription.Secret)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when the subscription does not exist", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM webhook_subscriptions WHERE id = \\$1").
+			WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "event_types", "secret", "created_at"}))
+
+		_, err := repo.GetSubscription(context.Background(), "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_ListSubscriptionsForEvent() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should filter subscriptions by event type", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "url", "event_types", "secret", "created_at"}).
+			AddRow("wh-1", "https://a.example.com", []byte(`["vehicle.sold"]`), "0123456789abcdef", now).
+			AddRow("wh-2", "https://b.example.com", []byte(`["vehicle.created","vehicle.sold"]`), "fedcba9876543210", now)
+		mock.ExpectQuery("SELECT (.+) FROM webhook_subscriptions WHERE event_types \\? \\$1").
+			WithArgs(domain.EventVehicleSold).
+			WillReturnRows(rows)
+
+		subscriptions, err := repo.ListSubscriptionsForEvent(context.Background(), domain.EventVehicleSold)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(subscriptions) != 2 {
+			t.Errorf("expected 2 subscriptions, got %d", len(subscriptions))
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when the stored event types are malformed", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "url", "event_types", "secret", "created_at"}).
+			AddRow("wh-1", "https://a.example.com", []byte(`not json`), "0123456789abcdef", now)
+		mock.ExpectQuery("SELECT (.+) FROM webhook_subscriptions").WillReturnRows(rows)
+
+		_, err := repo.ListSubscriptionsForEvent(context.Background(), domain.EventVehicleSold)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_DeleteSubscription() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+
+	suite.T().Run("should delete the subscription", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM webhook_subscriptions WHERE id = \\$1").
+			WithArgs("wh-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.DeleteSubscription(context.Background(), "wh-1")
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when no row is deleted", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM webhook_subscriptions WHERE id = \\$1").
+			WithArgs("missing").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.DeleteSubscription(context.Background(), "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_AddDelivery() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	delivery := &domain.WebhookDelivery{
+		ID:             "dlv-1",
+		SubscriptionID: "wh-1",
+		EventID:        "evt-1",
+		EventType:      domain.EventVehicleSold,
+		URL:            "https://example.com/hook",
+		Payload:        []byte(`{}`),
+		Status:         domain.DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+
+	suite.T().Run("should ignore a first delivery already recorded for the event", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO webhook_deliveries (.+) ON CONFLICT \\(subscription_id, event_id\\) WHERE redelivered_from IS NULL DO NOTHING").
+			WithArgs("dlv-1", "wh-1", "evt-1", domain.EventVehicleSold, delivery.URL, delivery.Payload, domain.DeliveryPending, 0, now, now, "").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.AddDelivery(context.Background(), delivery)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_ListDeliveries() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should page the log filtered by status", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM webhook_deliveries WHERE subscription_id = \\$1 AND status = \\$2").
+			WithArgs("wh-1", domain.DeliveryFailed).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery("SELECT (.+) FROM webhook_deliveries WHERE subscription_id = \\$1 AND status = \\$2 ORDER BY created_at DESC LIMIT \\$3 OFFSET \\$4").
+			WithArgs("wh-1", domain.DeliveryFailed, 2, 0).
+			WillReturnRows(sqlmock.NewRows(deliveryColumns).
+				AddRow("dlv-2", "wh-1", "evt-2", "vehicle.sold", "https://example.com", []byte(`{}`), "FAILED", 10, "timeout", 0, now, now, nil, "").
+				AddRow("dlv-1", "wh-1", "evt-1", "vehicle.sold", "https://example.com", []byte(`{}`), "FAILED", 10, "bad gateway", 502, now, now, nil, ""))
+
+		deliveries, total, err := repo.ListDeliveries(context.Background(), repository.WebhookDeliveryListParams{
+			SubscriptionID: "wh-1",
+			Status:         domain.DeliveryFailed,
+			Limit:          2,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if total != 3 || len(deliveries) != 2 {
+			t.Errorf("expected 2 of 3 deliveries, got %d of %d", len(deliveries), total)
+		}
+		if deliveries[1].ResponseStatus != 502 || deliveries[1].DeliveredAt != nil {
+			t.Errorf("unexpected delivery %+v", deliveries[1])
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when counting fails", func(t *testing.T) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM webhook_deliveries").
+			WillReturnError(errors.New("count error"))
+
+		deliveries, _, err := repo.ListDeliveries(context.Background(), repository.WebhookDeliveryListParams{SubscriptionID: "wh-1", Limit: 20})
+		if err == nil || deliveries != nil {
+			t.Errorf("expected error and nil deliveries, got err=%v, deliveries=%+v", err, deliveries)
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_GetDelivery() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should load a delivery of the subscription", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM webhook_deliveries WHERE id = \\$1 AND subscription_id = \\$2").
+			WithArgs("dlv-1", "wh-1").
+			WillReturnRows(sqlmock.NewRows(deliveryColumns).
+				AddRow("dlv-1", "wh-1", "evt-1", "vehicle.sold", "https://example.com", []byte(`{}`), "SUCCEEDED", 1, "", 200, now, now, now, "dlv-0"))
+
+		delivery, err := repo.GetDelivery(context.Background(), "wh-1", "dlv-1")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if delivery.DeliveredAt == nil || delivery.RedeliveredFrom != "dlv-0" {
+			t.Errorf("unexpected delivery %+v", delivery)
+		}
+	})
+
+	suite.T().Run("should return ErrNotFound when the delivery does not exist", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM webhook_deliveries").
+			WithArgs("missing", "wh-1").
+			WillReturnRows(sqlmock.NewRows(deliveryColumns))
+
+		_, err := repo.GetDelivery(context.Background(), "wh-1", "missing")
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_ClaimPendingDeliveries() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+	lease := now.Add(time.Minute)
+
+	suite.T().Run("should lease due deliveries together with their secret", func(t *testing.T) {
+		rows := sqlmock.NewRows(append(deliveryColumns, "secret")).
+			AddRow("dlv-1", "wh-1", "evt-1", "vehicle.sold", "https://example.com", []byte(`{}`), "PENDING", 2, "timeout", 0, now, now, nil, "", "0123456789abcdef")
+
+		mock.ExpectQuery("UPDATE webhook_deliveries SET claimed_until = \\$2(.+)WHERE status = 'PENDING' AND next_attempt_at <= \\$1\\s+"+
+			"AND \\(claimed_until IS NULL OR claimed_until <= \\$1\\)(.+)LIMIT \\$3\\s+FOR UPDATE SKIP LOCKED(.+)"+
+			"FROM claimed d\\s+JOIN webhook_subscriptions s ON s.id = d.subscription_id\\s+ORDER BY d.created_at").
+			WithArgs(now, lease, 10).
+			WillReturnRows(rows)
+
+		pending, err := repo.ClaimPendingDeliveries(context.Background(), now, lease, 10)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(pending) != 1 || pending[0].Secret != "0123456789abcdef" || pending[0].Delivery.Attempts != 2 {
+			t.Errorf("unexpected pending deliveries %+v", pending)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func (suite *PostgresWebhookRepositoryTestSuite) Test_UpdateDelivery() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresWebhookRepository(db)
+	now := time.Now()
+
+	suite.T().Run("should store the outcome of the attempt", func(t *testing.T) {
+		delivery := &domain.WebhookDelivery{
+			ID:             "dlv-1",
+			Status:         domain.DeliverySucceeded,
+			Attempts:       3,
+			ResponseStatus: 204,
+			NextAttemptAt:  now,
+			DeliveredAt:    &now,
+		}
+		mock.ExpectExec("UPDATE webhook_deliveries\\s+SET status = \\$1, attempts = \\$2(.+)claimed_until = NULL\\s+WHERE id = \\$7").
+			WithArgs(domain.DeliverySucceeded, 3, "", 204, now, &now, "dlv-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateDelivery(context.Background(), delivery)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+//go:generate mockgen -source=transactor.go -destination=./mocks/transactor_mock.go -package=mocks
+type Transactor interface {
+	// WithinTransaction runs fn inside a single database transaction. Repository
+	// calls made with the context handed to fn join that transaction; it is
+	// committed when fn returns nil and rolled back otherwise. Called with the
+	// context of an outer transaction, fn runs in a savepoint of it instead, so
+	// a failing fn undoes only its own writes and the outer transaction can go
+	// on.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txContextKey struct{}
+
+// executor is the subset of *sql.DB and *sql.Tx used by the repositories.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type postgresTransactor struct {
+	db *sql.DB
+}
+
+func NewPostgresTransactor(db *sql.DB) Transactor {
+	return &postgresTransactor{
+		db: db,
+	}
+}
+
+func (t *postgresTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return withinSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(context.WithValue(ctx, txContextKey{}, tx))
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// withinSavepoint runs fn inside a savepoint of tx. Nested calls reuse the
+// savepoint name, which Postgres resolves to the most recent savepoint.
+func withinSavepoint(ctx context.Context, tx *sql.Tx, fn func(ctx context.Context) error) error {
+	_, err := tx.ExecContext(ctx, "SAVEPOINT nested_transaction")
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	if err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT nested_transaction"); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT nested_transaction")
+	return err
+}
+
+// executorFromContext returns the transaction bound to ctx, falling back to db.
+func executorFromContext(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,201 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type PostgresTransactorTestSuite struct {
+	suite.Suite
+}
+
+func Test_PostgresTransactor(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(PostgresTransactorTestSuite))
+}
+
+var vehicleColumns = []string{
+	"id", "brand", "model", "year", "color", "price", "currency", "vin", "plate", "renavam", "mileage", "fuel_type", "transmission", "body_type", "doors", "engine_displacement", "condition", "status", "version", "created_at", "updated_at",
+}
+
+func (suite *PostgresTransactorTestSuite) Test_WithinTransaction() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		suite.T().Fatalf("failed to open sqlmock database: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewPostgresVehicleRepository(db)
+	transactor := repository.NewPostgresTransactor(db)
+	now := time.Now()
+
+	lockedRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(vehicleColumns).
+			AddRow("123", "Toyota", "Corolla", 2022, "Blue", "25000.00", "BRL", "", "", "", 0, "", "", "", 0, 0, "", domain.StatusAvailable, 4, now, now)
+	}
+
+	readModifyWrite := func(ctx context.Context) error {
+		vehicle, err := repo.GetByIDForUpdate(ctx, "123")
+		if err != nil {
+			return err
+		}
+		vehicle.Price = domain.NewMoney(2400000, "BRL")
+		return repo.Update(ctx, vehicle)
+	}
+
+	suite.T().Run("should commit the read-modify-write on the same transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM vehicles WHERE id = \\$1 AND deleted_at IS NULL FOR UPDATE").
+			WithArgs("123").
+			WillReturnRows(lockedRow())
+		mock.ExpectExec("UPDATE vehicles").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(context.Background(), readModifyWrite)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should roll back when the vehicle was deleted before the lock", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FOR UPDATE").
+			WithArgs("123").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := transactor.WithinTransaction(context.Background(), readModifyWrite)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should roll back when the vehicle disappears before the update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FOR UPDATE").
+			WithArgs("123").
+			WillReturnRows(lockedRow())
+		mock.ExpectExec("UPDATE vehicles").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs("123").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		err := transactor.WithinTransaction(context.Background(), readModifyWrite)
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should roll back with conflict when the version moved on", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FOR UPDATE").
+			WithArgs("123").
+			WillReturnRows(lockedRow())
+		mock.ExpectExec("UPDATE vehicles").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs("123").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		err := transactor.WithinTransaction(context.Background(), readModifyWrite)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("expected conflict error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should join an outer transaction in a savepoint instead of beginning a new one", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT nested_transaction").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE vehicles SET deleted_at").
+			WithArgs(now, "123").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("RELEASE SAVEPOINT nested_transaction").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return repo.Delete(ctx, "123", now)
+			})
+		})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should undo only the failed nested transaction and keep the outer one going", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT nested_transaction").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE vehicles SET deleted_at").
+			WithArgs(now, "123").
+			WillReturnError(errors.New("duplicate key"))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT nested_transaction").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE vehicles SET deleted_at").
+			WithArgs(now, "456").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var nestedErr error
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			nestedErr = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return repo.Delete(ctx, "123", now)
+			})
+			return repo.Delete(ctx, "456", now)
+		})
+		if err != nil || nestedErr == nil {
+			t.Errorf("expected only the nested transaction to fail, got err=%v, nestedErr=%v", err, nestedErr)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %v", err)
+		}
+	})
+
+	suite.T().Run("should return error when begin fails", func(t *testing.T) {
+		mock.ExpectBegin().WillReturnError(errors.New("begin error"))
+
+		called := false
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		if err == nil || called {
+			t.Errorf("expected begin error without running fn, got err=%v, called=%v", err, called)
+		}
+	})
+}
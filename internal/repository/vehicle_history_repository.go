@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+//go:generate mockgen -source=vehicle_history_repository.go -destination=./mocks/vehicle_history_repository_mock.go -package=mocks
+type VehicleHistoryRepository interface {
+	// Add joins the transaction carried by ctx, so the entry is only stored
+	// together with the change it describes.
+	Add(ctx context.Context, entry *domain.VehicleHistoryEntry) error
+	// ListByVehicle returns a page of the history of a vehicle, newest first,
+	// along with the total number of entries.
+	ListByVehicle(ctx context.Context, vehicleID string, limit, offset int) ([]*domain.VehicleHistoryEntry, int, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+//go:generate mockgen -source=vehicle_image_repository.go -destination=./mocks/vehicle_image_repository_mock.go -package=mocks
+type VehicleImageRepository interface {
+	Add(ctx context.Context, image *domain.VehicleImage) error
+	// ListByVehicle returns the images of a vehicle ordered by position.
+	ListByVehicle(ctx context.Context, vehicleID string) ([]*domain.VehicleImage, error)
+	// Update stores the position and cover flag of the image.
+	Update(ctx context.Context, image *domain.VehicleImage) error
+	Delete(ctx context.Context, vehicleID, id string) error
+}
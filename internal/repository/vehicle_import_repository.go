@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+//go:generate mockgen -source=vehicle_import_repository.go -destination=./mocks/vehicle_import_repository_mock.go -package=mocks
+type VehicleImportJobRepository interface {
+	Create(ctx context.Context, job *domain.VehicleImportJob) error
+	GetByID(ctx context.Context, id string) (*domain.VehicleImportJob, error)
+	// Update stores the progress, results and outcome of the job.
+	Update(ctx context.Context, job *domain.VehicleImportJob) error
+	// FailStale marks the running jobs whose progress was last stored before
+	// staleBefore as failed with message, for when the process that ran them
+	// has stopped. It returns how many jobs were marked.
+	FailStale(ctx context.Context, message string, staleBefore, at time.Time) (int64, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+// PriceStatsFilter narrows the price statistics to a brand and model; empty
+// fields match everything.
+type PriceStatsFilter struct {
+	Brand string
+	Model string
+}
+
+//go:generate mockgen -source=vehicle_price_repository.go -destination=./mocks/vehicle_price_repository_mock.go -package=mocks
+type VehiclePriceRepository interface {
+	// Add joins the transaction carried by ctx, so the change is only stored
+	// together with the vehicle update that made it.
+	Add(ctx context.Context, change *domain.PriceChange) error
+	// ListByVehicle returns every price change of a vehicle, oldest first.
+	ListByVehicle(ctx context.Context, vehicleID string) ([]*domain.PriceChange, error)
+	// Stats aggregates the pricing of the vehicles that are not deleted,
+	// grouped by brand, model and currency. Vehicles still on sale count their
+	// days on market until now.
+	Stats(ctx context.Context, filter PriceStatsFilter, now time.Time) ([]*domain.PriceStats, error)
+}
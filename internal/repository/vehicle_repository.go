@@ -2,13 +2,74 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 )
 
+type VehicleSortField string
+
+const (
+	SortByPrice     VehicleSortField = "price"
+	SortByYear      VehicleSortField = "year"
+	SortByMileage   VehicleSortField = "mileage"
+	SortByCreatedAt VehicleSortField = "created_at"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+type VehicleFilter struct {
+	Brand    string
+	Model    string
+	Color    string
+	YearMin  *int
+	YearMax  *int
+	PriceMin *domain.Money
+	PriceMax *domain.Money
+	// Currency restricts the vehicles to the ones priced in it, so that price
+	// bounds and price sorting compare amounts of a single currency.
+	Currency string
+
+	MileageMin            *int
+	MileageMax            *int
+	FuelType              domain.FuelType
+	Transmission          domain.Transmission
+	BodyType              domain.BodyType
+	Doors                 *int
+	EngineDisplacementMin *int
+	EngineDisplacementMax *int
+	Condition             domain.VehicleCondition
+}
+
+type VehicleSort struct {
+	Field VehicleSortField
+	Order SortOrder
+}
+
+type VehicleListParams struct {
+	Filter VehicleFilter
+	Sort   VehicleSort
+	Limit  int
+	Offset int
+}
+
 //go:generate mockgen -source=vehicle_repository.go -destination=./mocks/vehicle_repository_mock.go -package=mocks
 type VehicleRepository interface {
 	Save(ctx context.Context, vehicle *domain.Vehicle) error
 	GetByID(ctx context.Context, id string) (*domain.Vehicle, error)
+	// GetByIDForUpdate reads the vehicle and locks its row until the surrounding
+	// transaction ends. It must be called within Transactor.WithinTransaction.
+	GetByIDForUpdate(ctx context.Context, id string) (*domain.Vehicle, error)
+	// FindDuplicate returns the oldest other vehicle sharing the VIN, plate or
+	// RENAVAM of vehicle, or ErrNotFound when there is none.
+	FindDuplicate(ctx context.Context, vehicle *domain.Vehicle) (*domain.Vehicle, error)
+	List(ctx context.Context, params VehicleListParams) ([]*domain.Vehicle, int, error)
 	Update(ctx context.Context, vehicle *domain.Vehicle) error
+	UpdateStatus(ctx context.Context, vehicle *domain.Vehicle) error
+	Delete(ctx context.Context, id string, deletedAt time.Time) error
 }
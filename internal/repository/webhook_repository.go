@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+type WebhookDeliveryListParams struct {
+	SubscriptionID string
+	Status         domain.WebhookDeliveryStatus
+	Limit          int
+	Offset         int
+}
+
+// PendingWebhookDelivery pairs a due delivery with the secret of its
+// subscription, needed to sign the request.
+type PendingWebhookDelivery struct {
+	Delivery *domain.WebhookDelivery
+	Secret   string
+}
+
+//go:generate mockgen -source=webhook_repository.go -destination=./mocks/webhook_repository_mock.go -package=mocks
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	// ListSubscriptionsForEvent returns the subscriptions interested in eventType.
+	ListSubscriptionsForEvent(ctx context.Context, eventType domain.EventType) ([]*domain.WebhookSubscription, error)
+	// DeleteSubscription removes the subscription together with its delivery log.
+	DeleteSubscription(ctx context.Context, id string) error
+
+	// AddDelivery records a delivery. A first delivery of an event already
+	// recorded for the same subscription is ignored, so retried events are not
+	// sent twice; redeliveries are always recorded.
+	AddDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, subscriptionID, id string) (*domain.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, params WebhookDeliveryListParams) ([]*domain.WebhookDelivery, int, error)
+	// ClaimPendingDeliveries leases up to limit deliveries that are due at now
+	// to the caller until leaseUntil, skipping deliveries leased by another
+	// worker. The lease outlives the transaction, so deliveries can be sent
+	// outside of it.
+	ClaimPendingDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*PendingWebhookDelivery, error)
+	// UpdateDelivery stores the outcome of a delivery attempt and releases the
+	// lease of the delivery.
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
@@ -0,0 +1,66 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/storage"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalBlobStore(dir, "http://localhost:8080/media/")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("should store, serve and delete a blob", func(t *testing.T) {
+		err := store.Put(ctx, "vehicles/v-1/img.jpg", "image/jpeg", strings.NewReader("jpeg bytes"))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got := store.URL("vehicles/v-1/img.jpg"); got != "http://localhost:8080/media/vehicles/v-1/img.jpg" {
+			t.Errorf("unexpected URL %q", got)
+		}
+
+		w := httptest.NewRecorder()
+		store.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/vehicles/v-1/img.jpg", nil))
+		if w.Code != http.StatusOK || w.Body.String() != "jpeg bytes" {
+			t.Errorf("got %d %q, want the stored blob", w.Code, w.Body.String())
+		}
+
+		if err := store.Delete(ctx, "vehicles/v-1/img.jpg"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "vehicles", "v-1", "img.jpg")); !os.IsNotExist(err) {
+			t.Errorf("expected the file to be removed, got %v", err)
+		}
+		if err := store.Delete(ctx, "vehicles/v-1/img.jpg"); err != nil {
+			t.Errorf("expected deleting a missing blob to succeed, got %v", err)
+		}
+	})
+
+	t.Run("should not list directories", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		store.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/vehicles/", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("should reject keys escaping the directory", func(t *testing.T) {
+		for _, key := range []string{"", "../secret", "/etc/passwd", "a/../../b"} {
+			err := store.Put(ctx, key, "image/jpeg", strings.NewReader("x"))
+			if !errors.Is(err, storage.ErrInvalidKey) {
+				t.Errorf("key %q: expected ErrInvalidKey, got %v", key, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import "context"
+
+// anonymousActor is recorded when a change is made without an identified
+// actor.
+const anonymousActor = "anonymous"
+
+type actorContextKey struct{}
+
+// WithActor attributes the changes made with the returned context to actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or "anonymous".
+func ActorFromContext(ctx context.Context) string {
+	actor, ok := ctx.Value(actorContextKey{}).(string)
+	if !ok || actor == "" {
+		return anonymousActor
+	}
+	return actor
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate mockgen -source=blob_store.go -destination=./mocks/blob_store_mock.go -package=mocks
+type BlobStore interface {
+	// Put stores the content of r under key, replacing any previous blob.
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+	// Delete removes the blob under key. Deleting a missing blob is not an
+	// error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients download the blob under key from.
+	URL(key string) string
+}
@@ -0,0 +1,483 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=catalog_usecase.go -destination=./mocks/catalog_usecase_mock.go -package=mocks
+type CatalogUseCaseInterface interface {
+	CatalogResolver
+
+	CreateBrand(ctx context.Context, input dto.InputBrandDTO) (*dto.OutputBrandDTO, error)
+	ListBrands(ctx context.Context) (*dto.OutputListBrandsDTO, error)
+	GetBrand(ctx context.Context, id string) (*dto.OutputBrandDTO, error)
+	UpdateBrand(ctx context.Context, id string, input dto.InputBrandDTO) (*dto.OutputBrandDTO, error)
+	DeleteBrand(ctx context.Context, id string) error
+
+	CreateModel(ctx context.Context, brandID string, input dto.InputModelDTO) (*dto.OutputModelDTO, error)
+	ListModels(ctx context.Context, brandID string) (*dto.OutputListModelsDTO, error)
+	GetModel(ctx context.Context, brandID, id string) (*dto.OutputModelDTO, error)
+	UpdateModel(ctx context.Context, brandID, id string, input dto.InputModelDTO) (*dto.OutputModelDTO, error)
+	DeleteModel(ctx context.Context, brandID, id string) error
+}
+
+// CatalogResolver maps the brand and model typed for a vehicle to the
+// canonical entries of the catalog.
+type CatalogResolver interface {
+	// Resolve returns the canonical names of brand and model, matched by name
+	// or alias regardless of case and whitespace. Unknown entries fail with
+	// domain.ValidationErrors, unless the resolver creates them.
+	Resolve(ctx context.Context, brand, model string) (string, string, error)
+}
+
+type CatalogConfig struct {
+	// AutoCreate makes Resolve add unknown brands and models to the catalog
+	// instead of rejecting them.
+	AutoCreate bool
+}
+
+type catalogUseCase struct {
+	repo   repository.CatalogRepository
+	config CatalogConfig
+}
+
+func NewCatalogUseCase(repo repository.CatalogRepository, config CatalogConfig) CatalogUseCaseInterface {
+	return &catalogUseCase{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// CreateBrand is the handler for the POST /brands endpoint.
+// @Summary      Create a brand
+// @Description  Adds a canonical brand to the catalog. Vehicles typed with the name or any alias, in any case, are stored with the name.
+// @Tags         Catalog
+// @Accept       json
+// @Produce      json
+// @Param        brand  body      dto.InputBrandDTO  true  "Brand data"
+// @Success      201    {object}  dto.OutputBrandDTO
+// @Failure      400    {object}  dto.ProblemDetailsDTO "Invalid request body or brand data"
+// @Failure      409    {object}  dto.ProblemDetailsDTO "Name or alias already used by another brand"
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands [post]
+func (cuc *catalogUseCase) CreateBrand(ctx context.Context, input dto.InputBrandDTO) (*dto.OutputBrandDTO, error) {
+	now := time.Now()
+	brand := &domain.Brand{
+		ID:        uuid.New().String(),
+		Name:      domain.CatalogName(input.Name),
+		Aliases:   catalogAliases(input.Name, input.Aliases),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := utils.ValidateBrand(brand)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.checkBrandKeys(ctx, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.repo.CreateBrand(ctx, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputBrandDTO(brand)
+	return &output, nil
+}
+
+// ListBrands is the handler for the GET /brands endpoint.
+// @Summary      List brands
+// @Description  Returns every brand of the catalog, sorted by name.
+// @Tags         Catalog
+// @Produce      json
+// @Success      200  {object}  dto.OutputListBrandsDTO
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands [get]
+func (cuc *catalogUseCase) ListBrands(ctx context.Context) (*dto.OutputListBrandsDTO, error) {
+	brands, err := cuc.repo.ListBrands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.OutputBrandDTO, 0, len(brands))
+	for _, brand := range brands {
+		items = append(items, toOutputBrandDTO(brand))
+	}
+
+	return &dto.OutputListBrandsDTO{Items: items}, nil
+}
+
+// GetBrand is the handler for the GET /brands/{id} endpoint.
+// @Summary      Get a brand
+// @Description  Returns a brand of the catalog by its ID.
+// @Tags         Catalog
+// @Produce      json
+// @Param        id   path      string  true  "Brand ID"
+// @Success      200  {object}  dto.OutputBrandDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Brand not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id} [get]
+func (cuc *catalogUseCase) GetBrand(ctx context.Context, id string) (*dto.OutputBrandDTO, error) {
+	brand, err := cuc.repo.GetBrand(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputBrandDTO(brand)
+	return &output, nil
+}
+
+// UpdateBrand is the handler for the PUT /brands/{id} endpoint.
+// @Summary      Update a brand
+// @Description  Replaces the name and aliases of a brand. Vehicles already stored keep the brand they were saved with.
+// @Tags         Catalog
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string             true  "Brand ID"
+// @Param        brand  body      dto.InputBrandDTO  true  "Brand data"
+// @Success      200    {object}  dto.OutputBrandDTO
+// @Failure      400    {object}  dto.ProblemDetailsDTO "Invalid request body, ID or brand data"
+// @Failure      404    {object}  dto.ProblemDetailsDTO "Brand not found"
+// @Failure      409    {object}  dto.ProblemDetailsDTO "Name or alias already used by another brand"
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id} [put]
+func (cuc *catalogUseCase) UpdateBrand(ctx context.Context, id string, input dto.InputBrandDTO) (*dto.OutputBrandDTO, error) {
+	brand, err := cuc.repo.GetBrand(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	brand.Name = domain.CatalogName(input.Name)
+	brand.Aliases = catalogAliases(input.Name, input.Aliases)
+	brand.UpdatedAt = time.Now()
+
+	err = utils.ValidateBrand(brand)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.checkBrandKeys(ctx, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.repo.UpdateBrand(ctx, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputBrandDTO(brand)
+	return &output, nil
+}
+
+// DeleteBrand is the handler for the DELETE /brands/{id} endpoint.
+// @Summary      Delete a brand
+// @Description  Removes a brand and its models from the catalog. Vehicles already stored are not changed.
+// @Tags         Catalog
+// @Param        id   path      string  true  "Brand ID"
+// @Success      204  {string}  string "No Content"
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Brand not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id} [delete]
+func (cuc *catalogUseCase) DeleteBrand(ctx context.Context, id string) error {
+	return cuc.repo.DeleteBrand(ctx, id)
+}
+
+// CreateModel is the handler for the POST /brands/{id}/models endpoint.
+// @Summary      Create a model
+// @Description  Adds a canonical model to a brand of the catalog.
+// @Tags         Catalog
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string             true  "Brand ID"
+// @Param        model  body      dto.InputModelDTO  true  "Model data"
+// @Success      201    {object}  dto.OutputModelDTO
+// @Failure      400    {object}  dto.ProblemDetailsDTO "Invalid request body, ID or model data"
+// @Failure      404    {object}  dto.ProblemDetailsDTO "Brand not found"
+// @Failure      409    {object}  dto.ProblemDetailsDTO "Name or alias already used by another model of the brand"
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id}/models [post]
+func (cuc *catalogUseCase) CreateModel(ctx context.Context, brandID string, input dto.InputModelDTO) (*dto.OutputModelDTO, error) {
+	_, err := cuc.repo.GetBrand(ctx, brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	model := &domain.Model{
+		ID:        uuid.New().String(),
+		BrandID:   brandID,
+		Name:      domain.CatalogName(input.Name),
+		Aliases:   catalogAliases(input.Name, input.Aliases),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = utils.ValidateModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.checkModelKeys(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.repo.CreateModel(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputModelDTO(model)
+	return &output, nil
+}
+
+// ListModels is the handler for the GET /brands/{id}/models endpoint.
+// @Summary      List models
+// @Description  Returns every model of a brand, sorted by name.
+// @Tags         Catalog
+// @Produce      json
+// @Param        id   path      string  true  "Brand ID"
+// @Success      200  {object}  dto.OutputListModelsDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Brand not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id}/models [get]
+func (cuc *catalogUseCase) ListModels(ctx context.Context, brandID string) (*dto.OutputListModelsDTO, error) {
+	_, err := cuc.repo.GetBrand(ctx, brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := cuc.repo.ListModels(ctx, brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.OutputModelDTO, 0, len(models))
+	for _, model := range models {
+		items = append(items, toOutputModelDTO(model))
+	}
+
+	return &dto.OutputListModelsDTO{Items: items}, nil
+}
+
+// GetModel is the handler for the GET /brands/{id}/models/{modelId} endpoint.
+// @Summary      Get a model
+// @Description  Returns a model of a brand by its ID.
+// @Tags         Catalog
+// @Produce      json
+// @Param        id       path      string  true  "Brand ID"
+// @Param        modelId  path      string  true  "Model ID"
+// @Success      200      {object}  dto.OutputModelDTO
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404      {object}  dto.ProblemDetailsDTO "Model not found"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id}/models/{modelId} [get]
+func (cuc *catalogUseCase) GetModel(ctx context.Context, brandID, id string) (*dto.OutputModelDTO, error) {
+	model, err := cuc.repo.GetModel(ctx, brandID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputModelDTO(model)
+	return &output, nil
+}
+
+// UpdateModel is the handler for the PUT /brands/{id}/models/{modelId} endpoint.
+// @Summary      Update a model
+// @Description  Replaces the name and aliases of a model. Vehicles already stored keep the model they were saved with.
+// @Tags         Catalog
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string             true  "Brand ID"
+// @Param        modelId  path      string             true  "Model ID"
+// @Param        model    body      dto.InputModelDTO  true  "Model data"
+// @Success      200      {object}  dto.OutputModelDTO
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid request body, ID or model data"
+// @Failure      404      {object}  dto.ProblemDetailsDTO "Model not found"
+// @Failure      409      {object}  dto.ProblemDetailsDTO "Name or alias already used by another model of the brand"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id}/models/{modelId} [put]
+func (cuc *catalogUseCase) UpdateModel(ctx context.Context, brandID, id string, input dto.InputModelDTO) (*dto.OutputModelDTO, error) {
+	model, err := cuc.repo.GetModel(ctx, brandID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	model.Name = domain.CatalogName(input.Name)
+	model.Aliases = catalogAliases(input.Name, input.Aliases)
+	model.UpdatedAt = time.Now()
+
+	err = utils.ValidateModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.checkModelKeys(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cuc.repo.UpdateModel(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputModelDTO(model)
+	return &output, nil
+}
+
+// DeleteModel is the handler for the DELETE /brands/{id}/models/{modelId} endpoint.
+// @Summary      Delete a model
+// @Description  Removes a model from a brand of the catalog. Vehicles already stored are not changed.
+// @Tags         Catalog
+// @Param        id       path      string  true  "Brand ID"
+// @Param        modelId  path      string  true  "Model ID"
+// @Success      204      {string}  string "No Content"
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404      {object}  dto.ProblemDetailsDTO "Model not found"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /brands/{id}/models/{modelId} [delete]
+func (cuc *catalogUseCase) DeleteModel(ctx context.Context, brandID, id string) error {
+	return cuc.repo.DeleteModel(ctx, brandID, id)
+}
+
+func (cuc *catalogUseCase) Resolve(ctx context.Context, brandName, modelName string) (string, string, error) {
+	brand, err := cuc.repo.FindBrand(ctx, domain.CatalogKey(brandName))
+	if errors.Is(err, domain.ErrNotFound) {
+		if !cuc.config.AutoCreate {
+			return "", "", domain.ValidationErrors{{
+				Field:   "brand",
+				Code:    domain.CodeUnknown,
+				Message: fmt.Sprintf("brand %q is not in the catalog", domain.CatalogName(brandName)),
+			}}
+		}
+
+		now := time.Now()
+		brand = &domain.Brand{ID: uuid.New().String(), Name: domain.CatalogName(brandName), CreatedAt: now, UpdatedAt: now}
+		err = cuc.repo.CreateBrand(ctx, brand)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	model, err := cuc.repo.FindModel(ctx, brand.ID, domain.CatalogKey(modelName))
+	if errors.Is(err, domain.ErrNotFound) {
+		if !cuc.config.AutoCreate {
+			return "", "", domain.ValidationErrors{{
+				Field:   "model",
+				Code:    domain.CodeUnknown,
+				Message: fmt.Sprintf("model %q is not in the catalog for brand %s", domain.CatalogName(modelName), brand.Name),
+			}}
+		}
+
+		now := time.Now()
+		model = &domain.Model{ID: uuid.New().String(), BrandID: brand.ID, Name: domain.CatalogName(modelName), CreatedAt: now, UpdatedAt: now}
+		err = cuc.repo.CreateModel(ctx, model)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return brand.Name, model.Name, nil
+}
+
+// checkBrandKeys fails with ErrConflict when the name or an alias of brand
+// already resolves to another brand.
+func (cuc *catalogUseCase) checkBrandKeys(ctx context.Context, brand *domain.Brand) error {
+	for _, name := range append([]string{brand.Name}, brand.Aliases...) {
+		existing, err := cuc.repo.FindBrand(ctx, domain.CatalogKey(name))
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if existing.ID != brand.ID {
+			return fmt.Errorf("%w: %q already resolves to brand %s", domain.ErrConflict, name, existing.Name)
+		}
+	}
+	return nil
+}
+
+// checkModelKeys fails with ErrConflict when the name or an alias of model
+// already resolves to another model of its brand.
+func (cuc *catalogUseCase) checkModelKeys(ctx context.Context, model *domain.Model) error {
+	for _, name := range append([]string{model.Name}, model.Aliases...) {
+		existing, err := cuc.repo.FindModel(ctx, model.BrandID, domain.CatalogKey(name))
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if existing.ID != model.ID {
+			return fmt.Errorf("%w: %q already resolves to model %s", domain.ErrConflict, name, existing.Name)
+		}
+	}
+	return nil
+}
+
+// catalogAliases normalizes aliases, dropping repetitions and those matching
+// the name. Blank aliases are kept for validation to report.
+func catalogAliases(name string, aliases []string) []string {
+	seen := map[string]bool{domain.CatalogKey(name): true}
+	normalized := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = domain.CatalogName(alias)
+		key := domain.CatalogKey(alias)
+		if alias != "" && seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, alias)
+	}
+	return normalized
+}
+
+func toOutputBrandDTO(brand *domain.Brand) dto.OutputBrandDTO {
+	aliases := brand.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return dto.OutputBrandDTO{
+		ID:        brand.ID,
+		Name:      brand.Name,
+		Aliases:   aliases,
+		CreatedAt: brand.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: brand.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toOutputModelDTO(model *domain.Model) dto.OutputModelDTO {
+	aliases := model.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return dto.OutputModelDTO{
+		ID:        model.ID,
+		BrandID:   model.BrandID,
+		Name:      model.Name,
+		Aliases:   aliases,
+		CreatedAt: model.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: model.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CatalogUseCaseSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	repository *mocks.MockCatalogRepository
+}
+
+func (suite *CatalogUseCaseSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.repository = mocks.NewMockCatalogRepository(ctrl)
+}
+
+func Test_CatalogUseCaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(CatalogUseCaseSuite))
+}
+
+var volkswagen = &domain.Brand{ID: "brand-1", Name: "Volkswagen", Aliases: []string{"VW"}}
+
+func (suite *CatalogUseCaseSuite) Test_CreateBrand() {
+	useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{})
+
+	suite.T().Run("should normalize the name and aliases", func(t *testing.T) {
+		input := dto.InputBrandDTO{Name: "  Mercedes   Benz ", Aliases: []string{"mercedes-benz", " Mercedes  ", "MERCEDES BENZ", "mercedes"}}
+
+		suite.repository.EXPECT().FindBrand(suite.ctx, gomock.Any()).Return(nil, domain.ErrNotFound).Times(3)
+		suite.repository.EXPECT().
+			CreateBrand(suite.ctx, gomock.Cond(func(b *domain.Brand) bool {
+				return b.Name == "Mercedes Benz" && len(b.Aliases) == 2 &&
+					b.Aliases[0] == "mercedes-benz" && b.Aliases[1] == "Mercedes"
+			})).
+			Return(nil)
+
+		output, err := useCase.CreateBrand(suite.ctx, input)
+		suite.NoError(err)
+		suite.Equal("Mercedes Benz", output.Name)
+		suite.NotEmpty(output.ID)
+	})
+
+	suite.T().Run("should reject an alias of another brand", func(t *testing.T) {
+		suite.repository.EXPECT().FindBrand(suite.ctx, "volkswagen do brasil").Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().FindBrand(suite.ctx, "vw").Return(volkswagen, nil)
+
+		_, err := useCase.CreateBrand(suite.ctx, dto.InputBrandDTO{Name: "Volkswagen do Brasil", Aliases: []string{"VW"}})
+		suite.ErrorIs(err, domain.ErrConflict)
+	})
+
+	suite.T().Run("should return validation errors for a blank name", func(t *testing.T) {
+		_, err := useCase.CreateBrand(suite.ctx, dto.InputBrandDTO{Name: "   "})
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal("name", validationErrs[0].Field)
+		suite.Equal(domain.CodeRequired, validationErrs[0].Code)
+	})
+}
+
+func (suite *CatalogUseCaseSuite) Test_UpdateBrand() {
+	useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{})
+
+	suite.T().Run("should allow keeping its own name and aliases", func(t *testing.T) {
+		brand := *volkswagen
+		suite.repository.EXPECT().GetBrand(suite.ctx, "brand-1").Return(&brand, nil)
+		suite.repository.EXPECT().FindBrand(suite.ctx, gomock.Any()).Return(volkswagen, nil).Times(3)
+		suite.repository.EXPECT().
+			UpdateBrand(suite.ctx, gomock.Cond(func(b *domain.Brand) bool {
+				return b.ID == "brand-1" && len(b.Aliases) == 2
+			})).
+			Return(nil)
+
+		output, err := useCase.UpdateBrand(suite.ctx, "brand-1", dto.InputBrandDTO{Name: "Volkswagen", Aliases: []string{"VW", "Volks"}})
+		suite.NoError(err)
+		suite.Equal([]string{"VW", "Volks"}, output.Aliases)
+	})
+}
+
+func (suite *CatalogUseCaseSuite) Test_CreateModel() {
+	useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{})
+
+	suite.T().Run("should add the model to the brand", func(t *testing.T) {
+		suite.repository.EXPECT().GetBrand(suite.ctx, "brand-1").Return(volkswagen, nil)
+		suite.repository.EXPECT().FindModel(suite.ctx, "brand-1", "gol").Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().
+			CreateModel(suite.ctx, gomock.Cond(func(m *domain.Model) bool {
+				return m.BrandID == "brand-1" && m.Name == "Gol"
+			})).
+			Return(nil)
+
+		output, err := useCase.CreateModel(suite.ctx, "brand-1", dto.InputModelDTO{Name: "Gol"})
+		suite.NoError(err)
+		suite.Equal("brand-1", output.BrandID)
+		suite.Equal([]string{}, output.Aliases)
+	})
+
+	suite.T().Run("should return ErrNotFound for an unknown brand", func(t *testing.T) {
+		suite.repository.EXPECT().GetBrand(suite.ctx, "missing").Return(nil, domain.ErrNotFound)
+
+		_, err := useCase.CreateModel(suite.ctx, "missing", dto.InputModelDTO{Name: "Gol"})
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+func (suite *CatalogUseCaseSuite) Test_Resolve() {
+	gol := &domain.Model{ID: "model-1", BrandID: "brand-1", Name: "Gol"}
+
+	suite.T().Run("should return the canonical names", func(t *testing.T) {
+		useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{})
+		suite.repository.EXPECT().FindBrand(suite.ctx, "vw").Return(volkswagen, nil)
+		suite.repository.EXPECT().FindModel(suite.ctx, "brand-1", "gol").Return(gol, nil)
+
+		brand, model, err := useCase.Resolve(suite.ctx, " vw ", "GOL")
+		suite.NoError(err)
+		suite.Equal("Volkswagen", brand)
+		suite.Equal("Gol", model)
+	})
+
+	suite.T().Run("should reject an unknown brand", func(t *testing.T) {
+		useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{})
+		suite.repository.EXPECT().FindBrand(suite.ctx, "gurgel").Return(nil, domain.ErrNotFound)
+
+		_, _, err := useCase.Resolve(suite.ctx, "Gurgel", "BR-800")
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal("brand", validationErrs[0].Field)
+		suite.Equal(domain.CodeUnknown, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject a model of another brand", func(t *testing.T) {
+		useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{})
+		suite.repository.EXPECT().FindBrand(suite.ctx, "volkswagen").Return(volkswagen, nil)
+		suite.repository.EXPECT().FindModel(suite.ctx, "brand-1", "corolla").Return(nil, domain.ErrNotFound)
+
+		_, _, err := useCase.Resolve(suite.ctx, "Volkswagen", "Corolla")
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal("model", validationErrs[0].Field)
+		suite.Equal(domain.CodeUnknown, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should create unknown entries when allowed", func(t *testing.T) {
+		useCase := usecase.NewCatalogUseCase(suite.repository, usecase.CatalogConfig{AutoCreate: true})
+		var created *domain.Brand
+		suite.repository.EXPECT().FindBrand(suite.ctx, "gurgel").Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().
+			CreateBrand(suite.ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, b *domain.Brand) error {
+				created = b
+				return nil
+			})
+		suite.repository.EXPECT().
+			FindModel(suite.ctx, gomock.Any(), "br-800").
+			Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().
+			CreateModel(suite.ctx, gomock.Cond(func(m *domain.Model) bool {
+				return m.BrandID == created.ID && m.Name == "BR-800"
+			})).
+			Return(nil)
+
+		brand, model, err := useCase.Resolve(suite.ctx, " Gurgel", "BR-800 ")
+		suite.NoError(err)
+		suite.Equal("Gurgel", brand)
+		suite.Equal("BR-800", model)
+	})
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+//go:generate mockgen -source=event_publisher.go -destination=./mocks/event_publisher_mock.go -package=mocks
+type EventPublisher interface {
+	// Publish hands event to its consumers. The use case publishes inside the
+	// transaction of the vehicle change, so the publisher it is given should
+	// record the event in that transaction rather than call remote services.
+	Publish(ctx context.Context, event domain.Event) error
+}
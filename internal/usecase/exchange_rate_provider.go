@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+)
+
+//go:generate mockgen -source=exchange_rate_provider.go -destination=./mocks/exchange_rate_provider_mock.go -package=mocks
+type ExchangeRateProvider interface {
+	// Rate returns the value of one unit of from in to. It fails with
+	// domain.ErrUnsupportedCurrency when either currency has no known rate.
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+// ListingPrices converts the price of a vehicle to each of currencies other
+// than its own, for its showcase listing. Currencies without a known rate, or
+// in which the price is out of range, are left out rather than holding the
+// listing back.
+func ListingPrices(ctx context.Context, rates ExchangeRateProvider, currencies []string, vehicleID string, price domain.Money) ([]dto.ConvertedPriceDTO, error) {
+	var converted []dto.ConvertedPriceDTO
+	for _, currency := range currencies {
+		if currency == price.Currency() {
+			continue
+		}
+
+		rate, err := rates.Rate(ctx, price.Currency(), currency)
+		if errors.Is(err, domain.ErrUnsupportedCurrency) {
+			log.Printf("Warning: listing of vehicle %s sent without %s price: %v", vehicleID, currency, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		convertedPrice, err := price.Convert(rate, currency)
+		if err != nil {
+			log.Printf("Warning: listing of vehicle %s sent without %s price: %v", vehicleID, currency, err)
+			continue
+		}
+		converted = append(converted, dto.ConvertedPriceDTO{
+			Price:    convertedPrice,
+			Currency: currency,
+		})
+	}
+	return converted, nil
+}
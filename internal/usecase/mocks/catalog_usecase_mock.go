@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalog_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=catalog_usecase.go -destination=./mocks/catalog_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogUseCaseInterface is a mock of CatalogUseCaseInterface interface.
+type MockCatalogUseCaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogUseCaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogUseCaseInterfaceMockRecorder is the mock recorder for MockCatalogUseCaseInterface.
+type MockCatalogUseCaseInterfaceMockRecorder struct {
+	mock *MockCatalogUseCaseInterface
+}
+
+// NewMockCatalogUseCaseInterface creates a new mock instance.
+func NewMockCatalogUseCaseInterface(ctrl *gomock.Controller) *MockCatalogUseCaseInterface {
+	mock := &MockCatalogUseCaseInterface{ctrl: ctrl}
+	mock.recorder = &MockCatalogUseCaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogUseCaseInterface) EXPECT() *MockCatalogUseCaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogUseCaseInterface) CreateBrand(ctx context.Context, input dto.InputBrandDTO) (*dto.OutputBrandDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputBrandDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) CreateBrand(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).CreateBrand), ctx, input)
+}
+
+// CreateModel mocks base method.
+func (m *MockCatalogUseCaseInterface) CreateModel(ctx context.Context, brandID string, input dto.InputModelDTO) (*dto.OutputModelDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModel", ctx, brandID, input)
+	ret0, _ := ret[0].(*dto.OutputModelDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) CreateModel(ctx, brandID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).CreateModel), ctx, brandID, input)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockCatalogUseCaseInterface) DeleteBrand(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) DeleteBrand(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).DeleteBrand), ctx, id)
+}
+
+// DeleteModel mocks base method.
+func (m *MockCatalogUseCaseInterface) DeleteModel(ctx context.Context, brandID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", ctx, brandID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) DeleteModel(ctx, brandID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).DeleteModel), ctx, brandID, id)
+}
+
+// GetBrand mocks base method.
+func (m *MockCatalogUseCaseInterface) GetBrand(ctx context.Context, id string) (*dto.OutputBrandDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrand", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputBrandDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrand indicates an expected call of GetBrand.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) GetBrand(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrand", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).GetBrand), ctx, id)
+}
+
+// GetModel mocks base method.
+func (m *MockCatalogUseCaseInterface) GetModel(ctx context.Context, brandID, id string) (*dto.OutputModelDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModel", ctx, brandID, id)
+	ret0, _ := ret[0].(*dto.OutputModelDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModel indicates an expected call of GetModel.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) GetModel(ctx, brandID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).GetModel), ctx, brandID, id)
+}
+
+// ListBrands mocks base method.
+func (m *MockCatalogUseCaseInterface) ListBrands(ctx context.Context) (*dto.OutputListBrandsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrands", ctx)
+	ret0, _ := ret[0].(*dto.OutputListBrandsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrands indicates an expected call of ListBrands.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) ListBrands(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrands", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).ListBrands), ctx)
+}
+
+// ListModels mocks base method.
+func (m *MockCatalogUseCaseInterface) ListModels(ctx context.Context, brandID string) (*dto.OutputListModelsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModels", ctx, brandID)
+	ret0, _ := ret[0].(*dto.OutputListModelsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModels indicates an expected call of ListModels.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) ListModels(ctx, brandID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModels", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).ListModels), ctx, brandID)
+}
+
+// Resolve mocks base method.
+func (m *MockCatalogUseCaseInterface) Resolve(ctx context.Context, brand, model string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, brand, model)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) Resolve(ctx, brand, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).Resolve), ctx, brand, model)
+}
+
+// UpdateBrand mocks base method.
+func (m *MockCatalogUseCaseInterface) UpdateBrand(ctx context.Context, id string, input dto.InputBrandDTO) (*dto.OutputBrandDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrand", ctx, id, input)
+	ret0, _ := ret[0].(*dto.OutputBrandDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) UpdateBrand(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).UpdateBrand), ctx, id, input)
+}
+
+// UpdateModel mocks base method.
+func (m *MockCatalogUseCaseInterface) UpdateModel(ctx context.Context, brandID, id string, input dto.InputModelDTO) (*dto.OutputModelDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateModel", ctx, brandID, id, input)
+	ret0, _ := ret[0].(*dto.OutputModelDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockCatalogUseCaseInterfaceMockRecorder) UpdateModel(ctx, brandID, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockCatalogUseCaseInterface)(nil).UpdateModel), ctx, brandID, id, input)
+}
+
+// MockCatalogResolver is a mock of CatalogResolver interface.
+type MockCatalogResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogResolverMockRecorder is the mock recorder for MockCatalogResolver.
+type MockCatalogResolverMockRecorder struct {
+	mock *MockCatalogResolver
+}
+
+// NewMockCatalogResolver creates a new mock instance.
+func NewMockCatalogResolver(ctrl *gomock.Controller) *MockCatalogResolver {
+	mock := &MockCatalogResolver{ctrl: ctrl}
+	mock.recorder = &MockCatalogResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogResolver) EXPECT() *MockCatalogResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockCatalogResolver) Resolve(ctx context.Context, brand, model string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, brand, model)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockCatalogResolverMockRecorder) Resolve(ctx, brand, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockCatalogResolver)(nil).Resolve), ctx, brand, model)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exchange_rate_provider.go
+//
+// Generated by this command:
+//
+//	mockgen -source=exchange_rate_provider.go -destination=./mocks/exchange_rate_provider_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExchangeRateProvider is a mock of ExchangeRateProvider interface.
+type MockExchangeRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockExchangeRateProviderMockRecorder is the mock recorder for MockExchangeRateProvider.
+type MockExchangeRateProviderMockRecorder struct {
+	mock *MockExchangeRateProvider
+}
+
+// NewMockExchangeRateProvider creates a new mock instance.
+func NewMockExchangeRateProvider(ctrl *gomock.Controller) *MockExchangeRateProvider {
+	mock := &MockExchangeRateProvider{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateProvider) EXPECT() *MockExchangeRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockExchangeRateProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(*big.Rat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockExchangeRateProviderMockRecorder) Rate(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockExchangeRateProvider)(nil).Rate), ctx, from, to)
+}
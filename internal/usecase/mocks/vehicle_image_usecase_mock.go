@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_image_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vehicle_image_usecase.go -destination=./mocks/vehicle_image_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	dto "github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVehicleImageUseCaseInterface is a mock of VehicleImageUseCaseInterface interface.
+type MockVehicleImageUseCaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleImageUseCaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleImageUseCaseInterfaceMockRecorder is the mock recorder for MockVehicleImageUseCaseInterface.
+type MockVehicleImageUseCaseInterfaceMockRecorder struct {
+	mock *MockVehicleImageUseCaseInterface
+}
+
+// NewMockVehicleImageUseCaseInterface creates a new mock instance.
+func NewMockVehicleImageUseCaseInterface(ctrl *gomock.Controller) *MockVehicleImageUseCaseInterface {
+	mock := &MockVehicleImageUseCaseInterface{ctrl: ctrl}
+	mock.recorder = &MockVehicleImageUseCaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleImageUseCaseInterface) EXPECT() *MockVehicleImageUseCaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockVehicleImageUseCaseInterface) Delete(ctx context.Context, vehicleID, imageID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, vehicleID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) Delete(ctx, vehicleID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).Delete), ctx, vehicleID, imageID)
+}
+
+// Images mocks base method.
+func (m *MockVehicleImageUseCaseInterface) Images(ctx context.Context, vehicleID string) ([]dto.OutputVehicleImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Images", ctx, vehicleID)
+	ret0, _ := ret[0].([]dto.OutputVehicleImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Images indicates an expected call of Images.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) Images(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Images", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).Images), ctx, vehicleID)
+}
+
+// List mocks base method.
+func (m *MockVehicleImageUseCaseInterface) List(ctx context.Context, vehicleID string) (*dto.OutputListVehicleImagesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vehicleID)
+	ret0, _ := ret[0].(*dto.OutputListVehicleImagesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) List(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).List), ctx, vehicleID)
+}
+
+// MaxSize mocks base method.
+func (m *MockVehicleImageUseCaseInterface) MaxSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxSize indicates an expected call of MaxSize.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) MaxSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxSize", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).MaxSize))
+}
+
+// Reorder mocks base method.
+func (m *MockVehicleImageUseCaseInterface) Reorder(ctx context.Context, vehicleID string, input dto.InputReorderVehicleImagesDTO) (*dto.OutputListVehicleImagesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, vehicleID, input)
+	ret0, _ := ret[0].(*dto.OutputListVehicleImagesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) Reorder(ctx, vehicleID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).Reorder), ctx, vehicleID, input)
+}
+
+// SetCover mocks base method.
+func (m *MockVehicleImageUseCaseInterface) SetCover(ctx context.Context, vehicleID, imageID string) (*dto.OutputListVehicleImagesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCover", ctx, vehicleID, imageID)
+	ret0, _ := ret[0].(*dto.OutputListVehicleImagesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCover indicates an expected call of SetCover.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) SetCover(ctx, vehicleID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCover", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).SetCover), ctx, vehicleID, imageID)
+}
+
+// Upload mocks base method.
+func (m *MockVehicleImageUseCaseInterface) Upload(ctx context.Context, vehicleID string, content io.Reader) (*dto.OutputVehicleImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, vehicleID, content)
+	ret0, _ := ret[0].(*dto.OutputVehicleImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockVehicleImageUseCaseInterfaceMockRecorder) Upload(ctx, vehicleID, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockVehicleImageUseCaseInterface)(nil).Upload), ctx, vehicleID, content)
+}
+
+// MockVehicleImageLister is a mock of VehicleImageLister interface.
+type MockVehicleImageLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleImageListerMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleImageListerMockRecorder is the mock recorder for MockVehicleImageLister.
+type MockVehicleImageListerMockRecorder struct {
+	mock *MockVehicleImageLister
+}
+
+// NewMockVehicleImageLister creates a new mock instance.
+func NewMockVehicleImageLister(ctrl *gomock.Controller) *MockVehicleImageLister {
+	mock := &MockVehicleImageLister{ctrl: ctrl}
+	mock.recorder = &MockVehicleImageListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleImageLister) EXPECT() *MockVehicleImageListerMockRecorder {
+	return m.recorder
+}
+
+// Images mocks base method.
+func (m *MockVehicleImageLister) Images(ctx context.Context, vehicleID string) ([]dto.OutputVehicleImageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Images", ctx, vehicleID)
+	ret0, _ := ret[0].([]dto.OutputVehicleImageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Images indicates an expected call of Images.
+func (mr *MockVehicleImageListerMockRecorder) Images(ctx, vehicleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Images", reflect.TypeOf((*MockVehicleImageLister)(nil).Images), ctx, vehicleID)
+}
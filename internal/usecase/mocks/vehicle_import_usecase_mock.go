@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_import_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vehicle_import_usecase.go -destination=./mocks/vehicle_import_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	dto "github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVehicleImportUseCaseInterface is a mock of VehicleImportUseCaseInterface interface.
+type MockVehicleImportUseCaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleImportUseCaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleImportUseCaseInterfaceMockRecorder is the mock recorder for MockVehicleImportUseCaseInterface.
+type MockVehicleImportUseCaseInterfaceMockRecorder struct {
+	mock *MockVehicleImportUseCaseInterface
+}
+
+// NewMockVehicleImportUseCaseInterface creates a new mock instance.
+func NewMockVehicleImportUseCaseInterface(ctrl *gomock.Controller) *MockVehicleImportUseCaseInterface {
+	mock := &MockVehicleImportUseCaseInterface{ctrl: ctrl}
+	mock.recorder = &MockVehicleImportUseCaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleImportUseCaseInterface) EXPECT() *MockVehicleImportUseCaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FailStaleJobs mocks base method.
+func (m *MockVehicleImportUseCaseInterface) FailStaleJobs(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailStaleJobs", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailStaleJobs indicates an expected call of FailStaleJobs.
+func (mr *MockVehicleImportUseCaseInterfaceMockRecorder) FailStaleJobs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailStaleJobs", reflect.TypeOf((*MockVehicleImportUseCaseInterface)(nil).FailStaleJobs), ctx)
+}
+
+// GetJob mocks base method.
+func (m *MockVehicleImportUseCaseInterface) GetJob(ctx context.Context, id string) (*dto.OutputImportJobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputImportJobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockVehicleImportUseCaseInterfaceMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockVehicleImportUseCaseInterface)(nil).GetJob), ctx, id)
+}
+
+// Import mocks base method.
+func (m *MockVehicleImportUseCaseInterface) Import(ctx context.Context, input dto.InputImportVehiclesDTO, content io.Reader) (*dto.OutputImportVehiclesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, input, content)
+	ret0, _ := ret[0].(*dto.OutputImportVehiclesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockVehicleImportUseCaseInterfaceMockRecorder) Import(ctx, input, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockVehicleImportUseCaseInterface)(nil).Import), ctx, input, content)
+}
+
+// Wait mocks base method.
+func (m *MockVehicleImportUseCaseInterface) Wait(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockVehicleImportUseCaseInterfaceMockRecorder) Wait(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockVehicleImportUseCaseInterface)(nil).Wait), ctx)
+}
+
+// MockVehicleCreator is a mock of VehicleCreator interface.
+type MockVehicleCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockVehicleCreatorMockRecorder is the mock recorder for MockVehicleCreator.
+type MockVehicleCreatorMockRecorder struct {
+	mock *MockVehicleCreator
+}
+
+// NewMockVehicleCreator creates a new mock instance.
+func NewMockVehicleCreator(ctrl *gomock.Controller) *MockVehicleCreator {
+	mock := &MockVehicleCreator{ctrl: ctrl}
+	mock.recorder = &MockVehicleCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleCreator) EXPECT() *MockVehicleCreatorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVehicleCreator) Create(ctx context.Context, input dto.InputCreateVehicleDTO) (*dto.OutputCreateVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputCreateVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVehicleCreatorMockRecorder) Create(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicleCreator)(nil).Create), ctx, input)
+}
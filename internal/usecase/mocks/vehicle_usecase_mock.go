@@ -56,16 +56,166 @@ func (mr *MockVehicleUseCaseInterfaceMockRecorder) Create(ctx, input any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Create), ctx, input)
 }
 
-// Update mocks base method.
-func (m *MockVehicleUseCaseInterface) Update(ctx context.Context, id string, input dto.InputUpdateVehicleDTO) error {
+// Delete mocks base method.
+func (m *MockVehicleUseCaseInterface) Delete(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, input)
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockVehicleUseCaseInterface) GetByID(ctx context.Context, id, currency string) (*dto.OutputVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, currency)
+	ret0, _ := ret[0].(*dto.OutputVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) GetByID(ctx, id, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).GetByID), ctx, id, currency)
+}
+
+// History mocks base method.
+func (m *MockVehicleUseCaseInterface) History(ctx context.Context, id string, input dto.InputListVehicleHistoryDTO) (*dto.OutputListVehicleHistoryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id, input)
+	ret0, _ := ret[0].(*dto.OutputListVehicleHistoryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) History(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).History), ctx, id, input)
+}
+
+// List mocks base method.
+func (m *MockVehicleUseCaseInterface) List(ctx context.Context, input dto.InputListVehiclesDTO) (*dto.OutputListVehiclesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputListVehiclesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) List(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).List), ctx, input)
+}
+
+// Patch mocks base method.
+func (m *MockVehicleUseCaseInterface) Patch(ctx context.Context, id string, expectedVersion int, input dto.InputPatchVehicleDTO) (*dto.OutputVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, expectedVersion, input)
+	ret0, _ := ret[0].(*dto.OutputVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Patch(ctx, id, expectedVersion, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Patch), ctx, id, expectedVersion, input)
+}
+
+// PriceStats mocks base method.
+func (m *MockVehicleUseCaseInterface) PriceStats(ctx context.Context, input dto.InputPriceStatsDTO) (*dto.OutputListPriceStatsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceStats", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputListPriceStatsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceStats indicates an expected call of PriceStats.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) PriceStats(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceStats", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).PriceStats), ctx, input)
+}
+
+// Prices mocks base method.
+func (m *MockVehicleUseCaseInterface) Prices(ctx context.Context, id string) (*dto.OutputVehiclePricesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prices", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputVehiclePricesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prices indicates an expected call of Prices.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Prices(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prices", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Prices), ctx, id)
+}
+
+// Release mocks base method.
+func (m *MockVehicleUseCaseInterface) Release(ctx context.Context, id string) (*dto.OutputVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Release(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Release), ctx, id)
+}
+
+// Reserve mocks base method.
+func (m *MockVehicleUseCaseInterface) Reserve(ctx context.Context, id string) (*dto.OutputVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Reserve(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Reserve), ctx, id)
+}
+
+// Sell mocks base method.
+func (m *MockVehicleUseCaseInterface) Sell(ctx context.Context, id string) (*dto.OutputVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sell", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sell indicates an expected call of Sell.
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Sell(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sell", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Sell), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockVehicleUseCaseInterface) Update(ctx context.Context, id string, expectedVersion int, input dto.InputUpdateVehicleDTO) (*dto.OutputVehicleDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, expectedVersion, input)
+	ret0, _ := ret[0].(*dto.OutputVehicleDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Update indicates an expected call of Update.
-func (mr *MockVehicleUseCaseInterfaceMockRecorder) Update(ctx, id, input any) *gomock.Call {
+func (mr *MockVehicleUseCaseInterfaceMockRecorder) Update(ctx, id, expectedVersion, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Update), ctx, id, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleUseCaseInterface)(nil).Update), ctx, id, expectedVersion, input)
 }
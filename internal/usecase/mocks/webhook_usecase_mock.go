@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_usecase.go -destination=./mocks/webhook_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookUseCaseInterface is a mock of WebhookUseCaseInterface interface.
+type MockWebhookUseCaseInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUseCaseInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookUseCaseInterfaceMockRecorder is the mock recorder for MockWebhookUseCaseInterface.
+type MockWebhookUseCaseInterfaceMockRecorder struct {
+	mock *MockWebhookUseCaseInterface
+}
+
+// NewMockWebhookUseCaseInterface creates a new mock instance.
+func NewMockWebhookUseCaseInterface(ctrl *gomock.Controller) *MockWebhookUseCaseInterface {
+	mock := &MockWebhookUseCaseInterface{ctrl: ctrl}
+	mock.recorder = &MockWebhookUseCaseInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUseCaseInterface) EXPECT() *MockWebhookUseCaseInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookUseCaseInterface) Create(ctx context.Context, input dto.InputCreateWebhookDTO) (*dto.OutputCreateWebhookDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*dto.OutputCreateWebhookDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookUseCaseInterfaceMockRecorder) Create(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookUseCaseInterface)(nil).Create), ctx, input)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookUseCaseInterface) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookUseCaseInterfaceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookUseCaseInterface)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookUseCaseInterface) GetByID(ctx context.Context, id string) (*dto.OutputWebhookDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*dto.OutputWebhookDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookUseCaseInterfaceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookUseCaseInterface)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockWebhookUseCaseInterface) List(ctx context.Context) (*dto.OutputListWebhooksDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].(*dto.OutputListWebhooksDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookUseCaseInterfaceMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookUseCaseInterface)(nil).List), ctx)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookUseCaseInterface) ListDeliveries(ctx context.Context, id string, input dto.InputListWebhookDeliveriesDTO) (*dto.OutputListWebhookDeliveriesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, id, input)
+	ret0, _ := ret[0].(*dto.OutputListWebhookDeliveriesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookUseCaseInterfaceMockRecorder) ListDeliveries(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookUseCaseInterface)(nil).ListDeliveries), ctx, id, input)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookUseCaseInterface) Redeliver(ctx context.Context, id, deliveryID string) (*dto.OutputWebhookDeliveryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, id, deliveryID)
+	ret0, _ := ret[0].(*dto.OutputWebhookDeliveryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookUseCaseInterfaceMockRecorder) Redeliver(ctx, id, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookUseCaseInterface)(nil).Redeliver), ctx, id, deliveryID)
+}
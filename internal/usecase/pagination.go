@@ -0,0 +1,21 @@
+package usecase
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// normalizePage applies the default page size, caps it to the maximum and
+// clamps negative offsets.
+func normalizePage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,483 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/imaging"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=vehicle_image_usecase.go -destination=./mocks/vehicle_image_usecase_mock.go -package=mocks
+type VehicleImageUseCaseInterface interface {
+	VehicleImageLister
+
+	Upload(ctx context.Context, vehicleID string, content io.Reader) (*dto.OutputVehicleImageDTO, error)
+	List(ctx context.Context, vehicleID string) (*dto.OutputListVehicleImagesDTO, error)
+	Reorder(ctx context.Context, vehicleID string, input dto.InputReorderVehicleImagesDTO) (*dto.OutputListVehicleImagesDTO, error)
+	SetCover(ctx context.Context, vehicleID, imageID string) (*dto.OutputListVehicleImagesDTO, error)
+	Delete(ctx context.Context, vehicleID, imageID string) error
+	// MaxSize is the size of the largest image Upload accepts, in bytes.
+	MaxSize() int64
+}
+
+// VehicleImageLister supplies the images included in the vehicle snapshots.
+type VehicleImageLister interface {
+	// Images returns the images of a vehicle in display order.
+	Images(ctx context.Context, vehicleID string) ([]dto.OutputVehicleImageDTO, error)
+}
+
+// ListingImages returns images as showcase listing pictures. The list is never
+// nil, so an update of a vehicle without images clears them.
+func ListingImages(images []dto.OutputVehicleImageDTO) []dto.ListingImageDTO {
+	pictures := make([]dto.ListingImageDTO, 0, len(images))
+	for _, image := range images {
+		pictures = append(pictures, dto.ListingImageDTO{
+			URL:          image.URL,
+			ThumbnailURL: image.ThumbnailURL,
+			Cover:        image.Cover,
+		})
+	}
+	return pictures
+}
+
+const (
+	defaultMaxImageSize   = 10 << 20
+	defaultMaxImages      = 20
+	defaultThumbnailWidth = 320
+	defaultMaxImagePixels = 40_000_000
+)
+
+// imageExtensions maps the accepted content types, as sniffed from the
+// uploaded bytes, to the extension of the stored blob.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type VehicleImageConfig struct {
+	MaxSize        int64
+	MaxImages      int
+	ThumbnailWidth int
+	// MaxPixels is the largest width times height accepted, checked before
+	// the image is decoded so a small file cannot expand into a huge bitmap.
+	MaxPixels int
+}
+
+type vehicleImageUseCase struct {
+	vehicles   repository.VehicleRepository
+	repo       repository.VehicleImageRepository
+	blobs      BlobStore
+	transactor repository.Transactor
+	publisher  EventPublisher
+	config     VehicleImageConfig
+}
+
+// NewVehicleImageUseCase manages the images of the vehicles. Every change is
+// published as a vehicle.updated event carrying the new images, so the
+// showcase listing follows them.
+func NewVehicleImageUseCase(vehicles repository.VehicleRepository, repo repository.VehicleImageRepository, blobs BlobStore, transactor repository.Transactor, publisher EventPublisher, config VehicleImageConfig) VehicleImageUseCaseInterface {
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultMaxImageSize
+	}
+	if config.MaxImages <= 0 {
+		config.MaxImages = defaultMaxImages
+	}
+	if config.ThumbnailWidth <= 0 {
+		config.ThumbnailWidth = defaultThumbnailWidth
+	}
+	if config.MaxPixels <= 0 {
+		config.MaxPixels = defaultMaxImagePixels
+	}
+
+	return &vehicleImageUseCase{
+		vehicles:   vehicles,
+		repo:       repo,
+		blobs:      blobs,
+		transactor: transactor,
+		publisher:  publisher,
+		config:     config,
+	}
+}
+
+func (iuc *vehicleImageUseCase) MaxSize() int64 {
+	return iuc.config.MaxSize
+}
+
+// Upload is the handler for the POST /vehicles/{id}/images endpoint.
+// @Summary      Upload a vehicle image
+// @Description  Adds a JPEG or PNG picture to the end of the images of a vehicle and generates its thumbnail. The first image of a vehicle becomes its cover.
+// @Tags         Vehicle Images
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id     path      string  true  "Vehicle ID"
+// @Param        image  formData  file    true  "JPEG or PNG image"
+// @Success      201    {object}  dto.OutputVehicleImageDTO
+// @Failure      400    {object}  dto.ProblemDetailsDTO "Invalid ID, missing file, unsupported image, image over the size or pixel limit, or too many images"
+// @Failure      404    {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      413    {object}  dto.ProblemDetailsDTO "Request body too large"
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/images [post]
+func (iuc *vehicleImageUseCase) Upload(ctx context.Context, vehicleID string, content io.Reader) (*dto.OutputVehicleImageDTO, error) {
+	data, err := io.ReadAll(io.LimitReader(content, iuc.config.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > iuc.config.MaxSize {
+		return nil, imageError(domain.CodeTooLong, fmt.Sprintf("image must have at most %d bytes", iuc.config.MaxSize))
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, imageError(domain.CodeInvalid, "image must be a JPEG or PNG file")
+	}
+
+	thumbnail, width, height, err := imaging.Thumbnail(data, iuc.config.ThumbnailWidth, iuc.config.MaxPixels)
+	if errors.Is(err, imaging.ErrUnsupportedImage) {
+		return nil, imageError(domain.CodeInvalid, "image could not be decoded")
+	}
+	if errors.Is(err, imaging.ErrImageTooLarge) {
+		return nil, imageError(domain.CodeOutOfRange, fmt.Sprintf("image must have at most %d pixels", iuc.config.MaxPixels))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	image := &domain.VehicleImage{
+		ID:           id,
+		VehicleID:    vehicleID,
+		Key:          fmt.Sprintf("vehicles/%s/%s%s", vehicleID, id, extension),
+		ThumbnailKey: fmt.Sprintf("vehicles/%s/%s_thumb.jpg", vehicleID, id),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        width,
+		Height:       height,
+		CreatedAt:    time.Now(),
+	}
+
+	// The files are written before the vehicle is locked, so slow storage
+	// does not hold the lock, and removed again if the image is not stored.
+	err = iuc.blobs.Put(ctx, image.Key, contentType, bytes.NewReader(data))
+	if err == nil {
+		err = iuc.blobs.Put(ctx, image.ThumbnailKey, "image/jpeg", bytes.NewReader(thumbnail))
+	}
+	if err == nil {
+		err = iuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			vehicle, err := iuc.vehicles.GetByIDForUpdate(ctx, vehicleID)
+			if err != nil {
+				return err
+			}
+
+			images, err := iuc.repo.ListByVehicle(ctx, vehicleID)
+			if err != nil {
+				return err
+			}
+			if len(images) >= iuc.config.MaxImages {
+				return imageError(domain.CodeOutOfRange, fmt.Sprintf("a vehicle can have at most %d images", iuc.config.MaxImages))
+			}
+			image.Position = len(images)
+			image.Cover = len(images) == 0
+
+			err = iuc.repo.Add(ctx, image)
+			if err != nil {
+				return err
+			}
+
+			return iuc.publish(ctx, vehicle, append(images, image))
+		})
+	}
+	if err != nil {
+		iuc.deleteBlobs(ctx, image)
+		return nil, err
+	}
+
+	output := iuc.toOutputVehicleImageDTO(image)
+	return &output, nil
+}
+
+// List is the handler for the GET /vehicles/{id}/images endpoint.
+// @Summary      List vehicle images
+// @Description  Returns the images of a vehicle in display order, with the URLs of each image and its thumbnail.
+// @Tags         Vehicle Images
+// @Produce      json
+// @Param        id   path      string  true  "Vehicle ID"
+// @Success      200  {object}  dto.OutputListVehicleImagesDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/images [get]
+func (iuc *vehicleImageUseCase) List(ctx context.Context, vehicleID string) (*dto.OutputListVehicleImagesDTO, error) {
+	_, err := iuc.vehicles.GetByID(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := iuc.Images(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OutputListVehicleImagesDTO{Items: items}, nil
+}
+
+func (iuc *vehicleImageUseCase) Images(ctx context.Context, vehicleID string) ([]dto.OutputVehicleImageDTO, error) {
+	images, err := iuc.repo.ListByVehicle(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return iuc.toOutputVehicleImageDTOs(images), nil
+}
+
+// Reorder is the handler for the PUT /vehicles/{id}/images/order endpoint.
+// @Summary      Reorder vehicle images
+// @Description  Sets the display order of the images of a vehicle. image_ids must list every image of the vehicle exactly once.
+// @Tags         Vehicle Images
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                            true  "Vehicle ID"
+// @Param        order  body      dto.InputReorderVehicleImagesDTO  true  "Image IDs in the new order"
+// @Success      200    {object}  dto.OutputListVehicleImagesDTO
+// @Failure      400    {object}  dto.ProblemDetailsDTO "Invalid request body or ID, or image IDs not matching the images of the vehicle"
+// @Failure      404    {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/images/order [put]
+func (iuc *vehicleImageUseCase) Reorder(ctx context.Context, vehicleID string, input dto.InputReorderVehicleImagesDTO) (*dto.OutputListVehicleImagesDTO, error) {
+	var images []*domain.VehicleImage
+	err := iuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		vehicle, err := iuc.vehicles.GetByIDForUpdate(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		current, err := iuc.repo.ListByVehicle(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[string]*domain.VehicleImage, len(current))
+		for _, image := range current {
+			byID[image.ID] = image
+		}
+		if len(input.ImageIDs) != len(current) {
+			return reorderError()
+		}
+
+		images = make([]*domain.VehicleImage, 0, len(current))
+		for position, id := range input.ImageIDs {
+			image, ok := byID[id]
+			if !ok {
+				return reorderError()
+			}
+			delete(byID, id)
+
+			images = append(images, image)
+			if image.Position == position {
+				continue
+			}
+			image.Position = position
+			err = iuc.repo.Update(ctx, image)
+			if err != nil {
+				return err
+			}
+		}
+
+		return iuc.publish(ctx, vehicle, images)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OutputListVehicleImagesDTO{Items: iuc.toOutputVehicleImageDTOs(images)}, nil
+}
+
+// SetCover is the handler for the POST /vehicles/{id}/images/{imageId}/cover endpoint.
+// @Summary      Set the vehicle cover image
+// @Description  Makes an image the cover of its vehicle, in place of the current one. The display order is not changed.
+// @Tags         Vehicle Images
+// @Produce      json
+// @Param        id       path      string  true  "Vehicle ID"
+// @Param        imageId  path      string  true  "Image ID"
+// @Success      200      {object}  dto.OutputListVehicleImagesDTO
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404      {object}  dto.ProblemDetailsDTO "Vehicle or image not found"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/images/{imageId}/cover [post]
+func (iuc *vehicleImageUseCase) SetCover(ctx context.Context, vehicleID, imageID string) (*dto.OutputListVehicleImagesDTO, error) {
+	var images []*domain.VehicleImage
+	err := iuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		vehicle, err := iuc.vehicles.GetByIDForUpdate(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		images, err = iuc.repo.ListByVehicle(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+		if findImage(images, imageID) == nil {
+			return fmt.Errorf("image %s: %w", imageID, domain.ErrNotFound)
+		}
+
+		for _, image := range images {
+			cover := image.ID == imageID
+			if image.Cover == cover {
+				continue
+			}
+			image.Cover = cover
+			err = iuc.repo.Update(ctx, image)
+			if err != nil {
+				return err
+			}
+		}
+
+		return iuc.publish(ctx, vehicle, images)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OutputListVehicleImagesDTO{Items: iuc.toOutputVehicleImageDTOs(images)}, nil
+}
+
+// Delete is the handler for the DELETE /vehicles/{id}/images/{imageId} endpoint.
+// @Summary      Delete a vehicle image
+// @Description  Removes an image and its thumbnail. The images after it move up one position, and when the cover is removed the first remaining image takes its place.
+// @Tags         Vehicle Images
+// @Param        id       path      string  true  "Vehicle ID"
+// @Param        imageId  path      string  true  "Image ID"
+// @Success      204      {string}  string "No Content"
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404      {object}  dto.ProblemDetailsDTO "Vehicle or image not found"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/images/{imageId} [delete]
+func (iuc *vehicleImageUseCase) Delete(ctx context.Context, vehicleID, imageID string) error {
+	var removed *domain.VehicleImage
+	err := iuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		vehicle, err := iuc.vehicles.GetByIDForUpdate(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+
+		images, err := iuc.repo.ListByVehicle(ctx, vehicleID)
+		if err != nil {
+			return err
+		}
+		removed = findImage(images, imageID)
+		if removed == nil {
+			return fmt.Errorf("image %s: %w", imageID, domain.ErrNotFound)
+		}
+
+		err = iuc.repo.Delete(ctx, vehicleID, imageID)
+		if err != nil {
+			return err
+		}
+
+		remaining := make([]*domain.VehicleImage, 0, len(images)-1)
+		for _, image := range images {
+			if image.ID != imageID {
+				remaining = append(remaining, image)
+			}
+		}
+		for position, image := range remaining {
+			cover := image.Cover || (removed.Cover && position == 0)
+			if image.Position == position && image.Cover == cover {
+				continue
+			}
+			image.Position, image.Cover = position, cover
+			err = iuc.repo.Update(ctx, image)
+			if err != nil {
+				return err
+			}
+		}
+
+		return iuc.publish(ctx, vehicle, remaining)
+	})
+	if err != nil {
+		return err
+	}
+
+	iuc.deleteBlobs(ctx, removed)
+	return nil
+}
+
+// publish sends the vehicle.updated event of an image change, with the
+// snapshot of the vehicle carrying images.
+func (iuc *vehicleImageUseCase) publish(ctx context.Context, vehicle *domain.Vehicle, images []*domain.VehicleImage) error {
+	snapshot := toOutputVehicleDTO(vehicle)
+	snapshot.Images = iuc.toOutputVehicleImageDTOs(images)
+
+	event, err := newVehicleEvent(domain.EventVehicleUpdated, snapshot)
+	if err != nil {
+		return err
+	}
+
+	return iuc.publisher.Publish(ctx, event)
+}
+
+// deleteBlobs removes the files of image, even when ctx is already canceled.
+// Failures only leave unreferenced files behind, so they are logged rather
+// than returned.
+func (iuc *vehicleImageUseCase) deleteBlobs(ctx context.Context, image *domain.VehicleImage) {
+	ctx = context.WithoutCancel(ctx)
+	for _, key := range []string{image.Key, image.ThumbnailKey} {
+		if err := iuc.blobs.Delete(ctx, key); err != nil {
+			log.Printf("Warning: could not delete blob %s of image %s: %v", key, image.ID, err)
+		}
+	}
+}
+
+func (iuc *vehicleImageUseCase) toOutputVehicleImageDTOs(images []*domain.VehicleImage) []dto.OutputVehicleImageDTO {
+	outputs := make([]dto.OutputVehicleImageDTO, 0, len(images))
+	for _, image := range images {
+		outputs = append(outputs, iuc.toOutputVehicleImageDTO(image))
+	}
+	return outputs
+}
+
+func (iuc *vehicleImageUseCase) toOutputVehicleImageDTO(image *domain.VehicleImage) dto.OutputVehicleImageDTO {
+	return dto.OutputVehicleImageDTO{
+		ID:           image.ID,
+		URL:          iuc.blobs.URL(image.Key),
+		ThumbnailURL: iuc.blobs.URL(image.ThumbnailKey),
+		ContentType:  image.ContentType,
+		Size:         image.Size,
+		Width:        image.Width,
+		Height:       image.Height,
+		Position:     image.Position,
+		Cover:        image.Cover,
+		CreatedAt:    image.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func findImage(images []*domain.VehicleImage, id string) *domain.VehicleImage {
+	for _, image := range images {
+		if image.ID == id {
+			return image
+		}
+	}
+	return nil
+}
+
+func imageError(code, message string) error {
+	return domain.ValidationErrors{{Field: "image", Code: code, Message: message}}
+}
+
+func reorderError() error {
+	return domain.ValidationErrors{{
+		Field:   "image_ids",
+		Code:    domain.CodeInvalid,
+		Message: "image_ids must list every image of the vehicle exactly once",
+	}}
+}
@@ -0,0 +1,361 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	musecase "github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type VehicleImageUseCaseSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	vehicles   *mocks.MockVehicleRepository
+	repository *mocks.MockVehicleImageRepository
+	blobs      *musecase.MockBlobStore
+	transactor *mocks.MockTransactor
+	publisher  *musecase.MockEventPublisher
+	useCase    usecase.VehicleImageUseCaseInterface
+}
+
+func (suite *VehicleImageUseCaseSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.vehicles = mocks.NewMockVehicleRepository(ctrl)
+	suite.repository = mocks.NewMockVehicleImageRepository(ctrl)
+	suite.blobs = musecase.NewMockBlobStore(ctrl)
+	suite.transactor = mocks.NewMockTransactor(ctrl)
+	suite.publisher = musecase.NewMockEventPublisher(ctrl)
+	suite.useCase = usecase.NewVehicleImageUseCase(suite.vehicles, suite.repository, suite.blobs, suite.transactor, suite.publisher, usecase.VehicleImageConfig{
+		MaxSize:        64 << 10,
+		MaxImages:      3,
+		ThumbnailWidth: 32,
+		MaxPixels:      64 * 64,
+	})
+
+	suite.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	suite.blobs.EXPECT().
+		URL(gomock.Any()).
+		DoAndReturn(func(key string) string {
+			return "http://localhost:8080/media/" + key
+		}).
+		AnyTimes()
+}
+
+func Test_VehicleImageUseCaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(VehicleImageUseCaseSuite))
+}
+
+func pngImage(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+// storedImages returns a vehicle's images as listed by the repository, the
+// first one being the cover.
+func storedImages(ids ...string) []*domain.VehicleImage {
+	images := make([]*domain.VehicleImage, 0, len(ids))
+	for position, id := range ids {
+		images = append(images, &domain.VehicleImage{
+			ID:           id,
+			VehicleID:    "vehicle-1",
+			Key:          "vehicles/vehicle-1/" + id + ".jpg",
+			ThumbnailKey: "vehicles/vehicle-1/" + id + "_thumb.jpg",
+			ContentType:  "image/jpeg",
+			Position:     position,
+			Cover:        position == 0,
+		})
+	}
+	return images
+}
+
+// imageIDs returns the IDs of the images in a vehicle event snapshot.
+func imageIDs(v dto.OutputVehicleDTO) []string {
+	ids := make([]string, 0, len(v.Images))
+	for _, image := range v.Images {
+		ids = append(ids, image.ID)
+	}
+	return ids
+}
+
+func (suite *VehicleImageUseCaseSuite) Test_Upload() {
+	vehicle := &domain.Vehicle{ID: "vehicle-1", Brand: "Toyota", Model: "Corolla", Price: brl(100000)}
+
+	suite.T().Run("should store the image and its thumbnail and publish the vehicle", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages(), nil)
+		suite.blobs.EXPECT().Put(suite.ctx, gomock.Any(), "image/png", gomock.Any()).Return(nil)
+		suite.blobs.EXPECT().Put(suite.ctx, gomock.Any(), "image/jpeg", gomock.Any()).Return(nil)
+		suite.repository.EXPECT().
+			Add(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool {
+				return i.VehicleID == "vehicle-1" && i.Position == 0 && i.Cover &&
+					i.Width == 64 && i.Height == 48 && i.ContentType == "image/png" &&
+					i.Key == "vehicles/vehicle-1/"+i.ID+".png" && i.ThumbnailKey == "vehicles/vehicle-1/"+i.ID+"_thumb.jpg"
+			})).
+			Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleUpdated, func(v dto.OutputVehicleDTO) bool {
+				return len(v.Images) == 1 && v.Images[0].Cover
+			})).
+			Return(nil)
+
+		output, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader(pngImage(64, 48)))
+		suite.NoError(err)
+		suite.True(output.Cover)
+		suite.Equal("http://localhost:8080/media/vehicles/vehicle-1/"+output.ID+".png", output.URL)
+		suite.Equal("http://localhost:8080/media/vehicles/vehicle-1/"+output.ID+"_thumb.jpg", output.ThumbnailURL)
+	})
+
+	suite.T().Run("should reject an image over the size limit", func(t *testing.T) {
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader(make([]byte, 64<<10+1)))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal("image", validationErrs[0].Field)
+		suite.Equal(domain.CodeTooLong, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject an image over the pixel limit", func(t *testing.T) {
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader(pngImage(100, 100)))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal("image", validationErrs[0].Field)
+		suite.Equal(domain.CodeOutOfRange, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject a file that is not a JPEG or PNG", func(t *testing.T) {
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader([]byte("GIF89a not really an image")))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal(domain.CodeInvalid, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject a corrupt PNG", func(t *testing.T) {
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader(pngImage(64, 48)[:40]))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal(domain.CodeInvalid, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject an image beyond the maximum per vehicle", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b", "c"), nil)
+		suite.blobs.EXPECT().Put(suite.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		suite.blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader(pngImage(64, 48)))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal(domain.CodeOutOfRange, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should return ErrNotFound for an unknown vehicle", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-9").Return(nil, domain.ErrNotFound)
+		suite.blobs.EXPECT().Put(suite.ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		suite.blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-9", bytes.NewReader(pngImage(64, 48)))
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	suite.T().Run("should delete the stored files when the image cannot be saved", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a"), nil)
+		suite.blobs.EXPECT().Put(suite.ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, r io.Reader) error {
+				_, err := io.Copy(io.Discard, r)
+				return err
+			}).
+			Times(2)
+		suite.repository.EXPECT().Add(suite.ctx, gomock.Any()).Return(assert.AnError)
+		suite.blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := suite.useCase.Upload(suite.ctx, "vehicle-1", bytes.NewReader(pngImage(64, 48)))
+		suite.ErrorIs(err, assert.AnError)
+	})
+
+	suite.T().Run("should not lock the vehicle when the files cannot be stored", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(suite.ctx)
+		cancel()
+		suite.blobs.EXPECT().Put(ctx, gomock.Any(), "image/png", gomock.Any()).Return(context.Canceled)
+		suite.blobs.EXPECT().
+			Delete(gomock.Cond(func(ctx context.Context) bool { return ctx.Err() == nil }), gomock.Any()).
+			Return(nil).
+			Times(2)
+
+		_, err := suite.useCase.Upload(ctx, "vehicle-1", bytes.NewReader(pngImage(64, 48)))
+		suite.ErrorIs(err, context.Canceled)
+	})
+}
+
+func (suite *VehicleImageUseCaseSuite) Test_List() {
+	suite.T().Run("should return the images with their URLs", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByID(suite.ctx, "vehicle-1").Return(&domain.Vehicle{ID: "vehicle-1"}, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b"), nil)
+
+		output, err := suite.useCase.List(suite.ctx, "vehicle-1")
+		suite.NoError(err)
+		suite.Len(output.Items, 2)
+		suite.Equal("http://localhost:8080/media/vehicles/vehicle-1/a.jpg", output.Items[0].URL)
+		suite.Equal("http://localhost:8080/media/vehicles/vehicle-1/b_thumb.jpg", output.Items[1].ThumbnailURL)
+	})
+
+	suite.T().Run("should return ErrNotFound for an unknown vehicle", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByID(suite.ctx, "vehicle-9").Return(nil, domain.ErrNotFound)
+
+		_, err := suite.useCase.List(suite.ctx, "vehicle-9")
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+func (suite *VehicleImageUseCaseSuite) Test_Reorder() {
+	vehicle := &domain.Vehicle{ID: "vehicle-1", Price: brl(100000)}
+
+	suite.T().Run("should update only the images that moved", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b", "c"), nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool { return i.ID == "c" && i.Position == 1 })).
+			Return(nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool { return i.ID == "b" && i.Position == 2 })).
+			Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleUpdated, func(v dto.OutputVehicleDTO) bool {
+				return assert.ObjectsAreEqual([]string{"a", "c", "b"}, imageIDs(v))
+			})).
+			Return(nil)
+
+		output, err := suite.useCase.Reorder(suite.ctx, "vehicle-1", dto.InputReorderVehicleImagesDTO{ImageIDs: []string{"a", "c", "b"}})
+		suite.NoError(err)
+		suite.Equal("c", output.Items[1].ID)
+		suite.True(output.Items[0].Cover)
+	})
+
+	invalidOrders := map[string][]string{
+		"missing image":   {"a", "b"},
+		"unknown image":   {"a", "b", "x"},
+		"duplicate image": {"a", "b", "b"},
+	}
+	for name, ids := range invalidOrders {
+		suite.T().Run("should reject an order with a "+name, func(t *testing.T) {
+			suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+			suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b", "c"), nil)
+
+			_, err := suite.useCase.Reorder(suite.ctx, "vehicle-1", dto.InputReorderVehicleImagesDTO{ImageIDs: ids})
+
+			var validationErrs domain.ValidationErrors
+			suite.True(errors.As(err, &validationErrs))
+			suite.Equal("image_ids", validationErrs[0].Field)
+		})
+	}
+}
+
+func (suite *VehicleImageUseCaseSuite) Test_SetCover() {
+	vehicle := &domain.Vehicle{ID: "vehicle-1", Price: brl(100000)}
+
+	suite.T().Run("should move the cover to the image", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b"), nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool { return i.ID == "a" && !i.Cover })).
+			Return(nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool { return i.ID == "b" && i.Cover })).
+			Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		output, err := suite.useCase.SetCover(suite.ctx, "vehicle-1", "b")
+		suite.NoError(err)
+		suite.False(output.Items[0].Cover)
+		suite.True(output.Items[1].Cover)
+	})
+
+	suite.T().Run("should return ErrNotFound for an image of another vehicle", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b"), nil)
+
+		_, err := suite.useCase.SetCover(suite.ctx, "vehicle-1", "x")
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+func (suite *VehicleImageUseCaseSuite) Test_Delete() {
+	vehicle := &domain.Vehicle{ID: "vehicle-1", Price: brl(100000)}
+
+	suite.T().Run("should compact the positions and hand the cover to the first image", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b", "c"), nil)
+		suite.repository.EXPECT().Delete(suite.ctx, "vehicle-1", "a").Return(nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool { return i.ID == "b" && i.Position == 0 && i.Cover })).
+			Return(nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(i *domain.VehicleImage) bool { return i.ID == "c" && i.Position == 1 && !i.Cover })).
+			Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleUpdated, func(v dto.OutputVehicleDTO) bool {
+				return assert.ObjectsAreEqual([]string{"b", "c"}, imageIDs(v)) && v.Images[0].Cover
+			})).
+			Return(nil)
+		suite.blobs.EXPECT().Delete(gomock.Any(), "vehicles/vehicle-1/a.jpg").Return(nil)
+		suite.blobs.EXPECT().Delete(gomock.Any(), "vehicles/vehicle-1/a_thumb.jpg").Return(nil)
+
+		err := suite.useCase.Delete(suite.ctx, "vehicle-1", "a")
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should keep the files when the deletion fails", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a", "b"), nil)
+		suite.repository.EXPECT().Delete(suite.ctx, "vehicle-1", "b").Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		err := suite.useCase.Delete(suite.ctx, "vehicle-1", "b")
+		suite.ErrorIs(err, assert.AnError)
+	})
+
+	suite.T().Run("should return ErrNotFound for an unknown image", func(t *testing.T) {
+		suite.vehicles.EXPECT().GetByIDForUpdate(suite.ctx, "vehicle-1").Return(vehicle, nil)
+		suite.repository.EXPECT().ListByVehicle(suite.ctx, "vehicle-1").Return(storedImages("a"), nil)
+
+		err := suite.useCase.Delete(suite.ctx, "vehicle-1", "x")
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+func (suite *VehicleImageUseCaseSuite) Test_MaxSize() {
+	suite.T().Run("should return the configured size", func(t *testing.T) {
+		suite.Equal(int64(64<<10), suite.useCase.MaxSize())
+	})
+
+	suite.T().Run("should default to 10 MiB", func(t *testing.T) {
+		useCase := usecase.NewVehicleImageUseCase(suite.vehicles, suite.repository, suite.blobs, suite.transactor, suite.publisher, usecase.VehicleImageConfig{})
+		suite.Equal(int64(10<<20), useCase.MaxSize())
+	})
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+)
+
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+)
+
+// importRow is a row read from an import file. err holds the problems found
+// while reading it, in which case input is incomplete and the row is not
+// imported.
+type importRow struct {
+	line  int
+	input dto.InputCreateVehicleDTO
+	err   error
+}
+
+// csvColumns sets the field of the input named by each accepted CSV column,
+// which are the JSON names of dto.InputCreateVehicleDTO. Empty cells leave the
+// field unset.
+var csvColumns = map[string]func(input *dto.InputCreateVehicleDTO, value string) error{
+	"brand":               stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Brand }),
+	"model":               stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Model }),
+	"year":                intColumn(func(input *dto.InputCreateVehicleDTO) *int { return &input.Year }),
+	"color":               stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Color }),
+	"price":               priceColumn,
+	"currency":            stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Currency }),
+	"vin":                 stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.VIN }),
+	"plate":               stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Plate }),
+	"renavam":             stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Renavam }),
+	"mileage":             intColumn(func(input *dto.InputCreateVehicleDTO) *int { return &input.Mileage }),
+	"fuel_type":           stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.FuelType }),
+	"transmission":        stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Transmission }),
+	"body_type":           stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.BodyType }),
+	"doors":               intColumn(func(input *dto.InputCreateVehicleDTO) *int { return &input.Doors }),
+	"engine_displacement": intColumn(func(input *dto.InputCreateVehicleDTO) *int { return &input.EngineDisplacement }),
+	"condition":           stringColumn(func(input *dto.InputCreateVehicleDTO) *string { return &input.Condition }),
+	"override_vin":        overrideVINColumn,
+}
+
+// parseImport reads the rows of an import file. Problems with a single row
+// are kept in the row; an error is returned only when the file as a whole
+// cannot be read.
+func parseImport(format string, data []byte) ([]importRow, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseCSV(data)
+	case ImportFormatJSONL:
+		return parseJSONL(data)
+	default:
+		return nil, fileError(domain.CodeInvalid, fmt.Sprintf("format must be %s or %s", ImportFormatCSV, ImportFormatJSONL))
+	}
+}
+
+func parseCSV(data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fileError(domain.CodeInvalid, fmt.Sprintf("invalid CSV header: %v", err))
+	}
+
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := csvColumns[name]; !ok {
+			return nil, fileError(domain.CodeInvalid, fmt.Sprintf("unknown CSV column %q", name))
+		}
+		if seen[name] {
+			return nil, fileError(domain.CodeInvalid, fmt.Sprintf("duplicate CSV column %q", name))
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fileError(domain.CodeInvalid, fmt.Sprintf("invalid CSV: %v", err))
+		}
+		line, _ := reader.FieldPos(0)
+		if blankRecord(record) {
+			continue
+		}
+
+		row := importRow{line: line}
+		if len(record) != len(columns) {
+			row.err = fmt.Errorf("row has %d fields, the header has %d", len(record), len(columns))
+			rows = append(rows, row)
+			continue
+		}
+
+		var fieldErrs domain.ValidationErrors
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			err := csvColumns[columns[i]](&row.input, value)
+			if err != nil {
+				fieldErrs = append(fieldErrs, domain.FieldError{Field: columns[i], Code: domain.CodeInvalid, Message: err.Error()})
+			}
+		}
+		if len(fieldErrs) > 0 {
+			row.err = fieldErrs
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseJSONL(data []byte) ([]importRow, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := importRow{line: line}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&row.input)
+		if err == nil && decoder.More() {
+			err = errors.New("unexpected data after the object")
+		}
+		if err != nil {
+			row.err = jsonRowError(err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fileError(domain.CodeInvalid, fmt.Sprintf("invalid JSONL: %v", err))
+	}
+
+	return rows, nil
+}
+
+// jsonRowError describes why a JSONL row could not be decoded, naming the
+// field when the problem is confined to one.
+func jsonRowError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return domain.ValidationErrors{{
+			Field:   typeErr.Field,
+			Code:    domain.CodeInvalid,
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, typeErr.Type),
+		}}
+	case errors.Is(err, domain.ErrInvalidMoney):
+		return domain.ValidationErrors{{Field: "price", Code: domain.CodeInvalid, Message: "price must be a decimal number"}}
+	default:
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+}
+
+func stringColumn(field func(input *dto.InputCreateVehicleDTO) *string) func(*dto.InputCreateVehicleDTO, string) error {
+	return func(input *dto.InputCreateVehicleDTO, value string) error {
+		*field(input) = value
+		return nil
+	}
+}
+
+func intColumn(field func(input *dto.InputCreateVehicleDTO) *int) func(*dto.InputCreateVehicleDTO, string) error {
+	return func(input *dto.InputCreateVehicleDTO, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		*field(input) = parsed
+		return nil
+	}
+}
+
+func priceColumn(input *dto.InputCreateVehicleDTO, value string) error {
+	price, err := domain.ParseMoney(value, "")
+	if err != nil {
+		return fmt.Errorf("%q is not a decimal number", value)
+	}
+	input.Price = price
+	return nil
+}
+
+func overrideVINColumn(input *dto.InputCreateVehicleDTO, value string) error {
+	override, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%q is not true or false", value)
+	}
+	input.OverrideVIN = override
+	return nil
+}
+
+func blankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func fileError(code, message string) error {
+	return domain.ValidationErrors{{Field: "file", Code: code, Message: message}}
+}
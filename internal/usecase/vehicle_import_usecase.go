@@ -0,0 +1,436 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=vehicle_import_usecase.go -destination=./mocks/vehicle_import_usecase_mock.go -package=mocks
+type VehicleImportUseCaseInterface interface {
+	Import(ctx context.Context, input dto.InputImportVehiclesDTO, content io.Reader) (*dto.OutputImportVehiclesDTO, error)
+	GetJob(ctx context.Context, id string) (*dto.OutputImportJobDTO, error)
+	// FailStaleJobs marks as failed the background imports left running by a
+	// process that stopped, returning how many were marked. Running jobs store
+	// their progress after every batch, so only those idle for longer than
+	// config.StaleAfter are taken as interrupted; the jobs of other instances
+	// are left alone.
+	FailStaleJobs(ctx context.Context) (int64, error)
+	// Wait blocks until the background imports finish, returning ctx.Err() if
+	// ctx is done first.
+	Wait(ctx context.Context) error
+}
+
+// VehicleCreator creates the vehicles of an import, applying the same rules
+// as POST /vehicles/add.
+type VehicleCreator interface {
+	Create(ctx context.Context, input dto.InputCreateVehicleDTO) (*dto.OutputCreateVehicleDTO, error)
+}
+
+const (
+	defaultMaxImportSize    = 20 << 20
+	defaultMaxImportRows    = 10000
+	defaultImportBatchSize  = 100
+	defaultAsyncThreshold   = 500
+	defaultImportStaleAfter = 10 * time.Minute
+)
+
+var (
+	// errDryRun rolls back the transaction of a dry-run batch.
+	errDryRun = errors.New("dry run")
+	// errRowFailed rolls back the savepoint of a row that was not imported.
+	errRowFailed = errors.New("row failed")
+)
+
+type VehicleImportConfig struct {
+	MaxSize   int64
+	MaxRows   int
+	BatchSize int
+	// AsyncThreshold is the number of rows above which a file is imported in
+	// the background.
+	AsyncThreshold int
+	// StaleAfter is how long a running job may go without storing progress
+	// before FailStaleJobs takes it as interrupted. It must exceed the time
+	// taken by a batch.
+	StaleAfter time.Duration
+}
+
+type vehicleImportUseCase struct {
+	vehicles   VehicleCreator
+	jobs       repository.VehicleImportJobRepository
+	transactor repository.Transactor
+	config     VehicleImportConfig
+	running    sync.WaitGroup
+}
+
+// NewVehicleImportUseCase imports vehicles from CSV and JSONL files. The rows
+// are created in batches of config.BatchSize, each in its own transaction, so
+// a batch is imported entirely or not at all apart from the rows it rejects.
+func NewVehicleImportUseCase(vehicles VehicleCreator, jobs repository.VehicleImportJobRepository, transactor repository.Transactor, config VehicleImportConfig) VehicleImportUseCaseInterface {
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultMaxImportSize
+	}
+	if config.MaxRows <= 0 {
+		config.MaxRows = defaultMaxImportRows
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultImportBatchSize
+	}
+	if config.AsyncThreshold <= 0 {
+		config.AsyncThreshold = defaultAsyncThreshold
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = defaultImportStaleAfter
+	}
+
+	return &vehicleImportUseCase{
+		vehicles:   vehicles,
+		jobs:       jobs,
+		transactor: transactor,
+		config:     config,
+	}
+}
+
+// Import is the handler for the POST /vehicles/import endpoint.
+// @Summary      Import vehicles
+// @Description  Creates vehicles from a CSV file, with a header row naming the fields of POST /vehicles/add, or from a JSONL file with one such object per line. Each row is validated like a single vehicle and the report lists the outcome of every row. With dry_run the rows are only validated. Files with more rows than the async threshold, or any file with async, are imported in the background and return a job to poll.
+// @Tags         Vehicles
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        file     body      string  true   "CSV or JSONL file"
+// @Param        dry_run  query     bool    false  "Validate the rows without creating the vehicles"
+// @Param        async    query     bool    false  "Import in the background regardless of the file size"
+// @Success      200      {object}  dto.OutputImportReportDTO
+// @Success      202      {object}  dto.OutputImportJobDTO
+// @Header       202      {string}  Location  "URL of the import job"
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid query parameters, or a file that is empty, too large or unreadable"
+// @Failure      415      {object}  dto.ProblemDetailsDTO "Content-Type is not CSV or JSONL"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/import [post]
+func (iuc *vehicleImportUseCase) Import(ctx context.Context, input dto.InputImportVehiclesDTO, content io.Reader) (*dto.OutputImportVehiclesDTO, error) {
+	data, err := io.ReadAll(io.LimitReader(content, iuc.config.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > iuc.config.MaxSize {
+		return nil, fileError(domain.CodeTooLong, fmt.Sprintf("file must have at most %d bytes", iuc.config.MaxSize))
+	}
+
+	rows, err := parseImport(input.Format, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fileError(domain.CodeRequired, "file has no rows to import")
+	}
+	if len(rows) > iuc.config.MaxRows {
+		return nil, fileError(domain.CodeOutOfRange, fmt.Sprintf("file must have at most %d rows", iuc.config.MaxRows))
+	}
+
+	if !input.Async && len(rows) <= iuc.config.AsyncThreshold {
+		results, err := iuc.importRows(ctx, rows, input.DryRun, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.OutputImportVehiclesDTO{Report: toOutputImportReportDTO(input.DryRun, results)}, nil
+	}
+
+	now := time.Now()
+	job := &domain.VehicleImportJob{
+		ID:        uuid.New().String(),
+		Status:    domain.ImportRunning,
+		DryRun:    input.DryRun,
+		Total:     len(rows),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = iuc.jobs.Create(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputImportJobDTO(job)
+	iuc.running.Add(1)
+	go func() {
+		defer iuc.running.Done()
+		iuc.runJob(context.WithoutCancel(ctx), job, rows)
+	}()
+
+	return &dto.OutputImportVehiclesDTO{Job: &output}, nil
+}
+
+// GetJob is the handler for the GET /vehicles/import/{jobId} endpoint.
+// @Summary      Get an import job
+// @Description  Returns the progress of a background import, with the outcome of the rows processed so far.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        jobId  path      string  true  "Import job ID"
+// @Success      200    {object}  dto.OutputImportJobDTO
+// @Failure      400    {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404    {object}  dto.ProblemDetailsDTO "Import job not found"
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/import/{jobId} [get]
+func (iuc *vehicleImportUseCase) GetJob(ctx context.Context, id string) (*dto.OutputImportJobDTO, error) {
+	job, err := iuc.jobs.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputImportJobDTO(job)
+	return &output, nil
+}
+
+func (iuc *vehicleImportUseCase) FailStaleJobs(ctx context.Context) (int64, error) {
+	now := time.Now()
+	return iuc.jobs.FailStale(ctx, "the import was interrupted by a restart of the service", now.Add(-iuc.config.StaleAfter), now)
+}
+
+func (iuc *vehicleImportUseCase) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		iuc.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runJob imports rows in the background, storing the progress of job after
+// every batch.
+func (iuc *vehicleImportUseCase) runJob(ctx context.Context, job *domain.VehicleImportJob, rows []importRow) {
+	results, err := iuc.importRows(ctx, rows, job.DryRun, func(results []domain.ImportRowResult) {
+		setImportResults(job, results)
+		job.UpdatedAt = time.Now()
+		if err := iuc.jobs.Update(ctx, job); err != nil {
+			log.Printf("Warning: could not store the progress of import job %s: %v", job.ID, err)
+		}
+	})
+
+	setImportResults(job, results)
+	job.Status = domain.ImportCompleted
+	if err != nil {
+		log.Printf("Error: import job %s stopped: %v", job.ID, err)
+		job.Status = domain.ImportFailed
+		job.Error = "the import stopped on an internal error; the rows not listed were not imported"
+	}
+	now := time.Now()
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+
+	if err := iuc.jobs.Update(ctx, job); err != nil {
+		log.Printf("Error: could not store the outcome of import job %s: %v", job.ID, err)
+	}
+}
+
+// importRows creates the vehicles of rows batch by batch and returns the
+// outcome of each row. Rows rejected by validation or by a conflict are
+// reported and skipped; any other error rolls back the current batch and
+// stops the import, returning the results of the batches already committed.
+// Each row runs in a savepoint of the batch, so the writes of a failed row are
+// undone and a database error on it does not abort the rest of the batch.
+// progress, when set, is called after every batch.
+func (iuc *vehicleImportUseCase) importRows(ctx context.Context, rows []importRow, dryRun bool, progress func([]domain.ImportRowResult)) ([]domain.ImportRowResult, error) {
+	results := make([]domain.ImportRowResult, 0, len(rows))
+	identifiers := make(map[string]int)
+
+	for start := 0; start < len(rows); start += iuc.config.BatchSize {
+		batch := rows[start:min(start+iuc.config.BatchSize, len(rows))]
+
+		var batchResults []domain.ImportRowResult
+		var batchIdentifiers map[string]int
+		err := iuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			batchResults = make([]domain.ImportRowResult, 0, len(batch))
+			batchIdentifiers = make(map[string]int)
+			for _, row := range batch {
+				var result domain.ImportRowResult
+				err := iuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+					var err error
+					result, err = iuc.importRow(ctx, row, dryRun, identifiers, batchIdentifiers)
+					if err == nil && result.Status == domain.ImportRowFailed {
+						return errRowFailed
+					}
+					return err
+				})
+				if err != nil && !errors.Is(err, errRowFailed) {
+					return err
+				}
+				batchResults = append(batchResults, result)
+			}
+
+			if dryRun {
+				return errDryRun
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errDryRun) {
+			return results, err
+		}
+
+		results = append(results, batchResults...)
+		for key, line := range batchIdentifiers {
+			identifiers[key] = line
+		}
+		if progress != nil {
+			progress(results)
+		}
+	}
+
+	return results, nil
+}
+
+// importRow creates the vehicle of row. imported holds the identifiers of the
+// rows created in earlier batches and batch those of the current one, so a
+// file repeating a VIN, plate or RENAVAM is reported even in a dry run.
+func (iuc *vehicleImportUseCase) importRow(ctx context.Context, row importRow, dryRun bool, imported, batch map[string]int) (domain.ImportRowResult, error) {
+	result := domain.ImportRowResult{Line: row.line}
+	if row.err != nil {
+		return failedRow(result, row.err), nil
+	}
+
+	keys := identifierKeys(row.input)
+	for _, key := range keys {
+		line, ok := imported[key.key]
+		if !ok {
+			line, ok = batch[key.key]
+		}
+		if ok {
+			err := fmt.Errorf("%w: %s is already used on line %d", domain.ErrConflict, key.field, line)
+			return failedRow(result, err), nil
+		}
+	}
+
+	output, err := iuc.vehicles.Create(ctx, row.input)
+	if errors.Is(err, domain.ErrValidation) || errors.Is(err, domain.ErrConflict) {
+		return failedRow(result, err), nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	for _, key := range keys {
+		batch[key.key] = row.line
+	}
+	result.Status = domain.ImportRowCreated
+	result.VehicleID = output.ID
+	if dryRun {
+		result.Status = domain.ImportRowValid
+		result.VehicleID = ""
+	}
+	return result, nil
+}
+
+type identifierKey struct {
+	field string
+	key   string
+}
+
+// identifierKeys returns the normalized identifiers of input, which must be
+// unique among the vehicles.
+func identifierKeys(input dto.InputCreateVehicleDTO) []identifierKey {
+	var keys []identifierKey
+	if vin := utils.NormalizeVIN(input.VIN); vin != "" {
+		keys = append(keys, identifierKey{field: "vin", key: "vin:" + vin})
+	}
+	if plate := utils.NormalizePlate(input.Plate); plate != "" {
+		keys = append(keys, identifierKey{field: "plate", key: "plate:" + plate})
+	}
+	if renavam := utils.NormalizeRenavam(input.Renavam); renavam != "" {
+		keys = append(keys, identifierKey{field: "renavam", key: "renavam:" + renavam})
+	}
+	return keys
+}
+
+func failedRow(result domain.ImportRowResult, err error) domain.ImportRowResult {
+	result.Status = domain.ImportRowFailed
+	result.Error = err.Error()
+	var validationErrs domain.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		result.Errors = validationErrs
+	}
+	return result
+}
+
+func setImportResults(job *domain.VehicleImportJob, results []domain.ImportRowResult) {
+	job.Results = results
+	job.Processed = len(results)
+	job.Succeeded, job.Failed = countImportResults(results)
+}
+
+func countImportResults(results []domain.ImportRowResult) (succeeded, failed int) {
+	for _, result := range results {
+		if result.Status == domain.ImportRowFailed {
+			failed++
+		} else {
+			succeeded++
+		}
+	}
+	return succeeded, failed
+}
+
+func toOutputImportReportDTO(dryRun bool, results []domain.ImportRowResult) *dto.OutputImportReportDTO {
+	succeeded, failed := countImportResults(results)
+	return &dto.OutputImportReportDTO{
+		DryRun:    dryRun,
+		Total:     len(results),
+		Succeeded: succeeded,
+		Failed:    failed,
+		Rows:      toImportRowResultDTOs(results),
+	}
+}
+
+func toOutputImportJobDTO(job *domain.VehicleImportJob) dto.OutputImportJobDTO {
+	output := dto.OutputImportJobDTO{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		DryRun:    job.DryRun,
+		Total:     job.Total,
+		Processed: job.Processed,
+		Succeeded: job.Succeeded,
+		Failed:    job.Failed,
+		Rows:      toImportRowResultDTOs(job.Results),
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: job.UpdatedAt.Format(time.RFC3339),
+	}
+	if job.FinishedAt != nil {
+		output.FinishedAt = job.FinishedAt.Format(time.RFC3339)
+	}
+	return output
+}
+
+func toImportRowResultDTOs(results []domain.ImportRowResult) []dto.ImportRowResultDTO {
+	outputs := make([]dto.ImportRowResultDTO, 0, len(results))
+	for _, result := range results {
+		output := dto.ImportRowResultDTO{
+			Line:      result.Line,
+			Status:    string(result.Status),
+			VehicleID: result.VehicleID,
+			Error:     result.Error,
+		}
+		for _, fieldErr := range result.Errors {
+			output.Errors = append(output.Errors, dto.FieldErrorDTO{
+				Field:   fieldErr.Field,
+				Code:    fieldErr.Code,
+				Message: fieldErr.Message,
+			})
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
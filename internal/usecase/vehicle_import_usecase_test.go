@@ -0,0 +1,401 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	musecase "github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type VehicleImportUseCaseSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	vehicles   *musecase.MockVehicleCreator
+	jobs       *mocks.MockVehicleImportJobRepository
+	transactor *mocks.MockTransactor
+	useCase    usecase.VehicleImportUseCaseInterface
+}
+
+func (suite *VehicleImportUseCaseSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.vehicles = musecase.NewMockVehicleCreator(ctrl)
+	suite.jobs = mocks.NewMockVehicleImportJobRepository(ctrl)
+	suite.transactor = mocks.NewMockTransactor(ctrl)
+	suite.useCase = usecase.NewVehicleImportUseCase(suite.vehicles, suite.jobs, suite.transactor, usecase.VehicleImportConfig{
+		MaxSize:        4 << 10,
+		MaxRows:        10,
+		BatchSize:      2,
+		AsyncThreshold: 4,
+	})
+
+	suite.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+}
+
+func Test_VehicleImportUseCaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(VehicleImportUseCaseSuite))
+}
+
+// createdVehicle returns the output of a successful Create.
+func createdVehicle(id string) *dto.OutputCreateVehicleDTO {
+	return &dto.OutputCreateVehicleDTO{ID: id, CreatedAt: time.Now().Format(time.RFC3339)}
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportCSV() {
+	csvInput := dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV}
+
+	suite.T().Run("should create the valid rows and report the others", func(t *testing.T) {
+		file := "brand,model,year,color,price,plate,fuel_type\n" +
+			"Toyota,Corolla,2022,Black,120000.50,BRA2E19,flex\n" +
+			"Ford,Ka,abc,White,50000,,\n" +
+			"\n" +
+			"Fiat,Uno,2010,Red,20000,,\n"
+
+		suite.vehicles.EXPECT().
+			Create(suite.ctx, dto.InputCreateVehicleDTO{
+				Brand: "Toyota", Model: "Corolla", Year: 2022, Color: "Black",
+				Price: domain.NewMoney(12000050, ""), Plate: "BRA2E19", FuelType: "flex",
+			}).
+			Return(createdVehicle("vehicle-1"), nil)
+		suite.vehicles.EXPECT().
+			Create(suite.ctx, gomock.Cond(func(input dto.InputCreateVehicleDTO) bool { return input.Brand == "Fiat" })).
+			Return(nil, domain.ValidationErrors{{Field: "model", Code: domain.CodeUnknown, Message: "model Uno is not in the catalog"}})
+
+		output, err := suite.useCase.Import(suite.ctx, csvInput, strings.NewReader(file))
+		suite.NoError(err)
+		suite.Nil(output.Job)
+		suite.Equal(3, output.Report.Total)
+		suite.Equal(1, output.Report.Succeeded)
+		suite.Equal(2, output.Report.Failed)
+
+		rows := output.Report.Rows
+		suite.Equal(dto.ImportRowResultDTO{Line: 2, Status: "CREATED", VehicleID: "vehicle-1"}, rows[0])
+		suite.Equal(3, rows[1].Line)
+		suite.Equal("FAILED", rows[1].Status)
+		suite.Equal("year", rows[1].Errors[0].Field)
+		suite.Equal(5, rows[2].Line)
+		suite.Equal(domain.CodeUnknown, rows[2].Errors[0].Code)
+	})
+
+	suite.T().Run("should report a row with the wrong number of fields", func(t *testing.T) {
+		output, err := suite.useCase.Import(suite.ctx, csvInput, strings.NewReader("brand,model\nToyota\n"))
+		suite.NoError(err)
+		suite.Equal("FAILED", output.Report.Rows[0].Status)
+		suite.Contains(output.Report.Rows[0].Error, "1 fields")
+	})
+
+	suite.T().Run("should reject an unknown column", func(t *testing.T) {
+		_, err := suite.useCase.Import(suite.ctx, csvInput, strings.NewReader("brand,modle\nToyota,Corolla\n"))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal("file", validationErrs[0].Field)
+		suite.Contains(validationErrs[0].Message, "modle")
+	})
+
+	suite.T().Run("should reject a file without rows", func(t *testing.T) {
+		_, err := suite.useCase.Import(suite.ctx, csvInput, strings.NewReader("brand,model\n"))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal(domain.CodeRequired, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject a file over the row limit", func(t *testing.T) {
+		file := "brand\n" + strings.Repeat("Toyota\n", 11)
+
+		_, err := suite.useCase.Import(suite.ctx, csvInput, strings.NewReader(file))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal(domain.CodeOutOfRange, validationErrs[0].Code)
+	})
+
+	suite.T().Run("should reject a file over the size limit", func(t *testing.T) {
+		file := "brand\n" + strings.Repeat("x", 4<<10)
+
+		_, err := suite.useCase.Import(suite.ctx, csvInput, strings.NewReader(file))
+
+		var validationErrs domain.ValidationErrors
+		suite.True(errors.As(err, &validationErrs))
+		suite.Equal(domain.CodeTooLong, validationErrs[0].Code)
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportJSONL() {
+	jsonlInput := dto.InputImportVehiclesDTO{Format: usecase.ImportFormatJSONL}
+
+	suite.T().Run("should read one vehicle per line", func(t *testing.T) {
+		file := `{"brand":"Toyota","model":"Corolla","year":2022,"price":"120000.00"}` + "\n" +
+			`{"brand":"Ford","model":"Ka","year":"2019"}` + "\n" +
+			`{"brand":"Fiat","colour":"Red"}` + "\n" +
+			`not json`
+
+		suite.vehicles.EXPECT().
+			Create(suite.ctx, gomock.Cond(func(input dto.InputCreateVehicleDTO) bool {
+				return input.Brand == "Toyota" && input.Price == domain.NewMoney(12000000, "")
+			})).
+			Return(createdVehicle("vehicle-1"), nil)
+
+		output, err := suite.useCase.Import(suite.ctx, jsonlInput, strings.NewReader(file))
+		suite.NoError(err)
+		suite.Equal(1, output.Report.Succeeded)
+		suite.Equal(3, output.Report.Failed)
+		suite.Equal("year", output.Report.Rows[1].Errors[0].Field)
+		suite.Contains(output.Report.Rows[2].Error, "colour")
+		suite.Equal(4, output.Report.Rows[3].Line)
+		suite.Contains(output.Report.Rows[3].Error, "invalid JSON")
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportIdentifiers() {
+	suite.T().Run("should report identifiers repeated in the file", func(t *testing.T) {
+		file := "brand,model,plate\n" +
+			"Toyota,Corolla,BRA2E19\n" +
+			"Ford,Ka,ABC1234\n" +
+			"Fiat,Uno,bra-2e19\n"
+
+		suite.vehicles.EXPECT().Create(suite.ctx, gomock.Any()).Return(createdVehicle("vehicle-1"), nil)
+		suite.vehicles.EXPECT().Create(suite.ctx, gomock.Any()).Return(createdVehicle("vehicle-2"), nil)
+
+		output, err := suite.useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV}, strings.NewReader(file))
+		suite.NoError(err)
+		suite.Equal("FAILED", output.Report.Rows[2].Status)
+		suite.Contains(output.Report.Rows[2].Error, "plate is already used on line 2")
+	})
+
+	suite.T().Run("should report a conflict with an existing vehicle", func(t *testing.T) {
+		suite.vehicles.EXPECT().Create(suite.ctx, gomock.Any()).
+			Return(nil, &domain.DuplicateVehicleError{Field: "vin", VehicleID: "vehicle-9"})
+
+		output, err := suite.useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV}, strings.NewReader("brand,vin\nVolkswagen,9BWZZZ377VT004251\n"))
+		suite.NoError(err)
+		suite.Contains(output.Report.Rows[0].Error, "vehicle-9")
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportDryRun() {
+	suite.T().Run("should roll back every batch and report the rows as valid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		transactor := mocks.NewMockTransactor(ctrl)
+		var rolledBack int
+		transactor.EXPECT().
+			WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				err := fn(ctx)
+				if err != nil {
+					rolledBack++
+				}
+				return err
+			}).
+			Times(5)
+		useCase := usecase.NewVehicleImportUseCase(suite.vehicles, suite.jobs, transactor, usecase.VehicleImportConfig{BatchSize: 2})
+
+		suite.vehicles.EXPECT().Create(gomock.Any(), gomock.Any()).Return(createdVehicle("vehicle-1"), nil).Times(3)
+
+		file := "brand,model\nToyota,Corolla\nFord,Ka\nFiat,Uno\n"
+		output, err := useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV, DryRun: true}, strings.NewReader(file))
+		suite.NoError(err)
+		suite.Equal(2, rolledBack)
+		suite.True(output.Report.DryRun)
+		suite.Equal(3, output.Report.Succeeded)
+		suite.Equal(dto.ImportRowResultDTO{Line: 2, Status: "VALID"}, output.Report.Rows[0])
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportRowSavepoints() {
+	suite.T().Run("should run each row in a nested transaction and undo only the failed ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		transactor := mocks.NewMockTransactor(ctrl)
+		var depth, rowsRolledBack int
+		transactor.EXPECT().
+			WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				depth++
+				defer func() { depth-- }()
+				err := fn(ctx)
+				if err != nil && depth > 1 {
+					rowsRolledBack++
+				}
+				return err
+			}).
+			Times(3)
+		useCase := usecase.NewVehicleImportUseCase(suite.vehicles, suite.jobs, transactor, usecase.VehicleImportConfig{BatchSize: 2})
+
+		suite.vehicles.EXPECT().Create(gomock.Any(), gomock.Any()).Return(createdVehicle("vehicle-1"), nil)
+		suite.vehicles.EXPECT().Create(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.DuplicateVehicleError{Field: "vin", VehicleID: "vehicle-9"})
+
+		file := "brand,model\nToyota,Corolla\nFord,Ka\n"
+		output, err := useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV}, strings.NewReader(file))
+		suite.NoError(err)
+		suite.Equal(1, rowsRolledBack)
+		suite.Equal(1, output.Report.Succeeded)
+		suite.Equal("FAILED", output.Report.Rows[1].Status)
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportInternalError() {
+	suite.T().Run("should stop on an internal error", func(t *testing.T) {
+		suite.vehicles.EXPECT().Create(suite.ctx, gomock.Any()).Return(createdVehicle("vehicle-1"), nil)
+		suite.vehicles.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil, assert.AnError)
+
+		file := "brand,model\nToyota,Corolla\nFord,Ka\nFiat,Uno\n"
+		_, err := suite.useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV}, strings.NewReader(file))
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_ImportAsync() {
+	file := "brand,model\n" + strings.Repeat("Toyota,Corolla\n", 5)
+
+	suite.T().Run("should import a large file in the background", func(t *testing.T) {
+		finished := make(chan domain.VehicleImportJob, 1)
+		suite.jobs.EXPECT().
+			Create(suite.ctx, gomock.Cond(func(job *domain.VehicleImportJob) bool {
+				return job.Status == domain.ImportRunning && job.Total == 5
+			})).
+			Return(nil)
+		suite.vehicles.EXPECT().Create(gomock.Any(), gomock.Any()).Return(createdVehicle("vehicle-1"), nil).Times(5)
+		suite.jobs.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, job *domain.VehicleImportJob) error {
+				if job.Status != domain.ImportRunning {
+					finished <- *job
+				}
+				return nil
+			}).
+			Times(4)
+
+		output, err := suite.useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV}, strings.NewReader(file))
+		suite.NoError(err)
+		suite.Nil(output.Report)
+		suite.Equal("RUNNING", output.Job.Status)
+		suite.NotEmpty(output.Job.ID)
+
+		select {
+		case job := <-finished:
+			suite.Equal(domain.ImportCompleted, job.Status)
+			suite.Equal(5, job.Processed)
+			suite.Equal(5, job.Succeeded)
+			suite.NotNil(job.FinishedAt)
+		case <-time.After(time.Second):
+			t.Fatal("the import job did not finish")
+		}
+	})
+
+	suite.T().Run("should mark the job as failed on an internal error", func(t *testing.T) {
+		finished := make(chan domain.VehicleImportJob, 1)
+		suite.jobs.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil)
+		suite.vehicles.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+		suite.jobs.EXPECT().
+			Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, job *domain.VehicleImportJob) error {
+				finished <- *job
+				return nil
+			})
+
+		input := dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV, Async: true}
+		_, err := suite.useCase.Import(suite.ctx, input, strings.NewReader("brand,model\nToyota,Corolla\n"))
+		suite.NoError(err)
+
+		select {
+		case job := <-finished:
+			suite.Equal(domain.ImportFailed, job.Status)
+			suite.Equal(0, job.Processed)
+			suite.NotEmpty(job.Error)
+		case <-time.After(time.Second):
+			t.Fatal("the import job did not finish")
+		}
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_GetJob() {
+	suite.T().Run("should return the progress of the job", func(t *testing.T) {
+		now := time.Now()
+		suite.jobs.EXPECT().GetByID(suite.ctx, "job-1").Return(&domain.VehicleImportJob{
+			ID:        "job-1",
+			Status:    domain.ImportRunning,
+			Total:     800,
+			Processed: 100,
+			Succeeded: 99,
+			Failed:    1,
+			Results:   []domain.ImportRowResult{{Line: 2, Status: domain.ImportRowFailed, Error: "validation failed"}},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}, nil)
+
+		output, err := suite.useCase.GetJob(suite.ctx, "job-1")
+		suite.NoError(err)
+		suite.Equal("RUNNING", output.Status)
+		suite.Equal(100, output.Processed)
+		suite.Empty(output.FinishedAt)
+		suite.Len(output.Rows, 1)
+	})
+
+	suite.T().Run("should return ErrNotFound for an unknown job", func(t *testing.T) {
+		suite.jobs.EXPECT().GetByID(suite.ctx, "job-9").Return(nil, fmt.Errorf("import job job-9: %w", domain.ErrNotFound))
+
+		_, err := suite.useCase.GetJob(suite.ctx, "job-9")
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_FailStaleJobs() {
+	suite.T().Run("should fail only the jobs idle for longer than the stale threshold", func(t *testing.T) {
+		useCase := usecase.NewVehicleImportUseCase(suite.vehicles, suite.jobs, suite.transactor, usecase.VehicleImportConfig{StaleAfter: time.Hour})
+
+		start := time.Now()
+		suite.jobs.EXPECT().
+			FailStale(suite.ctx, gomock.Any(), gomock.Cond(func(staleBefore time.Time) bool {
+				return !staleBefore.Before(start.Add(-time.Hour)) && staleBefore.Before(start.Add(-59*time.Minute))
+			}), gomock.Any()).
+			Return(int64(2), nil)
+
+		failed, err := useCase.FailStaleJobs(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(int64(2), failed)
+	})
+}
+
+func (suite *VehicleImportUseCaseSuite) Test_Wait() {
+	suite.T().Run("should wait for the background imports to finish", func(t *testing.T) {
+		release := make(chan struct{})
+		suite.jobs.EXPECT().Create(suite.ctx, gomock.Any()).Return(nil)
+		suite.vehicles.EXPECT().Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, dto.InputCreateVehicleDTO) (*dto.OutputCreateVehicleDTO, error) {
+				<-release
+				return createdVehicle("vehicle-1"), nil
+			})
+		suite.jobs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		_, err := suite.useCase.Import(suite.ctx, dto.InputImportVehiclesDTO{Format: usecase.ImportFormatCSV, Async: true}, strings.NewReader("brand,model\nToyota,Corolla\n"))
+		suite.NoError(err)
+
+		expired, cancel := context.WithTimeout(suite.ctx, 10*time.Millisecond)
+		defer cancel()
+		suite.ErrorIs(suite.useCase.Wait(expired), context.DeadlineExceeded)
+
+		close(release)
+		suite.NoError(suite.useCase.Wait(suite.ctx))
+	})
+}
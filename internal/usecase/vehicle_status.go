@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+var ErrInvalidStatusTransition = fmt.Errorf("%w: invalid vehicle status transition", domain.ErrConflict)
+
+var vehicleStatusTransitions = map[domain.VehicleStatus][]domain.VehicleStatus{
+	domain.StatusAvailable: {domain.StatusReserved, domain.StatusSold},
+	domain.StatusReserved:  {domain.StatusAvailable, domain.StatusSold},
+	domain.StatusSold:      {},
+}
+
+func canTransition(from, to domain.VehicleStatus) bool {
+	for _, allowed := range vehicleStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func transitionStatus(vehicle *domain.Vehicle, to domain.VehicleStatus) error {
+	if !canTransition(vehicle.Status, to) {
+		return fmt.Errorf("%w: cannot change from %s to %s", ErrInvalidStatusTransition, vehicle.Status, to)
+	}
+	vehicle.Status = to
+	return nil
+}
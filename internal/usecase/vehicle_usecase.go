@@ -2,78 +2,136 @@ package usecase
 
 import (
 	"context"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
-	"github.com/NicolasNSC/catalog-service-fiap/internal/client"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/vin"
 	"github.com/google/uuid"
 )
 
 //go:generate mockgen -source=vehicle_usecase.go -destination=./mocks/vehicle_usecase_mock.go -package=mocks
 type VehicleUseCaseInterface interface {
 	Create(ctx context.Context, input dto.InputCreateVehicleDTO) (*dto.OutputCreateVehicleDTO, error)
-	Update(ctx context.Context, id string, input dto.InputUpdateVehicleDTO) error
+	Update(ctx context.Context, id string, expectedVersion int, input dto.InputUpdateVehicleDTO) (*dto.OutputVehicleDTO, error)
+	Patch(ctx context.Context, id string, expectedVersion int, input dto.InputPatchVehicleDTO) (*dto.OutputVehicleDTO, error)
+	// GetByID returns the vehicle, with its price converted to currency when
+	// currency is not empty.
+	GetByID(ctx context.Context, id, currency string) (*dto.OutputVehicleDTO, error)
+	List(ctx context.Context, input dto.InputListVehiclesDTO) (*dto.OutputListVehiclesDTO, error)
+	Delete(ctx context.Context, id string) error
+	Reserve(ctx context.Context, id string) (*dto.OutputVehicleDTO, error)
+	Sell(ctx context.Context, id string) (*dto.OutputVehicleDTO, error)
+	Release(ctx context.Context, id string) (*dto.OutputVehicleDTO, error)
+	History(ctx context.Context, id string, input dto.InputListVehicleHistoryDTO) (*dto.OutputListVehicleHistoryDTO, error)
+	Prices(ctx context.Context, id string) (*dto.OutputVehiclePricesDTO, error)
+	PriceStats(ctx context.Context, input dto.InputPriceStatsDTO) (*dto.OutputListPriceStatsDTO, error)
 }
 
 type vehicleUseCase struct {
-	repo           repository.VehicleRepository
-	showcaseClient client.ShowcaseClientInterface
+	repo       repository.VehicleRepository
+	history    repository.VehicleHistoryRepository
+	prices     repository.VehiclePriceRepository
+	transactor repository.Transactor
+	publisher  EventPublisher
+	rates      ExchangeRateProvider
+	catalog    CatalogResolver
+	images     VehicleImageLister
 }
 
-func NewVehicleUseCase(repo repository.VehicleRepository, showcaseClient client.ShowcaseClientInterface) VehicleUseCaseInterface {
+func NewVehicleUseCase(repo repository.VehicleRepository, history repository.VehicleHistoryRepository, prices repository.VehiclePriceRepository, transactor repository.Transactor, publisher EventPublisher, rates ExchangeRateProvider, catalog CatalogResolver, images VehicleImageLister) VehicleUseCaseInterface {
 	return &vehicleUseCase{
-		repo:           repo,
-		showcaseClient: showcaseClient,
+		repo:       repo,
+		history:    history,
+		prices:     prices,
+		transactor: transactor,
+		publisher:  publisher,
+		rates:      rates,
+		catalog:    catalog,
+		images:     images,
 	}
 }
 
 // Create is the handler for the POST /vehicles endpoint.
 // @Summary      Create a new vehicle
-// @Description  Adds a new vehicle to the catalog.
+// @Description  Adds a new vehicle to the catalog. Brand and model are stored with their canonical names from the brand and model catalog.
 // @Tags         Vehicles
 // @Accept       json
 // @Produce      json
 // @Param        vehicle  body      dto.InputCreateVehicleDTO  true  "Vehicle data to create"
 // @Success      201      {object}  dto.OutputCreateVehicleDTO
-// @Failure      400      {string}  string "Invalid request body"
-// @Failure      500      {string}  string "Internal server error"
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid request body or vehicle data, a brand or year contradicting the VIN, or a brand or model not in the catalog"
+// @Failure      409      {object}  dto.ProblemDetailsDTO "VIN, plate or RENAVAM already registered to the vehicle in conflicting_id"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
 // @Router       /vehicles/add [post]
 func (vuc *vehicleUseCase) Create(ctx context.Context, input dto.InputCreateVehicleDTO) (*dto.OutputCreateVehicleDTO, error) {
-	err := utils.ValidateVehicleFields(input.Brand, input.Model, input.Year, input.Price)
-	if err != nil {
-		return nil, err
-	}
-
 	vehicle := &domain.Vehicle{
-		ID:        uuid.New().String(),
-		Brand:     input.Brand,
-		Model:     input.Model,
-		Year:      input.Year,
-		Color:     input.Color,
-		Price:     input.Price,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:                 uuid.New().String(),
+		Brand:              input.Brand,
+		Model:              input.Model,
+		Year:               input.Year,
+		Color:              input.Color,
+		Price:              input.Price.WithCurrency(currencyOrDefault(input.Currency)),
+		VIN:                utils.NormalizeVIN(input.VIN),
+		Plate:              utils.NormalizePlate(input.Plate),
+		Renavam:            utils.NormalizeRenavam(input.Renavam),
+		Mileage:            input.Mileage,
+		FuelType:           enumValue[domain.FuelType](input.FuelType),
+		Transmission:       enumValue[domain.Transmission](input.Transmission),
+		BodyType:           enumValue[domain.BodyType](input.BodyType),
+		Doors:              input.Doors,
+		EngineDisplacement: input.EngineDisplacement,
+		Condition:          enumValue[domain.VehicleCondition](input.Condition),
+		Status:             domain.StatusAvailable,
+		Version:            1,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
 	}
 
-	err = vuc.repo.Save(ctx, vehicle)
-	if err != nil {
+	// The VIN mismatches are reported together with the other problems.
+	mismatches := decodeVIN(vehicle, input.OverrideVIN)
+	err := utils.ValidateVehicle(vehicle)
+	var validationErrs domain.ValidationErrors
+	if err != nil && !errors.As(err, &validationErrs) {
 		return nil, err
 	}
-
-	listingDTO := dto.CreateListingDTO{
-		VehicleID: vehicle.ID,
-		Brand:     vehicle.Brand,
-		Model:     vehicle.Model,
-		Price:     vehicle.Price,
+	validationErrs = append(validationErrs, mismatches...)
+	if len(validationErrs) > 0 {
+		return nil, validationErrs
 	}
 
-	err = vuc.showcaseClient.CreateListing(ctx, listingDTO)
+	err = vuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := vuc.resolveCatalog(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.checkDuplicate(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.repo.Save(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.recordHistory(ctx, domain.HistoryCreate, vehicle.ID, nil, vehicle)
+		if err != nil {
+			return err
+		}
+
+		return vuc.publish(ctx, domain.EventVehicleCreated, vehicle)
+	})
 	if err != nil {
-		log.Printf("Warning: failed to notify showcase-service about new vehicle %s: %v", vehicle.ID, err)
+		return nil, err
 	}
 
 	output := &dto.OutputCreateVehicleDTO{
@@ -86,50 +144,981 @@ func (vuc *vehicleUseCase) Create(ctx context.Context, input dto.InputCreateVehi
 
 // Update is the handler for the PUT /vehicles/{id} endpoint.
 // @Summary      Update an existing vehicle
-// @Description  Updates the data of a vehicle by its ID.
+// @Description  Updates the data of a vehicle by its ID. A changed brand or model is stored with its canonical name from the catalog. Without currency, the price keeps the currency of the vehicle.
 // @Tags         Vehicles
 // @Accept       json
 // @Produce      json
-// @Param        id       path      string                     true  "Vehicle ID"
-// @Param        vehicle  body      dto.InputUpdateVehicleDTO  true  "Vehicle data to update"
-// @Success      200      {string}  string "OK"
-// @Failure      400      {string}  string "Invalid request body or ID"
-// @Failure      404      {string}  string "Vehicle not found"
-// @Failure      500      {string}  string "Internal server error"
+// @Param        id        path      string                     true   "Vehicle ID"
+// @Param        If-Match  header    string                     false  "ETag of the vehicle version being updated"
+// @Param        vehicle   body      dto.InputUpdateVehicleDTO  true   "Vehicle data to update"
+// @Success      200       {object}  dto.OutputVehicleDTO
+// @Header       200       {string}  ETag  "Version of the updated vehicle"
+// @Failure      400       {object}  dto.ProblemDetailsDTO "Invalid request body, ID or vehicle data, or a brand or model not in the catalog"
+// @Failure      404       {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      409       {object}  dto.ProblemDetailsDTO "Vehicle was modified concurrently, or an identifier is registered to the vehicle in conflicting_id"
+// @Failure      412       {object}  dto.ProblemDetailsDTO "If-Match does not match the current version"
+// @Failure      500       {object}  dto.ProblemDetailsDTO "Internal server error"
 // @Router       /vehicles/{id} [put]
-func (vuc *vehicleUseCase) Update(ctx context.Context, id string, input dto.InputUpdateVehicleDTO) error {
+func (vuc *vehicleUseCase) Update(ctx context.Context, id string, expectedVersion int, input dto.InputUpdateVehicleDTO) (*dto.OutputVehicleDTO, error) {
+	var vehicle *domain.Vehicle
+	err := vuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = vuc.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(vehicle, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		before := *vehicle
+		vehicle.Brand = input.Brand
+		vehicle.Model = input.Model
+		vehicle.Color = input.Color
+		vehicle.Year = input.Year
+		// A PUT without currency keeps the one the vehicle is priced in.
+		currency := vehicle.Price.Currency()
+		if code := normalizeCurrency(input.Currency); code != "" {
+			currency = code
+		}
+		vehicle.Price = input.Price.WithCurrency(currency)
+		vehicle.VIN = utils.NormalizeVIN(input.VIN)
+		vehicle.Plate = utils.NormalizePlate(input.Plate)
+		vehicle.Renavam = utils.NormalizeRenavam(input.Renavam)
+		vehicle.Mileage = input.Mileage
+		vehicle.FuelType = enumValue[domain.FuelType](input.FuelType)
+		vehicle.Transmission = enumValue[domain.Transmission](input.Transmission)
+		vehicle.BodyType = enumValue[domain.BodyType](input.BodyType)
+		vehicle.Doors = input.Doors
+		vehicle.EngineDisplacement = input.EngineDisplacement
+		vehicle.Condition = enumValue[domain.VehicleCondition](input.Condition)
+		vehicle.UpdatedAt = time.Now()
+
+		err = utils.ValidateVehicle(vehicle)
+		if err != nil {
+			return err
+		}
+
+		if catalogChanged(&before, vehicle) {
+			err = vuc.resolveCatalog(ctx, vehicle)
+			if err != nil {
+				return err
+			}
+		} else {
+			vehicle.Brand, vehicle.Model = before.Brand, before.Model
+		}
+
+		if identifiersChanged(&before, vehicle) {
+			err = vuc.checkDuplicate(ctx, vehicle)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = vuc.repo.Update(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.recordHistory(ctx, domain.HistoryUpdate, vehicle.ID, &before, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.recordPriceChange(ctx, &before, vehicle)
+		if err != nil {
+			return err
+		}
+
+		return vuc.publish(ctx, domain.EventVehicleUpdated, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputVehicleDTO(vehicle)
+	return &output, nil
+}
+
+// Patch is the handler for the PATCH /vehicles/{id} endpoint.
+// @Summary      Partially update a vehicle
+// @Description  Applies a JSON Merge Patch (RFC 7396) to a vehicle. Absent fields are left unchanged.
+// @Tags         Vehicles
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id        path      string                    true   "Vehicle ID"
+// @Param        If-Match  header    string                    false  "ETag of the vehicle version being updated"
+// @Param        vehicle   body      dto.InputPatchVehicleDTO  true   "Vehicle fields to change"
+// @Success      200       {object}  dto.OutputVehicleDTO
+// @Header       200       {string}  ETag  "Version of the updated vehicle"
+// @Failure      400       {object}  dto.ProblemDetailsDTO "Invalid request body, ID or vehicle data, or a brand or model not in the catalog"
+// @Failure      404       {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      409       {object}  dto.ProblemDetailsDTO "Vehicle was modified concurrently, or an identifier is registered to the vehicle in conflicting_id"
+// @Failure      412       {object}  dto.ProblemDetailsDTO "If-Match does not match the current version"
+// @Failure      415       {object}  dto.ProblemDetailsDTO "Unsupported media type"
+// @Failure      500       {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id} [patch]
+func (vuc *vehicleUseCase) Patch(ctx context.Context, id string, expectedVersion int, input dto.InputPatchVehicleDTO) (*dto.OutputVehicleDTO, error) {
+	var vehicle *domain.Vehicle
+	err := vuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = vuc.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = checkVersion(vehicle, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		before := *vehicle
+		changed, err := vuc.applyPatch(ctx, vehicle, input)
+		if err != nil || !changed {
+			return err
+		}
+
+		err = vuc.recordHistory(ctx, domain.HistoryUpdate, vehicle.ID, &before, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.recordPriceChange(ctx, &before, vehicle)
+		if err != nil {
+			return err
+		}
+
+		return vuc.publish(ctx, domain.EventVehicleUpdated, vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputVehicleDTO(vehicle)
+	return &output, nil
+}
+
+// applyPatch validates and persists the fields of input that differ from the
+// stored vehicle, reporting whether anything changed.
+func (vuc *vehicleUseCase) applyPatch(ctx context.Context, vehicle *domain.Vehicle, input dto.InputPatchVehicleDTO) (bool, error) {
+	validator := utils.NewVehicleValidator()
+	changed := false
+
+	catalogChanged := false
+	if input.Brand != nil && domain.CatalogKey(*input.Brand) != domain.CatalogKey(vehicle.Brand) {
+		validator.Brand(*input.Brand)
+		vehicle.Brand = *input.Brand
+		catalogChanged, changed = true, true
+	}
+	if input.Model != nil && domain.CatalogKey(*input.Model) != domain.CatalogKey(vehicle.Model) {
+		validator.Model(*input.Model)
+		vehicle.Model = *input.Model
+		catalogChanged, changed = true, true
+	}
+	if input.Year != nil && *input.Year != vehicle.Year {
+		validator.Year(*input.Year)
+		vehicle.Year = *input.Year
+		changed = true
+	}
+	if input.Color != nil && *input.Color != vehicle.Color {
+		validator.Color(*input.Color)
+		vehicle.Color = *input.Color
+		changed = true
+	}
+
+	price := vehicle.Price
+	if input.Currency != nil {
+		price = price.WithCurrency(normalizeCurrency(*input.Currency))
+	}
+	if input.Price != nil {
+		price = input.Price.WithCurrency(price.Currency())
+	}
+	if price != vehicle.Price {
+		validator.Price(price)
+		vehicle.Price = price
+		changed = true
+	}
+
+	identifiersChanged := false
+	if input.VIN != nil && utils.NormalizeVIN(*input.VIN) != vehicle.VIN {
+		vehicle.VIN = utils.NormalizeVIN(*input.VIN)
+		validator.VIN(vehicle.VIN)
+		identifiersChanged, changed = true, true
+	}
+	if input.Plate != nil && utils.NormalizePlate(*input.Plate) != vehicle.Plate {
+		vehicle.Plate = utils.NormalizePlate(*input.Plate)
+		validator.Plate(vehicle.Plate)
+		identifiersChanged, changed = true, true
+	}
+	if input.Renavam != nil && utils.NormalizeRenavam(*input.Renavam) != vehicle.Renavam {
+		vehicle.Renavam = utils.NormalizeRenavam(*input.Renavam)
+		validator.Renavam(vehicle.Renavam)
+		identifiersChanged, changed = true, true
+	}
+
+	if input.Mileage != nil && *input.Mileage != vehicle.Mileage {
+		validator.Mileage(*input.Mileage)
+		vehicle.Mileage = *input.Mileage
+		changed = true
+	}
+	if input.FuelType != nil && enumValue[domain.FuelType](*input.FuelType) != vehicle.FuelType {
+		vehicle.FuelType = enumValue[domain.FuelType](*input.FuelType)
+		validator.FuelType(vehicle.FuelType)
+		changed = true
+	}
+	if input.Transmission != nil && enumValue[domain.Transmission](*input.Transmission) != vehicle.Transmission {
+		vehicle.Transmission = enumValue[domain.Transmission](*input.Transmission)
+		validator.Transmission(vehicle.Transmission)
+		changed = true
+	}
+	if input.BodyType != nil && enumValue[domain.BodyType](*input.BodyType) != vehicle.BodyType {
+		vehicle.BodyType = enumValue[domain.BodyType](*input.BodyType)
+		validator.BodyType(vehicle.BodyType)
+		changed = true
+	}
+	if input.Doors != nil && *input.Doors != vehicle.Doors {
+		validator.Doors(*input.Doors)
+		vehicle.Doors = *input.Doors
+		changed = true
+	}
+	if input.EngineDisplacement != nil && *input.EngineDisplacement != vehicle.EngineDisplacement {
+		validator.EngineDisplacement(*input.EngineDisplacement)
+		vehicle.EngineDisplacement = *input.EngineDisplacement
+		changed = true
+	}
+	if input.Condition != nil && enumValue[domain.VehicleCondition](*input.Condition) != vehicle.Condition {
+		vehicle.Condition = enumValue[domain.VehicleCondition](*input.Condition)
+		validator.Condition(vehicle.Condition)
+		changed = true
+	}
+
+	err := validator.Err()
+	if err != nil || !changed {
+		return false, err
+	}
+
+	if catalogChanged {
+		err = vuc.resolveCatalog(ctx, vehicle)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if identifiersChanged {
+		err = vuc.checkDuplicate(ctx, vehicle)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	vehicle.UpdatedAt = time.Now()
+
+	err = vuc.repo.Update(ctx, vehicle)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetByID is the handler for the GET /vehicles/{id} endpoint.
+// @Summary      Get a vehicle
+// @Description  Returns a vehicle of the catalog by its ID, along with its images.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        id        path      string  true   "Vehicle ID"
+// @Param        currency  query     string  false  "Also return the price converted to this currency"  example(USD)
+// @Success      200       {object}  dto.OutputVehicleDTO
+// @Header       200       {string}  ETag  "Version of the vehicle"
+// @Failure      400       {object}  dto.ProblemDetailsDTO "Invalid ID or unsupported currency"
+// @Failure      404       {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      500       {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id} [get]
+func (vuc *vehicleUseCase) GetByID(ctx context.Context, id, currency string) (*dto.OutputVehicleDTO, error) {
+	vehicle, err := vuc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputVehicleDTO(vehicle)
+	output.Images, err = vuc.images.Images(ctx, vehicle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vuc.convertPrice(ctx, &output, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// List is the handler for the GET /vehicles endpoint.
+// @Summary      List vehicles
+// @Description  Returns a page of the vehicle catalog matching the given filters, along with the total number of matches.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        brand      query     string  false  "Filter by brand (case-insensitive)"
+// @Param        model      query     string  false  "Filter by model (case-insensitive)"
+// @Param        color      query     string  false  "Filter by color (case-insensitive)"
+// @Param        year_min   query     int     false  "Minimum manufacturing year"
+// @Param        year_max   query     int     false  "Maximum manufacturing year"
+// @Param        price_min  query     number  false  "Minimum price"
+// @Param        price_max  query     number  false  "Maximum price"
+// @Param        mileage_min  query   int     false  "Minimum mileage in km"
+// @Param        mileage_max  query   int     false  "Maximum mileage in km"
+// @Param        fuel_type  query     string  false  "Filter by fuel type"  Enums(GASOLINE, ETHANOL, FLEX, DIESEL, HYBRID, ELECTRIC)
+// @Param        transmission  query  string  false  "Filter by transmission"  Enums(MANUAL, AUTOMATIC, AUTOMATED, CVT)
+// @Param        body_type  query     string  false  "Filter by body type"  Enums(HATCHBACK, SEDAN, SUV, PICKUP, COUPE, CONVERTIBLE, WAGON, MINIVAN, VAN)
+// @Param        doors      query     int     false  "Filter by number of doors"
+// @Param        engine_min  query    int     false  "Minimum engine displacement in cc"
+// @Param        engine_max  query    int     false  "Maximum engine displacement in cc"
+// @Param        condition  query     string  false  "Filter by condition"  Enums(NEW, USED)
+// @Param        currency   query     string  false  "Also return the prices converted to this currency; required by price_min, price_max and sort=price, which only match vehicles priced in it"  example(USD)
+// @Param        sort       query     string  false  "Sort field (default created_at)"  Enums(price, year, mileage, created_at)
+// @Param        order      query     string  false  "Sort order (default desc)"  Enums(asc, desc)
+// @Param        limit      query     int     false  "Maximum number of vehicles to return (default 20, max 100)"
+// @Param        offset     query     int     false  "Number of vehicles to skip"
+// @Success      200        {object}  dto.OutputListVehiclesDTO
+// @Failure      400        {object}  dto.ProblemDetailsDTO "Invalid filter, sort, pagination or currency parameters"
+// @Failure      500        {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles [get]
+func (vuc *vehicleUseCase) List(ctx context.Context, input dto.InputListVehiclesDTO) (*dto.OutputListVehiclesDTO, error) {
+	params := repository.VehicleListParams{
+		Filter: repository.VehicleFilter{
+			Brand:    input.Brand,
+			Model:    input.Model,
+			Color:    input.Color,
+			YearMin:  input.YearMin,
+			YearMax:  input.YearMax,
+			PriceMin: input.PriceMin,
+			PriceMax: input.PriceMax,
+
+			MileageMin:            input.MileageMin,
+			MileageMax:            input.MileageMax,
+			FuelType:              enumValue[domain.FuelType](input.FuelType),
+			Transmission:          enumValue[domain.Transmission](input.Transmission),
+			BodyType:              enumValue[domain.BodyType](input.BodyType),
+			Doors:                 input.Doors,
+			EngineDisplacementMin: input.EngineDisplacementMin,
+			EngineDisplacementMax: input.EngineDisplacementMax,
+			Condition:             enumValue[domain.VehicleCondition](input.Condition),
+		},
+		Sort: repository.VehicleSort{
+			Field: repository.VehicleSortField(input.SortBy),
+			Order: repository.SortOrder(input.SortOrder),
+		},
+		Limit:  input.Limit,
+		Offset: input.Offset,
+	}
+	params.Limit, params.Offset = normalizePage(params.Limit, params.Offset)
+
+	// Amounts in different currencies cannot be compared, so filtering or
+	// sorting by price is limited to the vehicles priced in the currency asked.
+	if input.PriceMin != nil || input.PriceMax != nil || params.Sort.Field == repository.SortByPrice {
+		if input.Currency == "" {
+			return nil, domain.ValidationErrors{{
+				Field:   "currency",
+				Code:    domain.CodeRequired,
+				Message: "currency is required to filter or sort by price",
+			}}
+		}
+		params.Filter.Currency = input.Currency
+	}
+
+	err := utils.NewVehicleValidator().
+		FuelType(params.Filter.FuelType).
+		Transmission(params.Filter.Transmission).
+		BodyType(params.Filter.BodyType).
+		Condition(params.Filter.Condition).
+		Err()
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, total, err := vuc.repo.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.OutputVehicleDTO, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		item := toOutputVehicleDTO(vehicle)
+		err = vuc.convertPrice(ctx, &item, input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	output := &dto.OutputListVehiclesDTO{
+		Items:  items,
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+
+	return output, nil
+}
+
+// Delete is the handler for the DELETE /vehicles/{id} endpoint.
+// @Summary      Delete a vehicle
+// @Description  Soft-deletes a vehicle by its ID and removes its listing from the showcase.
+// @Tags         Vehicles
+// @Param        id   path      string  true  "Vehicle ID"
+// @Success      204  {string}  string "No Content"
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id} [delete]
+func (vuc *vehicleUseCase) Delete(ctx context.Context, id string) error {
+	var vehicle *domain.Vehicle
+	err := vuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = vuc.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *vehicle
+		vehicle.UpdatedAt = time.Now()
+		err = vuc.repo.Delete(ctx, vehicle.ID, vehicle.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.recordHistory(ctx, domain.HistoryDelete, vehicle.ID, &before, nil)
+		if err != nil {
+			return err
+		}
+
+		return vuc.publish(ctx, domain.EventVehicleDeleted, vehicle)
+	})
+
+	return err
+}
+
+// Reserve is the handler for the POST /vehicles/{id}/reserve endpoint.
+// @Summary      Reserve a vehicle
+// @Description  Moves an available vehicle to the RESERVED status.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        id   path      string  true  "Vehicle ID"
+// @Success      200  {object}  dto.OutputVehicleDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      409  {object}  dto.ProblemDetailsDTO "Invalid status transition"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/reserve [post]
+func (vuc *vehicleUseCase) Reserve(ctx context.Context, id string) (*dto.OutputVehicleDTO, error) {
+	return vuc.changeStatus(ctx, id, domain.StatusReserved)
+}
+
+// Sell is the handler for the POST /vehicles/{id}/sell endpoint.
+// @Summary      Sell a vehicle
+// @Description  Moves an available or reserved vehicle to the SOLD status.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        id   path      string  true  "Vehicle ID"
+// @Success      200  {object}  dto.OutputVehicleDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      409  {object}  dto.ProblemDetailsDTO "Invalid status transition"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/sell [post]
+func (vuc *vehicleUseCase) Sell(ctx context.Context, id string) (*dto.OutputVehicleDTO, error) {
+	return vuc.changeStatus(ctx, id, domain.StatusSold)
+}
+
+// Release is the handler for the POST /vehicles/{id}/release endpoint.
+// @Summary      Release a vehicle reservation
+// @Description  Moves a reserved vehicle back to the AVAILABLE status.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        id   path      string  true  "Vehicle ID"
+// @Success      200  {object}  dto.OutputVehicleDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      409  {object}  dto.ProblemDetailsDTO "Invalid status transition"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/release [post]
+func (vuc *vehicleUseCase) Release(ctx context.Context, id string) (*dto.OutputVehicleDTO, error) {
+	return vuc.changeStatus(ctx, id, domain.StatusAvailable)
+}
+
+func (vuc *vehicleUseCase) changeStatus(ctx context.Context, id string, status domain.VehicleStatus) (*dto.OutputVehicleDTO, error) {
+	var vehicle *domain.Vehicle
+	err := vuc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		vehicle, err = vuc.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		before := *vehicle
+		err = transitionStatus(vehicle, status)
+		if err != nil {
+			return err
+		}
+		vehicle.UpdatedAt = time.Now()
+
+		err = vuc.repo.UpdateStatus(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+
+		err = vuc.recordHistory(ctx, domain.HistoryUpdate, vehicle.ID, &before, vehicle)
+		if err != nil {
+			return err
+		}
+
+		return vuc.publish(ctx, statusEvents[status], vehicle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputVehicleDTO(vehicle)
+	return &output, nil
+}
+
+var statusEvents = map[domain.VehicleStatus]domain.EventType{
+	domain.StatusReserved:  domain.EventVehicleReserved,
+	domain.StatusSold:      domain.EventVehicleSold,
+	domain.StatusAvailable: domain.EventVehicleReleased,
+}
+
+// History is the handler for the GET /vehicles/{id}/history endpoint.
+// @Summary      Get the change history of a vehicle
+// @Description  Returns a page of the audit trail of a vehicle, newest first: every creation, update and deletion with the vehicle before and after the change, who made it and when. The history of deleted vehicles remains available.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        id      path      string  true   "Vehicle ID"
+// @Param        limit   query     int     false  "Maximum number of entries to return (default 20, max 100)"
+// @Param        offset  query     int     false  "Number of entries to skip"
+// @Success      200     {object}  dto.OutputListVehicleHistoryDTO
+// @Failure      400     {object}  dto.ProblemDetailsDTO "Invalid ID or pagination parameters"
+// @Failure      404     {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      500     {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/history [get]
+func (vuc *vehicleUseCase) History(ctx context.Context, id string, input dto.InputListVehicleHistoryDTO) (*dto.OutputListVehicleHistoryDTO, error) {
+	limit, offset := normalizePage(input.Limit, input.Offset)
+
+	entries, total, err := vuc.history.ListByVehicle(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		// Vehicles created before auditing existed have no history yet.
+		_, err = vuc.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]dto.OutputVehicleHistoryDTO, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, dto.OutputVehicleHistoryDTO{
+			ID:        entry.ID,
+			Action:    string(entry.Action),
+			Before:    entry.Before,
+			After:     entry.After,
+			Actor:     entry.Actor,
+			ChangedAt: entry.ChangedAt.Format(time.RFC3339),
+		})
+	}
+
+	output := &dto.OutputListVehicleHistoryDTO{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	return output, nil
+}
+
+// Prices is the handler for the GET /vehicles/{id}/prices endpoint.
+// @Summary      Get the price timeline of a vehicle
+// @Description  Returns the asking prices of a vehicle, oldest first, with when each one took effect, who set it and how many days the vehicle stayed at it. The last period is the current price.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        id   path      string  true  "Vehicle ID"
+// @Success      200  {object}  dto.OutputVehiclePricesDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Vehicle not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/{id}/prices [get]
+func (vuc *vehicleUseCase) Prices(ctx context.Context, id string) (*dto.OutputVehiclePricesDTO, error) {
 	vehicle, err := vuc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := vuc.prices.ListByVehicle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := pricePeriods(vehicle, changes, time.Now())
+	output := &dto.OutputVehiclePricesDTO{
+		VehicleID:    vehicle.ID,
+		InitialPrice: items[0].Price,
+		CurrentPrice: vehicle.Price,
+		Currency:     vehicle.Price.Currency(),
+		Items:        items,
+	}
+
+	return output, nil
+}
+
+// PriceStats is the handler for the GET /vehicles/price-stats endpoint.
+// @Summary      Get price statistics by brand and model
+// @Description  Reports, for each brand and model of the catalog, the average days on market and the average discount from the first asking price. Days on market run until the sale, or until now for vehicles still on sale.
+// @Tags         Vehicles
+// @Produce      json
+// @Param        brand  query     string  false  "Filter by brand (case-insensitive)"
+// @Param        model  query     string  false  "Filter by model (case-insensitive)"
+// @Success      200    {object}  dto.OutputListPriceStatsDTO
+// @Failure      500    {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /vehicles/price-stats [get]
+func (vuc *vehicleUseCase) PriceStats(ctx context.Context, input dto.InputPriceStatsDTO) (*dto.OutputListPriceStatsDTO, error) {
+	filter := repository.PriceStatsFilter{
+		Brand: input.Brand,
+		Model: input.Model,
+	}
+
+	stats, err := vuc.prices.Stats(ctx, filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.OutputPriceStatsDTO, 0, len(stats))
+	for _, s := range stats {
+		items = append(items, dto.OutputPriceStatsDTO{
+			Brand:              s.Brand,
+			Model:              s.Model,
+			Currency:           s.Currency,
+			Vehicles:           s.Vehicles,
+			Sold:               s.Sold,
+			PriceChanges:       s.PriceChanges,
+			AvgDaysOnMarket:    round2(s.AvgDaysOnMarket),
+			AvgDiscount:        s.AvgDiscount,
+			AvgDiscountPercent: round2(s.AvgDiscountPercent),
+		})
+	}
+
+	return &dto.OutputListPriceStatsDTO{Items: items}, nil
+}
+
+// pricePeriods turns the price changes of vehicle into the periods it spent
+// at each price. The first period starts at the creation of the vehicle with
+// the price before the first change; the last one is still open at now.
+func pricePeriods(vehicle *domain.Vehicle, changes []*domain.PriceChange, now time.Time) []dto.OutputPricePeriodDTO {
+	initial := vehicle.Price
+	if len(changes) > 0 {
+		initial = changes[0].PreviousPrice
+	}
+
+	periods := make([]dto.OutputPricePeriodDTO, 0, len(changes)+1)
+	periods = append(periods, dto.OutputPricePeriodDTO{
+		Price:    initial,
+		Currency: initial.Currency(),
+		From:     vehicle.CreatedAt.Format(time.RFC3339),
+	})
+
+	from := vehicle.CreatedAt
+	for _, change := range changes {
+		last := &periods[len(periods)-1]
+		last.Until = change.ChangedAt.Format(time.RFC3339)
+		last.Days = daysBetween(from, change.ChangedAt)
+
+		previous := change.PreviousPrice
+		periods = append(periods, dto.OutputPricePeriodDTO{
+			Price:         change.Price,
+			PreviousPrice: &previous,
+			Currency:      change.Price.Currency(),
+			Actor:         change.Actor,
+			From:          change.ChangedAt.Format(time.RFC3339),
+		})
+		from = change.ChangedAt
+	}
+	periods[len(periods)-1].Days = daysBetween(from, now)
+
+	return periods
+}
+
+func daysBetween(from, until time.Time) float64 {
+	return round2(until.Sub(from).Hours() / 24)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// recordPriceChange stores the change of the asking price between before and
+// after, if any, attributed to the actor of ctx.
+func (vuc *vehicleUseCase) recordPriceChange(ctx context.Context, before, after *domain.Vehicle) error {
+	if before.Price == after.Price {
+		return nil
+	}
+
+	change := &domain.PriceChange{
+		ID:            uuid.New().String(),
+		VehicleID:     after.ID,
+		PreviousPrice: before.Price,
+		Price:         after.Price,
+		Actor:         ActorFromContext(ctx),
+		ChangedAt:     after.UpdatedAt,
+	}
+
+	return vuc.prices.Add(ctx, change)
+}
+
+// recordHistory stores the audit entry of a change, attributed to the actor
+// of ctx. before is nil on creation and after is nil on deletion.
+func (vuc *vehicleUseCase) recordHistory(ctx context.Context, action domain.HistoryAction, vehicleID string, before, after *domain.Vehicle) error {
+	entry := &domain.VehicleHistoryEntry{
+		ID:        uuid.New().String(),
+		VehicleID: vehicleID,
+		Action:    action,
+		Actor:     ActorFromContext(ctx),
+		ChangedAt: time.Now(),
+	}
+
+	var err error
+	if before != nil {
+		entry.Before, err = json.Marshal(toOutputVehicleDTO(before))
+		if err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		entry.After, err = json.Marshal(toOutputVehicleDTO(after))
+		if err != nil {
+			return err
+		}
+	}
+
+	return vuc.history.Add(ctx, entry)
+}
+
+// publish emits an event carrying a snapshot of vehicle. It must run inside
+// the transaction that persists the change it describes.
+func (vuc *vehicleUseCase) publish(ctx context.Context, eventType domain.EventType, vehicle *domain.Vehicle) error {
+	snapshot := toOutputVehicleDTO(vehicle)
+	if eventType != domain.EventVehicleCreated {
+		images, err := vuc.images.Images(ctx, vehicle.ID)
+		if err != nil {
+			return err
+		}
+		snapshot.Images = images
+	}
+
+	event, err := newVehicleEvent(eventType, snapshot)
 	if err != nil {
 		return err
 	}
 
-	err = utils.ValidateVehicleFields(input.Brand, input.Model, input.Year, input.Price)
+	return vuc.publisher.Publish(ctx, event)
+}
+
+// newVehicleEvent wraps a vehicle snapshot in an event of the current schema.
+func newVehicleEvent(eventType domain.EventType, snapshot dto.OutputVehicleDTO) (domain.Event, error) {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return domain.Event{}, err
+	}
+
+	return domain.Event{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		Version:     domain.EventSchemaVersion,
+		AggregateID: snapshot.ID,
+		OccurredAt:  time.Now().UTC(),
+		Payload:     payload,
+	}, nil
+}
+
+// convertPrice sets the price of output converted to currency, if any. A
+// currency without a known rate, or in which the price is out of range, is
+// reported as a validation error.
+func (vuc *vehicleUseCase) convertPrice(ctx context.Context, output *dto.OutputVehicleDTO, currency string) error {
+	if currency == "" {
+		return nil
+	}
+
+	rate, err := vuc.rates.Rate(ctx, output.Currency, currency)
+	if errors.Is(err, domain.ErrUnsupportedCurrency) {
+		return domain.ValidationErrors{{
+			Field:   "currency",
+			Code:    domain.CodeInvalid,
+			Message: fmt.Sprintf("cannot convert %s prices to %s", output.Currency, currency),
+		}}
+	}
 	if err != nil {
 		return err
 	}
 
-	vehicle.Brand = input.Brand
-	vehicle.Model = input.Model
-	vehicle.Color = input.Color
-	vehicle.Year = input.Year
-	vehicle.Price = input.Price
-	vehicle.UpdatedAt = time.Now()
+	price, err := output.Price.Convert(rate, currency)
+	if errors.Is(err, domain.ErrInvalidMoney) {
+		return domain.ValidationErrors{{
+			Field:   "currency",
+			Code:    domain.CodeOutOfRange,
+			Message: fmt.Sprintf("price of %s %s cannot be expressed in %s", output.Price, output.Currency, currency),
+		}}
+	}
+	if err != nil {
+		return err
+	}
 
-	err = vuc.repo.Update(ctx, vehicle)
+	output.Converted = &dto.ConvertedPriceDTO{
+		Price:    price,
+		Currency: currency,
+	}
+	return nil
+}
+
+// checkDuplicate fails with a DuplicateVehicleError when another vehicle
+// already holds the VIN, plate or RENAVAM of vehicle.
+func (vuc *vehicleUseCase) checkDuplicate(ctx context.Context, vehicle *domain.Vehicle) error {
+	if vehicle.VIN == "" && vehicle.Plate == "" && vehicle.Renavam == "" {
+		return nil
+	}
+
+	duplicate, err := vuc.repo.FindDuplicate(ctx, vehicle)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
-	listingDTO := dto.UpdateListingDTO{
-		Brand: vehicle.Brand,
-		Model: vehicle.Model,
-		Price: vehicle.Price,
+	field := "renavam"
+	switch {
+	case vehicle.VIN != "" && duplicate.VIN == vehicle.VIN:
+		field = "vin"
+	case vehicle.Plate != "" && duplicate.Plate == vehicle.Plate:
+		field = "plate"
 	}
+	return &domain.DuplicateVehicleError{Field: field, VehicleID: duplicate.ID}
+}
 
-	err = vuc.showcaseClient.UpdateListing(ctx, vehicle.ID, listingDTO)
+// decodeVIN fills the brand and year left blank from the VIN of vehicle and,
+// unless override is set, reports the ones that contradict it. VINs that do
+// not decode are left to the validation.
+func decodeVIN(vehicle *domain.Vehicle, override bool) domain.ValidationErrors {
+	if vehicle.VIN == "" {
+		return nil
+	}
+	info, err := vin.Decode(vehicle.VIN)
 	if err != nil {
-		log.Printf("Warning: failed to notify showcase-service about vehicle update %s: %v", vehicle.ID, err)
+		return nil
+	}
+
+	var mismatches domain.ValidationErrors
+	switch {
+	case info.Brand == "":
+	case strings.TrimSpace(vehicle.Brand) == "":
+		vehicle.Brand = info.Brand
+	case !override && !strings.EqualFold(strings.TrimSpace(vehicle.Brand), info.Brand):
+		mismatches = append(mismatches, domain.FieldError{
+			Field:   "brand",
+			Code:    domain.CodeVINMismatch,
+			Message: fmt.Sprintf("brand does not match the VIN manufacturer %s", info.Manufacturer),
+		})
 	}
 
+	// An ambiguous year code most likely stands for a vehicle already made.
+	modelYear := info.ModelYear(time.Now().Year())
+	switch {
+	case vehicle.Year == 0:
+		vehicle.Year = modelYear
+	case !override && !info.HasModelYear(vehicle.Year):
+		mismatches = append(mismatches, domain.FieldError{
+			Field:   "year",
+			Code:    domain.CodeVINMismatch,
+			Message: fmt.Sprintf("year does not match the VIN model year %d", modelYear),
+		})
+	}
+	return mismatches
+}
+
+func identifiersChanged(before, after *domain.Vehicle) bool {
+	return before.VIN != after.VIN || before.Plate != after.Plate || before.Renavam != after.Renavam
+}
+
+// catalogChanged reports whether the brand or model was changed to something
+// other than a different spelling of the stored one.
+func catalogChanged(before, after *domain.Vehicle) bool {
+	return domain.CatalogKey(before.Brand) != domain.CatalogKey(after.Brand) ||
+		domain.CatalogKey(before.Model) != domain.CatalogKey(after.Model)
+}
+
+// resolveCatalog replaces the brand and model of vehicle with their canonical
+// names. Vehicles stored before the catalog existed keep their names until
+// either one is changed.
+func (vuc *vehicleUseCase) resolveCatalog(ctx context.Context, vehicle *domain.Vehicle) error {
+	brand, model, err := vuc.catalog.Resolve(ctx, vehicle.Brand, vehicle.Model)
+	if err != nil {
+		return err
+	}
+
+	vehicle.Brand, vehicle.Model = brand, model
+	return nil
+}
+
+// enumValue reads an optional enumerated value, case-insensitively.
+func enumValue[T ~string](value string) T {
+	return T(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+// normalizeCurrency reads a currency code sent in a request body,
+// case-insensitively.
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+func currencyOrDefault(currency string) string {
+	currency = normalizeCurrency(currency)
+	if currency == "" {
+		return domain.DefaultCurrency
+	}
+	return currency
+}
+
+func checkVersion(vehicle *domain.Vehicle, expectedVersion int) error {
+	if expectedVersion != 0 && vehicle.Version != expectedVersion {
+		return fmt.Errorf("%w: vehicle %s is at version %d, not %d", domain.ErrPreconditionFailed, vehicle.ID, vehicle.Version, expectedVersion)
+	}
 	return nil
 }
+
+func toOutputVehicleDTO(vehicle *domain.Vehicle) dto.OutputVehicleDTO {
+	return dto.OutputVehicleDTO{
+		ID:                 vehicle.ID,
+		Brand:              vehicle.Brand,
+		Model:              vehicle.Model,
+		Year:               vehicle.Year,
+		Color:              vehicle.Color,
+		Price:              vehicle.Price,
+		Currency:           vehicle.Price.Currency(),
+		VIN:                vehicle.VIN,
+		Plate:              vehicle.Plate,
+		Renavam:            vehicle.Renavam,
+		Mileage:            vehicle.Mileage,
+		FuelType:           string(vehicle.FuelType),
+		Transmission:       string(vehicle.Transmission),
+		BodyType:           string(vehicle.BodyType),
+		Doors:              vehicle.Doors,
+		EngineDisplacement: vehicle.EngineDisplacement,
+		Condition:          string(vehicle.Condition),
+		Status:             string(vehicle.Status),
+		Version:            vehicle.Version,
+		CreatedAt:          vehicle.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          vehicle.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -2,13 +2,17 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
+	"math/big"
 	"testing"
+	"time"
 
-	mclient "github.com/NicolasNSC/catalog-service-fiap/internal/client/mocks"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	musecase "github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -17,9 +21,15 @@ import (
 type VehicleUseCaseSuite struct {
 	suite.Suite
 
-	ctx            context.Context
-	repository     *mocks.MockVehicleRepository
-	showcaseClient *mclient.MockShowcaseClientInterface
+	ctx        context.Context
+	repository *mocks.MockVehicleRepository
+	history    *mocks.MockVehicleHistoryRepository
+	prices     *mocks.MockVehiclePriceRepository
+	transactor *mocks.MockTransactor
+	publisher  *musecase.MockEventPublisher
+	rates      *musecase.MockExchangeRateProvider
+	catalog    *musecase.MockCatalogResolver
+	images     *musecase.MockVehicleImageLister
 }
 
 func (suite *VehicleUseCaseSuite) BeforeTest(_, _ string) {
@@ -27,7 +37,30 @@ func (suite *VehicleUseCaseSuite) BeforeTest(_, _ string) {
 	defer ctrl.Finish()
 	suite.ctx = context.Background()
 	suite.repository = mocks.NewMockVehicleRepository(ctrl)
-	suite.showcaseClient = mclient.NewMockShowcaseClientInterface(ctrl)
+	suite.history = mocks.NewMockVehicleHistoryRepository(ctrl)
+	suite.prices = mocks.NewMockVehiclePriceRepository(ctrl)
+	suite.transactor = mocks.NewMockTransactor(ctrl)
+	suite.publisher = musecase.NewMockEventPublisher(ctrl)
+	suite.rates = musecase.NewMockExchangeRateProvider(ctrl)
+	suite.catalog = musecase.NewMockCatalogResolver(ctrl)
+	suite.images = musecase.NewMockVehicleImageLister(ctrl)
+
+	suite.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	suite.catalog.EXPECT().
+		Resolve(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, brand, model string) (string, string, error) {
+			return brand, model, nil
+		}).
+		AnyTimes()
+	suite.images.EXPECT().
+		Images(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
 }
 
 func Test_VehicleUseCaseSuite(t *testing.T) {
@@ -35,20 +68,58 @@ func Test_VehicleUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(VehicleUseCaseSuite))
 }
 
+// vehicleEvent matches a versioned event of the given type whose vehicle
+// snapshot satisfies check.
+// brl returns a whole amount of reais.
+func brl(amount int64) domain.Money {
+	return domain.NewMoney(amount*100, "BRL")
+}
+
+// decodeVehicle decodes a vehicle snapshot, attaching its currency to the
+// price the way the catalog reads it back.
+func decodeVehicle(data []byte) (*dto.OutputVehicleDTO, error) {
+	var v dto.OutputVehicleDTO
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	v.Price = v.Price.WithCurrency(v.Currency)
+	return &v, nil
+}
+
+func vehicleEvent(eventType domain.EventType, check func(v dto.OutputVehicleDTO) bool) gomock.Matcher {
+	return gomock.Cond(func(e domain.Event) bool {
+		v, err := decodeVehicle(e.Payload)
+		if err != nil {
+			return false
+		}
+		return e.Type == eventType && e.ID != "" && e.Version == domain.EventSchemaVersion &&
+			!e.OccurredAt.IsZero() && e.AggregateID == v.ID && check(*v)
+	})
+}
+
 func (suite *VehicleUseCaseSuite) Test_Create() {
 	input := dto.InputCreateVehicleDTO{
 		Brand: "Toyota",
 		Model: "Corolla",
 		Year:  2022,
 		Color: "White",
-		Price: 100000,
+		Price: brl(100000),
 	}
 
 	suite.T().Run("should create a vehicle successfully", func(t *testing.T) {
-		suite.repository.EXPECT().Save(suite.ctx, gomock.Any()).Return(nil)
-		suite.showcaseClient.EXPECT().CreateListing(suite.ctx, gomock.Any()).Return(nil)
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Status == domain.StatusAvailable
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleCreated, func(v dto.OutputVehicleDTO) bool {
+				return v.Status == "AVAILABLE" && v.Brand == "Toyota"
+			})).
+			Return(nil)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
 		output, err := usecase.Create(suite.ctx, input)
 		suite.NoError(err)
 		suite.NotNil(output)
@@ -56,18 +127,37 @@ func (suite *VehicleUseCaseSuite) Test_Create() {
 		suite.NotEmpty(output.CreatedAt)
 	})
 
+	suite.T().Run("should read the currency case-insensitively", func(t *testing.T) {
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Price == domain.NewMoney(10000000, "USD")
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		input := input
+		input.Currency = " usd "
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Create(suite.ctx, input)
+		suite.NoError(err)
+	})
+
 	suite.T().Run("should return error when input validation fails", func(t *testing.T) {
 		input := dto.InputCreateVehicleDTO{
 			Brand: "",
 			Model: "",
 			Year:  0,
 			Color: "",
-			Price: 0,
+			Price: brl(0),
 		}
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
 		output, err := usecase.Create(suite.ctx, input)
-		suite.Error(err)
+		suite.ErrorIs(err, domain.ErrValidation)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 5)
 		suite.Nil(output)
 	})
 
@@ -76,24 +166,165 @@ func (suite *VehicleUseCaseSuite) Test_Create() {
 			Save(gomock.Any(), gomock.Any()).
 			Return(assert.AnError)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
 		output, err := usecase.Create(suite.ctx, input)
 		suite.Error(err)
 		suite.Nil(output)
 	})
 
-	suite.T().Run("should log warning when showcase client fails but still create vehicle", func(t *testing.T) {
+	suite.T().Run("should return error when publishing the event fails", func(t *testing.T) {
 		suite.repository.EXPECT().Save(suite.ctx, gomock.Any()).Return(nil)
-		suite.showcaseClient.EXPECT().CreateListing(suite.ctx, gomock.Any()).Return(assert.AnError)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Create(suite.ctx, input)
+		suite.ErrorIs(err, assert.AnError)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should normalize identifiers before saving", func(t *testing.T) {
+		input := input
+		input.Brand = "Volkswagen"
+		input.Year = 1997
+		input.VIN = " 9bwzzz377vt004251 "
+		input.Plate = "bra-2e19"
+		input.Renavam = "639884962"
+
+		suite.repository.EXPECT().
+			FindDuplicate(suite.ctx, gomock.Any()).
+			Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.VIN == "9BWZZZ377VT004251" && v.Plate == "BRA2E19" && v.Renavam == "00639884962"
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
 		output, err := usecase.Create(suite.ctx, input)
 		suite.NoError(err)
-		suite.NotNil(output)
 		suite.NotEmpty(output.ID)
-		suite.NotEmpty(output.CreatedAt)
 	})
 
+	suite.T().Run("should return a conflict when an identifier is already registered", func(t *testing.T) {
+		input := input
+		input.VIN = "9BWZZZ377VT004251"
+		input.Plate = "BRA2E19"
+		input.OverrideVIN = true
+
+		suite.repository.EXPECT().
+			FindDuplicate(suite.ctx, gomock.Any()).
+			Return(&domain.Vehicle{ID: "existing-1", Plate: "BRA2E19"}, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Create(suite.ctx, input)
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrConflict)
+		var duplicateErr *domain.DuplicateVehicleError
+		suite.ErrorAs(err, &duplicateErr)
+		suite.Equal("plate", duplicateErr.Field)
+		suite.Equal("existing-1", duplicateErr.VehicleID)
+	})
+
+	suite.T().Run("should save the specification attributes", func(t *testing.T) {
+		input := input
+		input.Mileage = 12000
+		input.FuelType = "hybrid"
+		input.Transmission = "CVT"
+		input.BodyType = "sedan"
+		input.Doors = 4
+		input.EngineDisplacement = 1798
+		input.Condition = "used"
+
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Mileage == 12000 && v.FuelType == domain.FuelHybrid && v.Transmission == domain.TransmissionCVT &&
+					v.BodyType == domain.BodySedan && v.Doors == 4 && v.EngineDisplacement == 1798 && v.Condition == domain.ConditionUsed
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleCreated, func(v dto.OutputVehicleDTO) bool {
+				return v.FuelType == "HYBRID" && v.Mileage == 12000
+			})).
+			Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Create(suite.ctx, input)
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should fill the brand and year from the VIN", func(t *testing.T) {
+		input := input
+		input.Brand = ""
+		input.Year = 0
+		input.VIN = "9BWZZZ3775P004251"
+
+		suite.repository.EXPECT().FindDuplicate(suite.ctx, gomock.Any()).Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Brand == "Volkswagen" && v.Year == 2005
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Create(suite.ctx, input)
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should reject a brand and year contradicting the VIN", func(t *testing.T) {
+		input := input
+		input.VIN = "9BWZZZ377VT004251"
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Create(suite.ctx, input)
+		suite.Nil(output)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 2)
+		suite.Equal("brand", validationErrs[0].Field)
+		suite.Equal(domain.CodeVINMismatch, validationErrs[0].Code)
+		suite.Equal("year", validationErrs[1].Field)
+	})
+
+	suite.T().Run("should report the VIN mismatches along with the other validation errors", func(t *testing.T) {
+		input := input
+		input.VIN = "9BWZZZ377VT004251"
+		input.Color = ""
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Create(suite.ctx, input)
+		suite.Nil(output)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 3)
+		suite.Equal("color", validationErrs[0].Field)
+		suite.Equal("brand", validationErrs[1].Field)
+		suite.Equal("year", validationErrs[2].Field)
+	})
+
+	suite.T().Run("should accept a contradicting brand and year with the override", func(t *testing.T) {
+		input := input
+		input.VIN = "9BWZZZ377VT004251"
+		input.OverrideVIN = true
+
+		suite.repository.EXPECT().FindDuplicate(suite.ctx, gomock.Any()).Return(nil, domain.ErrNotFound)
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Brand == "Toyota" && v.Year == 2022
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Create(suite.ctx, input)
+		suite.NoError(err)
+	})
 }
 
 func (suite *VehicleUseCaseSuite) Test_Update() {
@@ -103,7 +334,7 @@ func (suite *VehicleUseCaseSuite) Test_Update() {
 		Model: "Focus",
 		Year:  2023,
 		Color: "Blue",
-		Price: 120000,
+		Price: brl(120000),
 	}
 	existingVehicle := &domain.Vehicle{
 		ID:    id,
@@ -111,17 +342,54 @@ func (suite *VehicleUseCaseSuite) Test_Update() {
 		Model: "Fiesta",
 		Year:  2020,
 		Color: "Red",
-		Price: 80000,
+		Price: brl(80000),
 	}
 
 	suite.T().Run("should update a vehicle successfully", func(t *testing.T) {
-		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleUpdated, func(v dto.OutputVehicleDTO) bool {
+				return v.ID == id && v.Model == "Focus" && v.Price == brl(120000)
+			})).
+			Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(suite.ctx, id, 0, input)
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should keep the currency of the vehicle when none is given", func(t *testing.T) {
+		usdVehicle := &domain.Vehicle{ID: id, Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: domain.NewMoney(1600000, "USD")}
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(usdVehicle, nil)
 		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
-		suite.showcaseClient.EXPECT().UpdateListing(suite.ctx, id, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
-		err := usecase.Update(suite.ctx, id, input)
+		input := input
+		input.Price = domain.NewMoney(1500000, "")
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 0, input)
 		suite.NoError(err)
+		suite.Equal(domain.NewMoney(1500000, "USD"), output.Price)
+	})
+
+	suite.T().Run("should read the currency case-insensitively", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		input := input
+		input.Currency = "eur "
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 0, input)
+		suite.NoError(err)
+		suite.Equal(domain.NewMoney(12000000, "EUR"), output.Price)
 	})
 
 	suite.T().Run("should return error when input validation fails", func(t *testing.T) {
@@ -130,48 +398,1062 @@ func (suite *VehicleUseCaseSuite) Test_Update() {
 			Model: "",
 			Year:  0,
 			Color: "",
-			Price: 0,
+			Price: brl(0),
 		}
 
 		suite.repository.EXPECT().
-			GetByID(suite.ctx, id).
+			GetByIDForUpdate(suite.ctx, id).
 			Return(existingVehicle, nil)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
-		err := usecase.Update(suite.ctx, id, input)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(suite.ctx, id, 0, input)
 		suite.Error(err)
 	})
 
 	suite.T().Run("should return error when vehicle not found", func(t *testing.T) {
 		suite.repository.EXPECT().
-			GetByID(suite.ctx, id).
+			GetByIDForUpdate(suite.ctx, id).
 			Return(nil, assert.AnError)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
-		err := usecase.Update(suite.ctx, id, input)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(suite.ctx, id, 0, input)
 		suite.Error(err)
 	})
 
 	suite.T().Run("should return error when repository update fails", func(t *testing.T) {
 		suite.repository.EXPECT().
-			GetByID(suite.ctx, id).
+			GetByIDForUpdate(suite.ctx, id).
 			Return(existingVehicle, nil)
 		suite.repository.EXPECT().
 			Update(suite.ctx, gomock.Any()).
 			Return(assert.AnError)
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
-		err := usecase.Update(suite.ctx, id, input)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(suite.ctx, id, 0, input)
 		suite.Error(err)
 	})
 
-	suite.T().Run("should log warning when showcase client fails but still update vehicle", func(t *testing.T) {
+	suite.T().Run("should update when expected version matches", func(t *testing.T) {
+		versioned := *existingVehicle
+		versioned.Version = 3
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(&versioned, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 3, input)
+		suite.NoError(err)
+		suite.NotNil(output)
+	})
+
+	suite.T().Run("should return precondition failed when expected version is stale", func(t *testing.T) {
+		versioned := *existingVehicle
+		versioned.Version = 4
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(&versioned, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 3, input)
+		suite.ErrorIs(err, domain.ErrPreconditionFailed)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return conflict when the vehicle changes concurrently", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(domain.ErrConflict)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 0, input)
+		suite.ErrorIs(err, domain.ErrConflict)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should not publish an event when the vehicle disappears before the update", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(domain.ErrNotFound)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 0, input)
+		suite.ErrorIs(err, domain.ErrNotFound)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when publishing the event fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Update(suite.ctx, id, 0, input)
+		suite.ErrorIs(err, assert.AnError)
+		suite.Nil(output)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_GetByID() {
+	id := "vehicle-123"
+	now := time.Now()
+	existingVehicle := &domain.Vehicle{
+		ID:        id,
+		Brand:     "Ford",
+		Model:     "Fiesta",
+		Year:      2020,
+		Color:     "Red",
+		Price:     brl(80000),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	suite.T().Run("should get a vehicle successfully", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.GetByID(suite.ctx, id, "")
+		suite.NoError(err)
+		suite.Equal(&dto.OutputVehicleDTO{
+			ID:        id,
+			Brand:     "Ford",
+			Model:     "Fiesta",
+			Year:      2020,
+			Color:     "Red",
+			Price:     brl(80000),
+			Currency:  "BRL",
+			CreatedAt: now.Format(time.RFC3339),
+			UpdatedAt: now.Format(time.RFC3339),
+		}, output)
+	})
+
+	suite.T().Run("should convert the price to the requested currency", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "USD").Return(big.NewRat(1851, 10000), nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.GetByID(suite.ctx, id, "USD")
+		suite.NoError(err)
+		suite.Equal(brl(80000), output.Price)
+		suite.Equal(&dto.ConvertedPriceDTO{Price: domain.NewMoney(1480800, "USD"), Currency: "USD"}, output.Converted)
+	})
+
+	suite.T().Run("should include the images of the vehicle", func(t *testing.T) {
+		images := []dto.OutputVehicleImageDTO{{ID: "image-1", URL: "http://localhost:8080/media/vehicles/vehicle-123/image-1.jpg", Cover: true}}
+		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+		lister := musecase.NewMockVehicleImageLister(gomock.NewController(t))
+		lister.EXPECT().Images(suite.ctx, id).Return(images, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, lister)
+		output, err := usecase.GetByID(suite.ctx, id, "")
+		suite.NoError(err)
+		suite.Equal(images, output.Images)
+	})
+
+	suite.T().Run("should reject a currency without exchange rate", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "XYZ").Return(nil, domain.ErrUnsupportedCurrency)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.GetByID(suite.ctx, id, "XYZ")
+		suite.ErrorIs(err, domain.ErrValidation)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Equal("currency", validationErrs[0].Field)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should reject a currency in which the price is out of range", func(t *testing.T) {
 		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "VND").Return(big.NewRat(1<<62, 1), nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.GetByID(suite.ctx, id, "VND")
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Equal("currency", validationErrs[0].Field)
+		suite.Equal(domain.CodeOutOfRange, validationErrs[0].Code)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when the exchange rate cannot be read", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(existingVehicle, nil)
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "USD").Return(nil, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.GetByID(suite.ctx, id, "USD")
+		suite.ErrorIs(err, assert.AnError)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when vehicle not found", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, id).Return(nil, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.GetByID(suite.ctx, id, "")
+		suite.Error(err)
+		suite.Nil(output)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_List() {
+	vehicles := []*domain.Vehicle{
+		{ID: "1", Brand: "Ford", Model: "Fiesta", Year: 2020, Price: brl(80000)},
+		{ID: "2", Brand: "Honda", Model: "Civic", Year: 2021, Price: brl(120000)},
+	}
+
+	suite.T().Run("should list vehicles with the requested page", func(t *testing.T) {
+		suite.repository.EXPECT().
+			List(suite.ctx, repository.VehicleListParams{Limit: 2, Offset: 4}).
+			Return(vehicles, 10, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{Limit: 2, Offset: 4})
+		suite.NoError(err)
+		suite.Len(output.Items, 2)
+		suite.Equal("1", output.Items[0].ID)
+		suite.Equal(10, output.Total)
+		suite.Equal(2, output.Limit)
+		suite.Equal(4, output.Offset)
+	})
+
+	suite.T().Run("should pass filters and sort to the repository", func(t *testing.T) {
+		yearMin := 2020
+		priceMax := brl(100000)
+		expectedParams := repository.VehicleListParams{
+			Filter: repository.VehicleFilter{
+				Brand:    "Ford",
+				Color:    "Red",
+				YearMin:  &yearMin,
+				PriceMax: &priceMax,
+				Currency: "BRL",
+			},
+			Sort: repository.VehicleSort{
+				Field: repository.SortByPrice,
+				Order: repository.SortAsc,
+			},
+			Limit: 20,
+		}
+		suite.repository.EXPECT().
+			List(suite.ctx, expectedParams).
+			Return(vehicles[:1], 1, nil)
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "BRL").Return(big.NewRat(1, 1), nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{
+			Brand:     "Ford",
+			Color:     "Red",
+			YearMin:   &yearMin,
+			PriceMax:  &priceMax,
+			Currency:  "BRL",
+			SortBy:    "price",
+			SortOrder: "asc",
+		})
+		suite.NoError(err)
+		suite.Equal(1, output.Total)
+	})
+
+	suite.T().Run("should require a currency to filter or sort by price", func(t *testing.T) {
+		priceMin := brl(50000)
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+
+		for _, input := range []dto.InputListVehiclesDTO{{PriceMin: &priceMin}, {SortBy: "price"}} {
+			output, err := usecase.List(suite.ctx, input)
+			suite.Nil(output)
+			var validationErrs domain.ValidationErrors
+			suite.ErrorAs(err, &validationErrs)
+			suite.Equal("currency", validationErrs[0].Field)
+			suite.Equal(domain.CodeRequired, validationErrs[0].Code)
+		}
+	})
+
+	suite.T().Run("should pass specification filters case-insensitively", func(t *testing.T) {
+		mileageMax, doors := 50000, 4
+		expectedParams := repository.VehicleListParams{
+			Filter: repository.VehicleFilter{
+				MileageMax:   &mileageMax,
+				FuelType:     domain.FuelFlex,
+				Transmission: domain.TransmissionAutomatic,
+				Doors:        &doors,
+				Condition:    domain.ConditionUsed,
+			},
+			Limit: 20,
+		}
+		suite.repository.EXPECT().
+			List(suite.ctx, expectedParams).
+			Return(vehicles[:1], 1, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{
+			MileageMax:   &mileageMax,
+			FuelType:     "flex",
+			Transmission: " Automatic ",
+			Doors:        &doors,
+			Condition:    "USED",
+		})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should reject unknown enumerated filters", func(t *testing.T) {
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{FuelType: "steam", BodyType: "limo"})
+		suite.Nil(output)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 2)
+		suite.Equal("fuel_type", validationErrs[0].Field)
+		suite.Equal("body_type", validationErrs[1].Field)
+	})
+
+	suite.T().Run("should convert every price to the requested currency", func(t *testing.T) {
+		suite.repository.EXPECT().
+			List(suite.ctx, repository.VehicleListParams{Limit: 20}).
+			Return(vehicles, 2, nil)
+		suite.rates.EXPECT().Rate(suite.ctx, "BRL", "EUR").Return(big.NewRat(1, 6), nil).Times(2)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{Currency: "EUR"})
+		suite.NoError(err)
+		suite.Equal(domain.NewMoney(1333333, "EUR"), output.Items[0].Converted.Price)
+		suite.Equal(domain.NewMoney(2000000, "EUR"), output.Items[1].Converted.Price)
+	})
+
+	suite.T().Run("should apply default limit", func(t *testing.T) {
+		suite.repository.EXPECT().
+			List(suite.ctx, repository.VehicleListParams{Limit: 20, Offset: 0}).
+			Return([]*domain.Vehicle{}, 0, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{})
+		suite.NoError(err)
+		suite.Empty(output.Items)
+		suite.Equal(20, output.Limit)
+	})
+
+	suite.T().Run("should cap limit to the maximum", func(t *testing.T) {
+		suite.repository.EXPECT().
+			List(suite.ctx, repository.VehicleListParams{Limit: 100, Offset: 0}).
+			Return([]*domain.Vehicle{}, 0, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{Limit: 1000, Offset: -1})
+		suite.NoError(err)
+		suite.Equal(100, output.Limit)
+		suite.Equal(0, output.Offset)
+	})
+
+	suite.T().Run("should return error when repository list fails", func(t *testing.T) {
+		suite.repository.EXPECT().
+			List(suite.ctx, gomock.Any()).
+			Return(nil, 0, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.List(suite.ctx, dto.InputListVehiclesDTO{})
+		suite.Error(err)
+		suite.Nil(output)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_Delete() {
+	id := "vehicle-123"
+	existingVehicle := &domain.Vehicle{
+		ID:    id,
+		Brand: "Ford",
+		Model: "Fiesta",
+		Year:  2020,
+		Color: "Red",
+		Price: brl(80000),
+	}
+
+	suite.T().Run("should delete a vehicle successfully", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Delete(suite.ctx, id, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleDeleted, func(v dto.OutputVehicleDTO) bool { return v.ID == id })).
+			Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		err := usecase.Delete(suite.ctx, id)
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should return error when vehicle not found", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(nil, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		err := usecase.Delete(suite.ctx, id)
+		suite.Error(err)
+	})
+
+	suite.T().Run("should return error when repository delete fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Delete(suite.ctx, id, gomock.Any()).Return(assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		err := usecase.Delete(suite.ctx, id)
+		suite.Error(err)
+	})
+
+	suite.T().Run("should return error when publishing the event fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(existingVehicle, nil)
+		suite.repository.EXPECT().Delete(suite.ctx, id, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		err := usecase.Delete(suite.ctx, id)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_StatusTransitions() {
+	id := "vehicle-123"
+	newVehicle := func(status domain.VehicleStatus) *domain.Vehicle {
+		return &domain.Vehicle{
+			ID:     id,
+			Brand:  "Ford",
+			Model:  "Fiesta",
+			Year:   2020,
+			Price:  brl(80000),
+			Status: status,
+		}
+	}
+
+	actions := map[string]func(uc usecase.VehicleUseCaseInterface) (*dto.OutputVehicleDTO, error){
+		"reserve": func(uc usecase.VehicleUseCaseInterface) (*dto.OutputVehicleDTO, error) {
+			return uc.Reserve(suite.ctx, id)
+		},
+		"sell": func(uc usecase.VehicleUseCaseInterface) (*dto.OutputVehicleDTO, error) {
+			return uc.Sell(suite.ctx, id)
+		},
+		"release": func(uc usecase.VehicleUseCaseInterface) (*dto.OutputVehicleDTO, error) {
+			return uc.Release(suite.ctx, id)
+		},
+	}
+
+	tests := []struct {
+		name     string
+		from     domain.VehicleStatus
+		action   string
+		expected domain.VehicleStatus
+		event    domain.EventType
+		wantErr  bool
+	}{
+		{"reserve available vehicle", domain.StatusAvailable, "reserve", domain.StatusReserved, domain.EventVehicleReserved, false},
+		{"reserve reserved vehicle", domain.StatusReserved, "reserve", "", "", true},
+		{"reserve sold vehicle", domain.StatusSold, "reserve", "", "", true},
+		{"sell available vehicle", domain.StatusAvailable, "sell", domain.StatusSold, domain.EventVehicleSold, false},
+		{"sell reserved vehicle", domain.StatusReserved, "sell", domain.StatusSold, domain.EventVehicleSold, false},
+		{"sell sold vehicle", domain.StatusSold, "sell", "", "", true},
+		{"release reserved vehicle", domain.StatusReserved, "release", domain.StatusAvailable, domain.EventVehicleReleased, false},
+		{"release available vehicle", domain.StatusAvailable, "release", "", "", true},
+		{"release sold vehicle", domain.StatusSold, "release", "", "", true},
+	}
+
+	for _, tt := range tests {
+		suite.T().Run(tt.name, func(t *testing.T) {
+			suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(tt.from), nil)
+			if !tt.wantErr {
+				suite.repository.EXPECT().UpdateStatus(suite.ctx, gomock.Any()).Return(nil)
+				suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+				suite.publisher.EXPECT().
+					Publish(suite.ctx, vehicleEvent(tt.event, func(v dto.OutputVehicleDTO) bool {
+						return v.Status == string(tt.expected)
+					})).
+					Return(nil)
+			}
+
+			uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+			output, err := actions[tt.action](uc)
+			if tt.wantErr {
+				suite.ErrorIs(err, usecase.ErrInvalidStatusTransition)
+				suite.ErrorIs(err, domain.ErrConflict)
+				suite.Nil(output)
+				return
+			}
+			suite.NoError(err)
+			suite.Equal(string(tt.expected), output.Status)
+		})
+	}
+
+	suite.T().Run("should return error when vehicle not found", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(nil, assert.AnError)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Reserve(suite.ctx, id)
+		suite.Error(err)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when repository update fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(domain.StatusAvailable), nil)
+		suite.repository.EXPECT().UpdateStatus(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Sell(suite.ctx, id)
+		suite.Error(err)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when publishing the event fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(domain.StatusAvailable), nil)
+		suite.repository.EXPECT().UpdateStatus(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Reserve(suite.ctx, id)
+		suite.ErrorIs(err, assert.AnError)
+		suite.Nil(output)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_Patch() {
+	id := "vehicle-123"
+	newVehicle := func() *domain.Vehicle {
+		return &domain.Vehicle{
+			ID:     id,
+			Brand:  "Ford",
+			Model:  "Fiesta",
+			Year:   2020,
+			Color:  "",
+			Price:  brl(80000),
+			Status: domain.StatusAvailable,
+		}
+	}
+	price := func(amount int64) *domain.Money {
+		p := brl(amount)
+		return &p
+	}
+	text := func(s string) *string { return &s }
+
+	suite.T().Run("should patch only the price and publish the update", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Price == brl(75000) && v.Brand == "Ford" && v.Model == "Fiesta" && v.Year == 2020
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleUpdated, func(v dto.OutputVehicleDTO) bool {
+				return v.Price == brl(75000) && v.Brand == "Ford"
+			})).
+			Return(nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Price: price(75000)})
+		suite.NoError(err)
+		suite.Equal(brl(75000), output.Price)
+		suite.Equal("", output.Color)
+	})
+
+	suite.T().Run("should publish an update when only the color changes", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().
+			Publish(suite.ctx, vehicleEvent(domain.EventVehicleUpdated, func(v dto.OutputVehicleDTO) bool { return v.Color == "Black" })).
+			Return(nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Color: text("Black")})
+		suite.NoError(err)
+		suite.Equal("Black", output.Color)
+	})
+
+	suite.T().Run("should read the currency case-insensitively", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Currency: text(" Usd")})
+		suite.NoError(err)
+		suite.Equal(domain.NewMoney(8000000, "USD"), output.Price)
+	})
+
+	suite.T().Run("should skip the update when nothing changes", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Brand: text("Ford"), Price: price(80000)})
+		suite.NoError(err)
+		suite.Equal("Ford", output.Brand)
+	})
+
+	suite.T().Run("should validate only the changed fields", func(t *testing.T) {
+		year := 1900
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Model: text(""), Year: &year})
+		suite.Nil(output)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 2)
+		suite.Equal("model", validationErrs[0].Field)
+		suite.Equal("year", validationErrs[1].Field)
+	})
+
+	suite.T().Run("should patch and validate the specification attributes", func(t *testing.T) {
+		mileage, doors := -5, 4
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Mileage: &mileage, Doors: &doors, Transmission: text("sideways")})
+		suite.Nil(output)
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 2)
+		suite.Equal("mileage", validationErrs[0].Field)
+		suite.Equal("transmission", validationErrs[1].Field)
+
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Doors == 4 && v.Condition == domain.ConditionNew && v.Price == brl(80000)
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		output, err = uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Doors: &doors, Condition: text("new")})
+		suite.NoError(err)
+		suite.Equal("NEW", output.Condition)
+	})
+
+	suite.T().Run("should reject a plate already registered to another vehicle", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+		suite.repository.EXPECT().
+			FindDuplicate(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool { return v.Plate == "ABC1234" })).
+			Return(&domain.Vehicle{ID: "other-1"}, nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Plate: text("abc-1234")})
+		suite.Nil(output)
+		var duplicateErr *domain.DuplicateVehicleError
+		suite.ErrorAs(err, &duplicateErr)
+		suite.Equal("other-1", duplicateErr.VehicleID)
+	})
+
+	suite.T().Run("should return precondition failed when expected version is stale", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 7, dto.InputPatchVehicleDTO{Price: price(1)})
+		suite.ErrorIs(err, domain.ErrPreconditionFailed)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when vehicle not found", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(nil, assert.AnError)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Price: price(1)})
+		suite.Error(err)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when repository update fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Brand: text("Chevrolet")})
+		suite.Error(err)
+		suite.Nil(output)
+	})
+
+	suite.T().Run("should return error when publishing the event fails", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(newVehicle(), nil)
 		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
-		suite.showcaseClient.EXPECT().UpdateListing(suite.ctx, id, gomock.Any()).Return(assert.AnError)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Model: text("Focus")})
+		suite.ErrorIs(err, assert.AnError)
+		suite.Nil(output)
+	})
+}
 
-		usecase := usecase.NewVehicleUseCase(suite.repository, suite.showcaseClient)
-		err := usecase.Update(suite.ctx, id, input)
+// historyEntry matches an audit entry of the given action whose snapshots
+// satisfy check. A missing snapshot is passed as nil.
+func historyEntry(action domain.HistoryAction, actor string, check func(before, after *dto.OutputVehicleDTO) bool) gomock.Matcher {
+	return gomock.Cond(func(e *domain.VehicleHistoryEntry) bool {
+		var before, after *dto.OutputVehicleDTO
+		var err error
+		if e.Before != nil {
+			if before, err = decodeVehicle(e.Before); err != nil {
+				return false
+			}
+		}
+		if e.After != nil {
+			if after, err = decodeVehicle(e.After); err != nil {
+				return false
+			}
+		}
+		return e.ID != "" && e.Action == action && e.Actor == actor && !e.ChangedAt.IsZero() && check(before, after)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_ResolveCatalog() {
+	id := "vehicle-123"
+	stored := func() *domain.Vehicle {
+		return &domain.Vehicle{ID: id, Brand: "toyota ", Model: "Corolla", Year: 2022, Price: brl(100000), Status: domain.StatusAvailable}
+	}
+	text := func(s string) *string { return &s }
+
+	suite.T().Run("should store the canonical brand and model on create", func(t *testing.T) {
+		catalog := musecase.NewMockCatalogResolver(gomock.NewController(t))
+		catalog.EXPECT().Resolve(suite.ctx, "VW", "gol").Return("Volkswagen", "Gol", nil)
+		suite.repository.EXPECT().
+			Save(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Brand == "Volkswagen" && v.Model == "Gol"
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, catalog, suite.images)
+		_, err := uc.Create(suite.ctx, dto.InputCreateVehicleDTO{Brand: "VW", Model: "gol", Year: 2010, Color: "Red", Price: brl(30000)})
 		suite.NoError(err)
 	})
+
+	suite.T().Run("should reject a brand and model not in the catalog", func(t *testing.T) {
+		catalog := musecase.NewMockCatalogResolver(gomock.NewController(t))
+		catalog.EXPECT().Resolve(suite.ctx, "Gurgel", "BR-800").Return("", "", domain.ValidationErrors{
+			{Field: "brand", Code: domain.CodeUnknown, Message: `brand "Gurgel" is not in the catalog`},
+		})
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, catalog, suite.images)
+		_, err := uc.Create(suite.ctx, dto.InputCreateVehicleDTO{Brand: "Gurgel", Model: "BR-800", Year: 1990, Color: "Blue", Price: brl(15000)})
+		suite.ErrorIs(err, domain.ErrValidation)
+	})
+
+	suite.T().Run("should keep the stored names when update only respells them", func(t *testing.T) {
+		catalog := musecase.NewMockCatalogResolver(gomock.NewController(t))
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(stored(), nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Brand == "toyota " && v.Model == "Corolla" && v.Color == "Black"
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, catalog, suite.images)
+		_, err := uc.Update(suite.ctx, id, 0, dto.InputUpdateVehicleDTO{Brand: "TOYOTA", Model: "corolla", Year: 2022, Color: "Black", Price: brl(100000)})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should resolve a changed model on update", func(t *testing.T) {
+		catalog := musecase.NewMockCatalogResolver(gomock.NewController(t))
+		catalog.EXPECT().Resolve(suite.ctx, "Toyota", "yaris").Return("Toyota", "Yaris", nil)
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(stored(), nil)
+		suite.repository.EXPECT().
+			Update(suite.ctx, gomock.Cond(func(v *domain.Vehicle) bool {
+				return v.Brand == "Toyota" && v.Model == "Yaris"
+			})).
+			Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, catalog, suite.images)
+		_, err := uc.Update(suite.ctx, id, 0, dto.InputUpdateVehicleDTO{Brand: "Toyota", Model: "yaris", Year: 2022, Color: "White", Price: brl(100000)})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should resolve a patched model against the stored brand", func(t *testing.T) {
+		catalog := musecase.NewMockCatalogResolver(gomock.NewController(t))
+		catalog.EXPECT().Resolve(suite.ctx, "toyota ", "Etios").Return("", "", domain.ValidationErrors{
+			{Field: "model", Code: domain.CodeUnknown, Message: `model "Etios" is not in the catalog for brand Toyota`},
+		})
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(stored(), nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, catalog, suite.images)
+		_, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Model: text("Etios")})
+		suite.ErrorIs(err, domain.ErrValidation)
+	})
+
+	suite.T().Run("should not patch a respelled brand", func(t *testing.T) {
+		catalog := musecase.NewMockCatalogResolver(gomock.NewController(t))
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, id).Return(stored(), nil)
+
+		uc := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, catalog, suite.images)
+		output, err := uc.Patch(suite.ctx, id, 0, dto.InputPatchVehicleDTO{Brand: text("TOYOTA")})
+		suite.NoError(err)
+		suite.Equal("toyota ", output.Brand)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_RecordHistory() {
+	suite.T().Run("should record the created vehicle", func(t *testing.T) {
+		suite.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+		suite.history.EXPECT().
+			Add(gomock.Any(), historyEntry(domain.HistoryCreate, "anonymous", func(before, after *dto.OutputVehicleDTO) bool {
+				return before == nil && after != nil && after.Price == brl(100000)
+			})).
+			Return(nil)
+		suite.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Create(suite.ctx, dto.InputCreateVehicleDTO{Brand: "Toyota", Model: "Corolla", Year: 2022, Color: "White", Price: brl(100000)})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should record the price before and after an update by the actor", func(t *testing.T) {
+		ctx := usecase.WithActor(suite.ctx, "pricing@example.com")
+		existing := &domain.Vehicle{ID: "v1", Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: brl(80000), Version: 2}
+		suite.repository.EXPECT().GetByIDForUpdate(ctx, "v1").Return(existing, nil)
+		suite.repository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().
+			Add(ctx, historyEntry(domain.HistoryUpdate, "pricing@example.com", func(before, after *dto.OutputVehicleDTO) bool {
+				return before.Price == brl(80000) && after.Price == brl(75000) && before.Version == 2
+			})).
+			Return(nil)
+		suite.prices.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(ctx, "v1", 0, dto.InputUpdateVehicleDTO{Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: brl(75000)})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should record status transitions as updates", func(t *testing.T) {
+		existing := &domain.Vehicle{ID: "v1", Status: domain.StatusAvailable}
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, "v1").Return(existing, nil)
+		suite.repository.EXPECT().UpdateStatus(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().
+			Add(suite.ctx, historyEntry(domain.HistoryUpdate, "anonymous", func(before, after *dto.OutputVehicleDTO) bool {
+				return before.Status == "AVAILABLE" && after.Status == "SOLD"
+			})).
+			Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Sell(suite.ctx, "v1")
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should record the deleted vehicle", func(t *testing.T) {
+		existing := &domain.Vehicle{ID: "v1", Price: brl(50000)}
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, "v1").Return(existing, nil)
+		suite.repository.EXPECT().Delete(suite.ctx, "v1", gomock.Any()).Return(nil)
+		suite.history.EXPECT().
+			Add(suite.ctx, historyEntry(domain.HistoryDelete, "anonymous", func(before, after *dto.OutputVehicleDTO) bool {
+				return before != nil && before.Price == brl(50000) && after == nil
+			})).
+			Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		suite.NoError(usecase.Delete(suite.ctx, "v1"))
+	})
+
+	suite.T().Run("should fail the change when the history cannot be written", func(t *testing.T) {
+		existing := &domain.Vehicle{ID: "v1", Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: brl(80000)}
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, "v1").Return(existing, nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		price := brl(70000)
+		output, err := usecase.Patch(suite.ctx, "v1", 0, dto.InputPatchVehicleDTO{Price: &price})
+		suite.ErrorIs(err, assert.AnError)
+		suite.Nil(output)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_History() {
+	changedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.T().Run("should return a page of the history", func(t *testing.T) {
+		suite.history.EXPECT().ListByVehicle(suite.ctx, "v1", 20, 0).Return([]*domain.VehicleHistoryEntry{
+			{ID: "h2", Action: domain.HistoryUpdate, Before: []byte(`{"price":80000}`), After: []byte(`{"price":75000}`), Actor: "ana", ChangedAt: changedAt},
+			{ID: "h1", Action: domain.HistoryCreate, After: []byte(`{"price":80000}`), Actor: "anonymous", ChangedAt: changedAt},
+		}, 2, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.History(suite.ctx, "v1", dto.InputListVehicleHistoryDTO{})
+		suite.NoError(err)
+		suite.Equal(2, output.Total)
+		suite.Equal(20, output.Limit)
+		suite.Equal("UPDATE", output.Items[0].Action)
+		suite.JSONEq(`{"price":75000}`, string(output.Items[0].After))
+		suite.Equal("2024-05-01T12:00:00Z", output.Items[0].ChangedAt)
+		suite.Nil(output.Items[1].Before)
+	})
+
+	suite.T().Run("should return an empty history for an existing vehicle", func(t *testing.T) {
+		suite.history.EXPECT().ListByVehicle(suite.ctx, "v1", 100, 0).Return(nil, 0, nil)
+		suite.repository.EXPECT().GetByID(suite.ctx, "v1").Return(&domain.Vehicle{ID: "v1"}, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.History(suite.ctx, "v1", dto.InputListVehicleHistoryDTO{Limit: 500})
+		suite.NoError(err)
+		suite.Empty(output.Items)
+		suite.Equal(100, output.Limit)
+	})
+
+	suite.T().Run("should return not found for an unknown vehicle", func(t *testing.T) {
+		suite.history.EXPECT().ListByVehicle(suite.ctx, "missing", 20, 0).Return(nil, 0, nil)
+		suite.repository.EXPECT().GetByID(suite.ctx, "missing").Return(nil, domain.ErrNotFound)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.History(suite.ctx, "missing", dto.InputListVehicleHistoryDTO{})
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	suite.T().Run("should return error when the history cannot be read", func(t *testing.T) {
+		suite.history.EXPECT().ListByVehicle(suite.ctx, "v1", 20, 0).Return(nil, 0, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.History(suite.ctx, "v1", dto.InputListVehicleHistoryDTO{})
+		suite.Nil(output)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_RecordPriceChange() {
+	existing := func() *domain.Vehicle {
+		return &domain.Vehicle{ID: "v1", Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: brl(80000)}
+	}
+
+	suite.T().Run("should record the previous and new price with the actor", func(t *testing.T) {
+		ctx := usecase.WithActor(suite.ctx, "pricing@example.com")
+		suite.repository.EXPECT().GetByIDForUpdate(ctx, "v1").Return(existing(), nil)
+		suite.repository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().
+			Add(ctx, gomock.Cond(func(c *domain.PriceChange) bool {
+				return c.ID != "" && c.VehicleID == "v1" && c.PreviousPrice == brl(80000) && c.Price == brl(72000) &&
+					c.Actor == "pricing@example.com" && !c.ChangedAt.IsZero()
+			})).
+			Return(nil)
+		suite.publisher.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(ctx, "v1", 0, dto.InputUpdateVehicleDTO{Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: brl(72000)})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should not record updates that keep the price", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, "v1").Return(existing(), nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(suite.ctx, "v1", 0, dto.InputUpdateVehicleDTO{Brand: "Ford", Model: "Focus", Year: 2021, Color: "Red", Price: brl(80000)})
+		suite.NoError(err)
+	})
+
+	suite.T().Run("should fail the update when the price change cannot be stored", func(t *testing.T) {
+		suite.repository.EXPECT().GetByIDForUpdate(suite.ctx, "v1").Return(existing(), nil)
+		suite.repository.EXPECT().Update(suite.ctx, gomock.Any()).Return(nil)
+		suite.history.EXPECT().Add(suite.ctx, gomock.Any()).Return(nil)
+		suite.prices.EXPECT().Add(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		_, err := usecase.Update(suite.ctx, "v1", 0, dto.InputUpdateVehicleDTO{Brand: "Ford", Model: "Focus", Year: 2020, Color: "Red", Price: brl(72000)})
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_Prices() {
+	createdAt := time.Now().Add(-30 * 24 * time.Hour).Truncate(time.Second)
+	firstCut := createdAt.Add(10 * 24 * time.Hour)
+	secondCut := createdAt.Add(25 * 24 * time.Hour)
+
+	suite.T().Run("should split the timeline into the periods spent at each price", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, "v1").Return(&domain.Vehicle{ID: "v1", Price: brl(70000), CreatedAt: createdAt}, nil)
+		suite.prices.EXPECT().ListByVehicle(suite.ctx, "v1").Return([]*domain.PriceChange{
+			{PreviousPrice: brl(80000), Price: brl(75000), Actor: "ana", ChangedAt: firstCut},
+			{PreviousPrice: brl(75000), Price: brl(70000), Actor: "bia", ChangedAt: secondCut},
+		}, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Prices(suite.ctx, "v1")
+		suite.NoError(err)
+		suite.Equal(brl(80000), output.InitialPrice)
+		suite.Equal(brl(70000), output.CurrentPrice)
+		suite.Len(output.Items, 3)
+
+		suite.Equal(brl(80000), output.Items[0].Price)
+		suite.Nil(output.Items[0].PreviousPrice)
+		suite.Equal(createdAt.Format(time.RFC3339), output.Items[0].From)
+		suite.Equal(firstCut.Format(time.RFC3339), output.Items[0].Until)
+		suite.Equal(10.0, output.Items[0].Days)
+
+		suite.Equal(brl(75000), output.Items[1].Price)
+		suite.Equal(brl(80000), *output.Items[1].PreviousPrice)
+		suite.Equal("ana", output.Items[1].Actor)
+		suite.Equal(15.0, output.Items[1].Days)
+
+		suite.Equal(brl(70000), output.Items[2].Price)
+		suite.Empty(output.Items[2].Until)
+		suite.InDelta(5.0, output.Items[2].Days, 0.01)
+	})
+
+	suite.T().Run("should report a single open period for a vehicle never repriced", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, "v1").Return(&domain.Vehicle{ID: "v1", Price: brl(90000), CreatedAt: createdAt}, nil)
+		suite.prices.EXPECT().ListByVehicle(suite.ctx, "v1").Return([]*domain.PriceChange{}, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Prices(suite.ctx, "v1")
+		suite.NoError(err)
+		suite.Len(output.Items, 1)
+		suite.Equal(brl(90000), output.InitialPrice)
+		suite.Equal(brl(90000), output.Items[0].Price)
+		suite.InDelta(30.0, output.Items[0].Days, 0.01)
+	})
+
+	suite.T().Run("should return not found for an unknown vehicle", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, "missing").Return(nil, domain.ErrNotFound)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Prices(suite.ctx, "missing")
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	suite.T().Run("should return error when the price history cannot be read", func(t *testing.T) {
+		suite.repository.EXPECT().GetByID(suite.ctx, "v1").Return(&domain.Vehicle{ID: "v1"}, nil)
+		suite.prices.EXPECT().ListByVehicle(suite.ctx, "v1").Return(nil, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.Prices(suite.ctx, "v1")
+		suite.Nil(output)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *VehicleUseCaseSuite) Test_PriceStats() {
+	suite.T().Run("should return the statistics rounded to cents", func(t *testing.T) {
+		suite.prices.EXPECT().
+			Stats(suite.ctx, repository.PriceStatsFilter{Brand: "Ford"}, gomock.Any()).
+			Return([]*domain.PriceStats{
+				{Brand: "Ford", Model: "Focus", Currency: "BRL", Vehicles: 3, Sold: 1, PriceChanges: 4, AvgDaysOnMarket: 12.3456, AvgDiscount: domain.NewMoney(333333, "BRL"), AvgDiscountPercent: 4.16666},
+			}, nil)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.PriceStats(suite.ctx, dto.InputPriceStatsDTO{Brand: "Ford"})
+		suite.NoError(err)
+		suite.Equal([]dto.OutputPriceStatsDTO{
+			{Brand: "Ford", Model: "Focus", Currency: "BRL", Vehicles: 3, Sold: 1, PriceChanges: 4, AvgDaysOnMarket: 12.35, AvgDiscount: domain.NewMoney(333333, "BRL"), AvgDiscountPercent: 4.17},
+		}, output.Items)
+	})
+
+	suite.T().Run("should return error when the statistics cannot be computed", func(t *testing.T) {
+		suite.prices.EXPECT().Stats(suite.ctx, gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		usecase := usecase.NewVehicleUseCase(suite.repository, suite.history, suite.prices, suite.transactor, suite.publisher, suite.rates, suite.catalog, suite.images)
+		output, err := usecase.PriceStats(suite.ctx, dto.InputPriceStatsDTO{})
+		suite.Nil(output)
+		suite.ErrorIs(err, assert.AnError)
+	})
 }
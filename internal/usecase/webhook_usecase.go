@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=webhook_usecase.go -destination=./mocks/webhook_usecase_mock.go -package=mocks
+type WebhookUseCaseInterface interface {
+	Create(ctx context.Context, input dto.InputCreateWebhookDTO) (*dto.OutputCreateWebhookDTO, error)
+	List(ctx context.Context) (*dto.OutputListWebhooksDTO, error)
+	GetByID(ctx context.Context, id string) (*dto.OutputWebhookDTO, error)
+	Delete(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, id string, input dto.InputListWebhookDeliveriesDTO) (*dto.OutputListWebhookDeliveriesDTO, error)
+	Redeliver(ctx context.Context, id string, deliveryID string) (*dto.OutputWebhookDeliveryDTO, error)
+}
+
+const webhookSecretBytes = 32
+
+type webhookUseCase struct {
+	repo repository.WebhookRepository
+}
+
+func NewWebhookUseCase(repo repository.WebhookRepository) WebhookUseCaseInterface {
+	return &webhookUseCase{
+		repo: repo,
+	}
+}
+
+// Create is the handler for the POST /webhooks endpoint.
+// @Summary      Subscribe a webhook
+// @Description  Registers a URL to receive catalog events. Every delivery is signed with an HMAC-SHA256 of the body in the X-Signature header; a secret is generated when none is given and is only returned here.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      dto.InputCreateWebhookDTO  true  "Webhook subscription"
+// @Success      201      {object}  dto.OutputCreateWebhookDTO
+// @Failure      400      {object}  dto.ProblemDetailsDTO "Invalid request body or subscription data"
+// @Failure      500      {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /webhooks [post]
+func (wuc *webhookUseCase) Create(ctx context.Context, input dto.InputCreateWebhookDTO) (*dto.OutputCreateWebhookDTO, error) {
+	subscription := &domain.WebhookSubscription{
+		ID:        uuid.New().String(),
+		URL:       input.URL,
+		Secret:    input.Secret,
+		CreatedAt: time.Now(),
+	}
+	for _, eventType := range input.EventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, domain.EventType(eventType))
+	}
+
+	if subscription.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	err := utils.ValidateWebhookSubscription(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wuc.repo.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &dto.OutputCreateWebhookDTO{
+		OutputWebhookDTO: toOutputWebhookDTO(subscription),
+		Secret:           subscription.Secret,
+	}
+
+	return output, nil
+}
+
+// List is the handler for the GET /webhooks endpoint.
+// @Summary      List webhooks
+// @Description  Returns every webhook subscription. Secrets are not included.
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200  {object}  dto.OutputListWebhooksDTO
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /webhooks [get]
+func (wuc *webhookUseCase) List(ctx context.Context) (*dto.OutputListWebhooksDTO, error) {
+	subscriptions, err := wuc.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.OutputWebhookDTO, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		items = append(items, toOutputWebhookDTO(subscription))
+	}
+
+	return &dto.OutputListWebhooksDTO{Items: items}, nil
+}
+
+// GetByID is the handler for the GET /webhooks/{id} endpoint.
+// @Summary      Get a webhook
+// @Description  Returns a webhook subscription by its ID. The secret is not included.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  dto.OutputWebhookDTO
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Webhook not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /webhooks/{id} [get]
+func (wuc *webhookUseCase) GetByID(ctx context.Context, id string) (*dto.OutputWebhookDTO, error) {
+	subscription, err := wuc.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputWebhookDTO(subscription)
+	return &output, nil
+}
+
+// Delete is the handler for the DELETE /webhooks/{id} endpoint.
+// @Summary      Delete a webhook
+// @Description  Removes a webhook subscription along with its delivery log. Pending deliveries are discarded.
+// @Tags         Webhooks
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      204  {string}  string "No Content"
+// @Failure      400  {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404  {object}  dto.ProblemDetailsDTO "Webhook not found"
+// @Failure      500  {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /webhooks/{id} [delete]
+func (wuc *webhookUseCase) Delete(ctx context.Context, id string) error {
+	return wuc.repo.DeleteSubscription(ctx, id)
+}
+
+// ListDeliveries is the handler for the GET /webhooks/{id}/deliveries endpoint.
+// @Summary      List webhook deliveries
+// @Description  Returns a page of the delivery log of a webhook, newest first, along with the total number of entries.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id      path      string  true   "Webhook ID"
+// @Param        status  query     string  false  "Filter by delivery status"  Enums(PENDING, SUCCEEDED, FAILED)
+// @Param        limit   query     int     false  "Maximum number of deliveries to return (default 20, max 100)"
+// @Param        offset  query     int     false  "Number of deliveries to skip"
+// @Success      200     {object}  dto.OutputListWebhookDeliveriesDTO
+// @Failure      400     {object}  dto.ProblemDetailsDTO "Invalid ID, status or pagination parameters"
+// @Failure      404     {object}  dto.ProblemDetailsDTO "Webhook not found"
+// @Failure      500     {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /webhooks/{id}/deliveries [get]
+func (wuc *webhookUseCase) ListDeliveries(ctx context.Context, id string, input dto.InputListWebhookDeliveriesDTO) (*dto.OutputListWebhookDeliveriesDTO, error) {
+	status := domain.WebhookDeliveryStatus(input.Status)
+	switch status {
+	case "", domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: invalid status parameter", domain.ErrValidation)
+	}
+
+	_, err := wuc.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.WebhookDeliveryListParams{
+		SubscriptionID: id,
+		Status:         status,
+		Limit:          input.Limit,
+		Offset:         input.Offset,
+	}
+	params.Limit, params.Offset = normalizePage(params.Limit, params.Offset)
+
+	deliveries, total, err := wuc.repo.ListDeliveries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.OutputWebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, toOutputWebhookDeliveryDTO(delivery))
+	}
+
+	output := &dto.OutputListWebhookDeliveriesDTO{
+		Items:  items,
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+
+	return output, nil
+}
+
+// Redeliver is the handler for the POST /webhooks/{id}/deliveries/{deliveryId}/redeliver endpoint.
+// @Summary      Redeliver a webhook event
+// @Description  Queues a new delivery of the same event to the current URL of the webhook. The original entry of the log is kept unchanged.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id          path      string  true  "Webhook ID"
+// @Param        deliveryId  path      string  true  "Delivery ID"
+// @Success      202         {object}  dto.OutputWebhookDeliveryDTO
+// @Failure      400         {object}  dto.ProblemDetailsDTO "Invalid ID"
+// @Failure      404         {object}  dto.ProblemDetailsDTO "Webhook or delivery not found"
+// @Failure      500         {object}  dto.ProblemDetailsDTO "Internal server error"
+// @Router       /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (wuc *webhookUseCase) Redeliver(ctx context.Context, id string, deliveryID string) (*dto.OutputWebhookDeliveryDTO, error) {
+	subscription, err := wuc.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := wuc.repo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		ID:              uuid.New().String(),
+		SubscriptionID:  subscription.ID,
+		EventID:         original.EventID,
+		EventType:       original.EventType,
+		URL:             subscription.URL,
+		Payload:         original.Payload,
+		Status:          domain.DeliveryPending,
+		NextAttemptAt:   now,
+		CreatedAt:       now,
+		RedeliveredFrom: original.ID,
+	}
+
+	err = wuc.repo.AddDelivery(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toOutputWebhookDeliveryDTO(delivery)
+	return &output, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func toOutputWebhookDTO(subscription *domain.WebhookSubscription) dto.OutputWebhookDTO {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return dto.OutputWebhookDTO{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  subscription.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toOutputWebhookDeliveryDTO(delivery *domain.WebhookDelivery) dto.OutputWebhookDeliveryDTO {
+	output := dto.OutputWebhookDeliveryDTO{
+		ID:              delivery.ID,
+		SubscriptionID:  delivery.SubscriptionID,
+		EventID:         delivery.EventID,
+		EventType:       string(delivery.EventType),
+		URL:             delivery.URL,
+		Payload:         delivery.Payload,
+		Status:          string(delivery.Status),
+		Attempts:        delivery.Attempts,
+		LastError:       delivery.LastError,
+		ResponseStatus:  delivery.ResponseStatus,
+		NextAttemptAt:   delivery.NextAttemptAt.Format(time.RFC3339),
+		CreatedAt:       delivery.CreatedAt.Format(time.RFC3339),
+		RedeliveredFrom: delivery.RedeliveredFrom,
+	}
+	if delivery.DeliveredAt != nil {
+		output.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+
+	return output
+}
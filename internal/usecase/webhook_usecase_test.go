@@ -0,0 +1,226 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/dto"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookUseCaseSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	repository *mocks.MockWebhookRepository
+	useCase    usecase.WebhookUseCaseInterface
+}
+
+func (suite *WebhookUseCaseSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.repository = mocks.NewMockWebhookRepository(ctrl)
+	suite.useCase = usecase.NewWebhookUseCase(suite.repository)
+}
+
+func Test_WebhookUseCaseSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WebhookUseCaseSuite))
+}
+
+func (suite *WebhookUseCaseSuite) Test_Create() {
+	suite.T().Run("should generate a secret and return it once", func(t *testing.T) {
+		input := dto.InputCreateWebhookDTO{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"vehicle.created", "vehicle.sold"},
+		}
+
+		var stored *domain.WebhookSubscription
+		suite.repository.EXPECT().
+			CreateSubscription(suite.ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, s *domain.WebhookSubscription) error {
+				stored = s
+				return nil
+			})
+
+		output, err := suite.useCase.Create(suite.ctx, input)
+		suite.NoError(err)
+		suite.Len(output.Secret, 64)
+		suite.Equal(stored.Secret, output.Secret)
+		suite.Equal(stored.ID, output.ID)
+		suite.Equal([]domain.EventType{domain.EventVehicleCreated, domain.EventVehicleSold}, stored.EventTypes)
+		suite.Equal(input.EventTypes, output.EventTypes)
+	})
+
+	suite.T().Run("should keep the secret given by the caller", func(t *testing.T) {
+		input := dto.InputCreateWebhookDTO{
+			URL:        "http://localhost:9000/hook",
+			EventTypes: []string{"vehicle.deleted"},
+			Secret:     "a-secret-of-my-own",
+		}
+		suite.repository.EXPECT().
+			CreateSubscription(suite.ctx, gomock.Cond(func(s *domain.WebhookSubscription) bool {
+				return s.Secret == input.Secret
+			})).
+			Return(nil)
+
+		output, err := suite.useCase.Create(suite.ctx, input)
+		suite.NoError(err)
+		suite.Equal(input.Secret, output.Secret)
+	})
+
+	suite.T().Run("should reject invalid subscriptions", func(t *testing.T) {
+		input := dto.InputCreateWebhookDTO{
+			URL:        "ftp://example.com",
+			EventTypes: []string{"vehicle.crashed"},
+			Secret:     "short",
+		}
+
+		output, err := suite.useCase.Create(suite.ctx, input)
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrValidation)
+
+		var validationErrs domain.ValidationErrors
+		suite.ErrorAs(err, &validationErrs)
+		suite.Len(validationErrs, 3)
+	})
+
+	suite.T().Run("should return error when the repository fails", func(t *testing.T) {
+		suite.repository.EXPECT().CreateSubscription(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		output, err := suite.useCase.Create(suite.ctx, dto.InputCreateWebhookDTO{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"vehicle.sold"},
+		})
+		suite.Nil(output)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *WebhookUseCaseSuite) Test_List() {
+	suite.T().Run("should list subscriptions without secrets", func(t *testing.T) {
+		suite.repository.EXPECT().ListSubscriptions(suite.ctx).Return([]*domain.WebhookSubscription{
+			{ID: "wh-1", URL: "https://example.com", EventTypes: []domain.EventType{domain.EventVehicleSold}, Secret: "0123456789abcdef"},
+		}, nil)
+
+		output, err := suite.useCase.List(suite.ctx)
+		suite.NoError(err)
+		suite.Len(output.Items, 1)
+		suite.Equal("wh-1", output.Items[0].ID)
+		suite.Equal([]string{"vehicle.sold"}, output.Items[0].EventTypes)
+	})
+
+	suite.T().Run("should return an empty list", func(t *testing.T) {
+		suite.repository.EXPECT().ListSubscriptions(suite.ctx).Return(nil, nil)
+
+		output, err := suite.useCase.List(suite.ctx)
+		suite.NoError(err)
+		suite.NotNil(output.Items)
+		suite.Empty(output.Items)
+	})
+}
+
+func (suite *WebhookUseCaseSuite) Test_GetByIDAndDelete() {
+	suite.T().Run("should return the subscription", func(t *testing.T) {
+		suite.repository.EXPECT().GetSubscription(suite.ctx, "wh-1").
+			Return(&domain.WebhookSubscription{ID: "wh-1", URL: "https://example.com"}, nil)
+
+		output, err := suite.useCase.GetByID(suite.ctx, "wh-1")
+		suite.NoError(err)
+		suite.Equal("https://example.com", output.URL)
+	})
+
+	suite.T().Run("should propagate not found", func(t *testing.T) {
+		suite.repository.EXPECT().GetSubscription(suite.ctx, "missing").Return(nil, domain.ErrNotFound)
+
+		output, err := suite.useCase.GetByID(suite.ctx, "missing")
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+
+	suite.T().Run("should delete the subscription", func(t *testing.T) {
+		suite.repository.EXPECT().DeleteSubscription(suite.ctx, "wh-1").Return(nil)
+
+		suite.NoError(suite.useCase.Delete(suite.ctx, "wh-1"))
+	})
+}
+
+func (suite *WebhookUseCaseSuite) Test_ListDeliveries() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.T().Run("should apply the default page size", func(t *testing.T) {
+		suite.repository.EXPECT().GetSubscription(suite.ctx, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1"}, nil)
+		suite.repository.EXPECT().
+			ListDeliveries(suite.ctx, repository.WebhookDeliveryListParams{SubscriptionID: "wh-1", Status: domain.DeliveryFailed, Limit: 20}).
+			Return([]*domain.WebhookDelivery{
+				{ID: "dlv-1", Status: domain.DeliveryFailed, Payload: []byte(`{"id":"evt-1"}`), NextAttemptAt: now, CreatedAt: now},
+			}, 1, nil)
+
+		output, err := suite.useCase.ListDeliveries(suite.ctx, "wh-1", dto.InputListWebhookDeliveriesDTO{Status: "FAILED"})
+		suite.NoError(err)
+		suite.Equal(1, output.Total)
+		suite.Equal(20, output.Limit)
+		suite.JSONEq(`{"id":"evt-1"}`, string(output.Items[0].Payload))
+		suite.Empty(output.Items[0].DeliveredAt)
+	})
+
+	suite.T().Run("should reject an unknown status", func(t *testing.T) {
+		output, err := suite.useCase.ListDeliveries(suite.ctx, "wh-1", dto.InputListWebhookDeliveriesDTO{Status: "LOST"})
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrValidation)
+	})
+
+	suite.T().Run("should return not found for an unknown webhook", func(t *testing.T) {
+		suite.repository.EXPECT().GetSubscription(suite.ctx, "missing").Return(nil, domain.ErrNotFound)
+
+		output, err := suite.useCase.ListDeliveries(suite.ctx, "missing", dto.InputListWebhookDeliveriesDTO{})
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
+
+func (suite *WebhookUseCaseSuite) Test_Redeliver() {
+	suite.T().Run("should queue a copy of the delivery to the current URL", func(t *testing.T) {
+		original := &domain.WebhookDelivery{
+			ID:             "dlv-1",
+			SubscriptionID: "wh-1",
+			EventID:        "evt-1",
+			EventType:      domain.EventVehicleSold,
+			URL:            "https://old.example.com",
+			Payload:        []byte(`{"id":"evt-1"}`),
+			Status:         domain.DeliveryFailed,
+			Attempts:       10,
+		}
+		suite.repository.EXPECT().GetSubscription(suite.ctx, "wh-1").
+			Return(&domain.WebhookSubscription{ID: "wh-1", URL: "https://new.example.com"}, nil)
+		suite.repository.EXPECT().GetDelivery(suite.ctx, "wh-1", "dlv-1").Return(original, nil)
+		suite.repository.EXPECT().
+			AddDelivery(suite.ctx, gomock.Cond(func(d *domain.WebhookDelivery) bool {
+				return d.ID != "dlv-1" && d.RedeliveredFrom == "dlv-1" && d.EventID == "evt-1" &&
+					d.URL == "https://new.example.com" && d.Status == domain.DeliveryPending && d.Attempts == 0
+			})).
+			Return(nil)
+
+		output, err := suite.useCase.Redeliver(suite.ctx, "wh-1", "dlv-1")
+		suite.NoError(err)
+		suite.Equal("PENDING", output.Status)
+		suite.Equal("dlv-1", output.RedeliveredFrom)
+	})
+
+	suite.T().Run("should return not found for a delivery of another webhook", func(t *testing.T) {
+		suite.repository.EXPECT().GetSubscription(suite.ctx, "wh-1").Return(&domain.WebhookSubscription{ID: "wh-1"}, nil)
+		suite.repository.EXPECT().GetDelivery(suite.ctx, "wh-1", "dlv-9").Return(nil, domain.ErrNotFound)
+
+		output, err := suite.useCase.Redeliver(suite.ctx, "wh-1", "dlv-9")
+		suite.Nil(output)
+		suite.ErrorIs(err, domain.ErrNotFound)
+	})
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+const maxCatalogNameLength = 100
+
+func ValidateBrand(brand *domain.Brand) error {
+	return validateCatalogEntry(brand.Name, brand.Aliases)
+}
+
+func ValidateModel(model *domain.Model) error {
+	return validateCatalogEntry(model.Name, model.Aliases)
+}
+
+func validateCatalogEntry(name string, aliases []string) error {
+	var errs domain.ValidationErrors
+
+	if name == "" {
+		errs = append(errs, domain.FieldError{Field: "name", Code: domain.CodeRequired, Message: "name cannot be empty"})
+	} else if len(name) > maxCatalogNameLength {
+		errs = append(errs, domain.FieldError{Field: "name", Code: domain.CodeTooLong, Message: fmt.Sprintf("name must have at most %d characters", maxCatalogNameLength)})
+	}
+
+	for _, alias := range aliases {
+		if alias == "" {
+			errs = append(errs, domain.FieldError{Field: "aliases", Code: domain.CodeRequired, Message: "aliases cannot be empty"})
+		} else if len(alias) > maxCatalogNameLength {
+			errs = append(errs, domain.FieldError{Field: "aliases", Code: domain.CodeTooLong, Message: fmt.Sprintf("aliases must have at most %d characters", maxCatalogNameLength)})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
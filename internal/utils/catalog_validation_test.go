@@ -0,0 +1,63 @@
+package utils_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
+)
+
+func TestValidateBrand(t *testing.T) {
+	tests := []struct {
+		name      string
+		brand     domain.Brand
+		wantCodes []string
+	}{
+		{
+			name:  "valid brand",
+			brand: domain.Brand{Name: "Volkswagen", Aliases: []string{"VW"}},
+		},
+		{
+			name:      "empty name",
+			brand:     domain.Brand{Name: ""},
+			wantCodes: []string{domain.CodeRequired},
+		},
+		{
+			name:      "name too long",
+			brand:     domain.Brand{Name: strings.Repeat("a", 101)},
+			wantCodes: []string{domain.CodeTooLong},
+		},
+		{
+			name:      "empty and long aliases",
+			brand:     domain.Brand{Name: "Volkswagen", Aliases: []string{"", strings.Repeat("a", 101)}},
+			wantCodes: []string{domain.CodeRequired, domain.CodeTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateBrand(&tt.brand)
+			if len(tt.wantCodes) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validationErrs domain.ValidationErrors
+			if !errors.As(err, &validationErrs) {
+				t.Fatalf("expected ValidationErrors, got %T", err)
+			}
+			if len(validationErrs) != len(tt.wantCodes) {
+				t.Fatalf("expected %d errors, got %+v", len(tt.wantCodes), validationErrs)
+			}
+			for i, code := range tt.wantCodes {
+				if validationErrs[i].Code != code {
+					t.Errorf("expected code %s, got %s", code, validationErrs[i].Code)
+				}
+			}
+		})
+	}
+}
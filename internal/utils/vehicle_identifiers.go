@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	vinPattern           = regexp.MustCompile(`^[A-HJ-NPR-Z0-9]{17}$`)
+	oldPlatePattern      = regexp.MustCompile(`^[A-Z]{3}[0-9]{4}$`)
+	mercosulPlatePattern = regexp.MustCompile(`^[A-Z]{3}[0-9][A-Z][0-9]{2}$`)
+	renavamPattern       = regexp.MustCompile(`^[0-9]{11}$`)
+)
+
+// vinValues transliterates the letters of a VIN for its check digit (ISO 3779).
+var vinValues = map[rune]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+var renavamWeights = [10]int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// NormalizeVIN upper-cases vin and trims the spaces around it.
+func NormalizeVIN(vin string) string {
+	return strings.ToUpper(strings.TrimSpace(vin))
+}
+
+// NormalizePlate upper-cases plate and drops the spaces and the hyphen of
+// the old format, so "abc-1234" and "ABC1234" are the same plate.
+func NormalizePlate(plate string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.ToUpper(plate))
+}
+
+// NormalizeRenavam trims renavam and pads the 9-digit numbers issued before
+// 2013 to the current 11 digits.
+func NormalizeRenavam(renavam string) string {
+	renavam = strings.TrimSpace(renavam)
+	if len(renavam) == 9 {
+		return "00" + renavam
+	}
+	return renavam
+}
+
+func isVIN(vin string) bool {
+	return vinPattern.MatchString(vin)
+}
+
+// vinHasCheckDigit reports whether the 9th character of vin is a check
+// digit. It is mandatory for vehicles made for North America, whose world
+// manufacturer identifiers start with 1 to 5; elsewhere, Brazil included,
+// that position is free for the manufacturer.
+func vinHasCheckDigit(vin string) bool {
+	return vin[0] >= '1' && vin[0] <= '5'
+}
+
+func vinCheckDigit(vin string) byte {
+	sum := 0
+	for i, r := range vin {
+		value, ok := vinValues[r]
+		if !ok {
+			value = int(r - '0')
+		}
+		sum += value * vinWeights[i]
+	}
+
+	if remainder := sum % 11; remainder != 10 {
+		return byte('0' + remainder)
+	}
+	return 'X'
+}
+
+func isPlate(plate string) bool {
+	return oldPlatePattern.MatchString(plate) || mercosulPlatePattern.MatchString(plate)
+}
+
+func isRenavam(renavam string) bool {
+	return renavamPattern.MatchString(renavam)
+}
+
+// renavamCheckDigit computes the last digit of an 11-digit RENAVAM from the
+// first ten.
+func renavamCheckDigit(renavam string) byte {
+	sum := 0
+	for i, weight := range renavamWeights {
+		sum += int(renavam[i]-'0') * weight
+	}
+
+	digit := sum * 10 % 11
+	if digit == 10 {
+		digit = 0
+	}
+	return byte('0' + digit)
+}
@@ -1,22 +1,218 @@
 package utils
 
 import (
-	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+const (
+	minVehicleYear = 1951
+	maxBrandLength = 100
+	maxModelLength = 100
+	maxColorLength = 50
+
+	maxMileage            = 2000000
+	minDoors              = 2
+	maxDoors              = 5
+	minEngineDisplacement = 50
+	maxEngineDisplacement = 10000
 )
 
-func ValidateVehicleFields(brand, model string, year int, price float64) error {
-	if brand == "" {
-		return errors.New("brand cannot be empty")
+type VehicleValidator struct {
+	errs domain.ValidationErrors
+}
+
+func NewVehicleValidator() *VehicleValidator {
+	return &VehicleValidator{}
+}
+
+func ValidateVehicle(vehicle *domain.Vehicle) error {
+	return NewVehicleValidator().
+		Brand(vehicle.Brand).
+		Model(vehicle.Model).
+		Year(vehicle.Year).
+		Color(vehicle.Color).
+		Price(vehicle.Price).
+		VIN(vehicle.VIN).
+		Plate(vehicle.Plate).
+		Renavam(vehicle.Renavam).
+		Specs(vehicle).
+		Err()
+}
+
+func (v *VehicleValidator) Brand(brand string) *VehicleValidator {
+	return v.requiredText("brand", brand, maxBrandLength)
+}
+
+func (v *VehicleValidator) Model(model string) *VehicleValidator {
+	return v.requiredText("model", model, maxModelLength)
+}
+
+func (v *VehicleValidator) Color(color string) *VehicleValidator {
+	return v.requiredText("color", color, maxColorLength)
+}
+
+func (v *VehicleValidator) Year(year int) *VehicleValidator {
+	maxYear := time.Now().Year() + 1
+	if year < minVehicleYear || year > maxYear {
+		v.add("year", domain.CodeOutOfRange, fmt.Sprintf("year must be between %d and %d", minVehicleYear, maxYear))
+	}
+	return v
+}
+
+func (v *VehicleValidator) Price(price domain.Money) *VehicleValidator {
+	if !price.IsPositive() {
+		v.add("price", domain.CodeMustBePositive, "price must be greater than zero")
+	} else if price.Scale() > domain.MoneyScale {
+		v.add("price", domain.CodeTooPrecise, fmt.Sprintf("price must have at most %d decimal places", domain.MoneyScale))
+	}
+	if !isCurrencyCode(price.Currency()) {
+		v.add("currency", domain.CodeInvalid, "currency must be a three-letter ISO 4217 code")
+	}
+	return v
+}
+
+// VIN checks an optional chassis number: 17 letters and digits without I, O
+// or Q, with a valid check digit where one is required.
+func (v *VehicleValidator) VIN(vin string) *VehicleValidator {
+	if vin == "" {
+		return v
+	}
+	if !isVIN(vin) {
+		v.add("vin", domain.CodeInvalid, "vin must have 17 letters and digits, without I, O or Q")
+	} else if vinHasCheckDigit(vin) && vinCheckDigit(vin) != vin[8] {
+		v.add("vin", domain.CodeInvalidChecksum, "vin check digit does not match")
+	}
+	return v
+}
+
+// Plate checks an optional Brazilian license plate, in the old (ABC1234) or
+// the Mercosul (ABC1D23) format.
+func (v *VehicleValidator) Plate(plate string) *VehicleValidator {
+	if plate != "" && !isPlate(plate) {
+		v.add("plate", domain.CodeInvalid, "plate must follow the ABC1234 or ABC1D23 format")
+	}
+	return v
+}
+
+// Renavam checks an optional 11-digit RENAVAM and its check digit.
+func (v *VehicleValidator) Renavam(renavam string) *VehicleValidator {
+	if renavam == "" {
+		return v
+	}
+	if !isRenavam(renavam) {
+		v.add("renavam", domain.CodeInvalid, "renavam must have 11 digits")
+	} else if renavamCheckDigit(renavam) != renavam[10] {
+		v.add("renavam", domain.CodeInvalidChecksum, "renavam check digit does not match")
+	}
+	return v
+}
+
+// Specs checks the optional specification attributes of vehicle.
+func (v *VehicleValidator) Specs(vehicle *domain.Vehicle) *VehicleValidator {
+	return v.Mileage(vehicle.Mileage).
+		FuelType(vehicle.FuelType).
+		Transmission(vehicle.Transmission).
+		BodyType(vehicle.BodyType).
+		Doors(vehicle.Doors).
+		EngineDisplacement(vehicle.EngineDisplacement).
+		Condition(vehicle.Condition)
+}
+
+func (v *VehicleValidator) Mileage(mileage int) *VehicleValidator {
+	if mileage < 0 || mileage > maxMileage {
+		v.add("mileage", domain.CodeOutOfRange, fmt.Sprintf("mileage must be between 0 and %d km", maxMileage))
+	}
+	return v
+}
+
+func (v *VehicleValidator) FuelType(fuelType domain.FuelType) *VehicleValidator {
+	return oneOf(v, "fuel_type", fuelType, domain.FuelTypes)
+}
+
+func (v *VehicleValidator) Transmission(transmission domain.Transmission) *VehicleValidator {
+	return oneOf(v, "transmission", transmission, domain.Transmissions)
+}
+
+func (v *VehicleValidator) BodyType(bodyType domain.BodyType) *VehicleValidator {
+	return oneOf(v, "body_type", bodyType, domain.BodyTypes)
+}
+
+func (v *VehicleValidator) Condition(condition domain.VehicleCondition) *VehicleValidator {
+	return oneOf(v, "condition", condition, domain.VehicleConditions)
+}
+
+// Doors checks an optional number of doors; zero means not informed.
+func (v *VehicleValidator) Doors(doors int) *VehicleValidator {
+	if doors != 0 && (doors < minDoors || doors > maxDoors) {
+		v.add("doors", domain.CodeOutOfRange, fmt.Sprintf("doors must be between %d and %d", minDoors, maxDoors))
+	}
+	return v
+}
+
+// EngineDisplacement checks an optional displacement in cubic centimeters;
+// zero means not informed, as for electric vehicles.
+func (v *VehicleValidator) EngineDisplacement(displacement int) *VehicleValidator {
+	if displacement != 0 && (displacement < minEngineDisplacement || displacement > maxEngineDisplacement) {
+		v.add("engine_displacement", domain.CodeOutOfRange,
+			fmt.Sprintf("engine_displacement must be between %d and %d cc", minEngineDisplacement, maxEngineDisplacement))
+	}
+	return v
+}
+
+func (v *VehicleValidator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+func (v *VehicleValidator) requiredText(field, value string, maxLength int) *VehicleValidator {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		v.add(field, domain.CodeRequired, field+" cannot be empty")
+		return v
 	}
-	if model == "" {
-		return errors.New("model cannot be empty")
+	if utf8.RuneCountInString(value) > maxLength {
+		v.add(field, domain.CodeTooLong, fmt.Sprintf("%s must have at most %d characters", field, maxLength))
 	}
-	if year <= 1950 || year > time.Now().Year()+1 {
-		return errors.New("vehicle year is invalid")
+	return v
+}
+
+// oneOf checks an optional enumerated value against the allowed ones.
+func oneOf[T ~string](v *VehicleValidator, field string, value T, allowed []T) *VehicleValidator {
+	if value == "" || slices.Contains(allowed, value) {
+		return v
+	}
+	names := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		names = append(names, string(a))
+	}
+	v.add(field, domain.CodeInvalid, fmt.Sprintf("%s must be one of %s", field, strings.Join(names, ", ")))
+	return v
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
 	}
-	if price <= 0 {
-		return errors.New("price must be greater than zero")
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
 	}
-	return nil
+	return true
+}
+
+func (v *VehicleValidator) add(field, code, message string) {
+	v.errs = append(v.errs, domain.FieldError{
+		Field:   field,
+		Code:    code,
+		Message: message,
+	})
 }
@@ -1,85 +1,254 @@
 package utils_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
 	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
 )
 
-func TestValidateVehicleFields(t *testing.T) {
+func TestValidateVehicle(t *testing.T) {
+	validVehicle := func() *domain.Vehicle {
+		return &domain.Vehicle{
+			Brand: "Toyota",
+			Model: "Corolla",
+			Year:  time.Now().Year(),
+			Color: "White",
+			Price: domain.NewMoney(2000000, "BRL"),
+		}
+	}
+
 	tests := []struct {
-		name    string
-		brand   string
-		model   string
-		year    int
-		price   float64
-		wantErr bool
+		name       string
+		mutate     func(v *domain.Vehicle)
+		wantFields []string
+		wantCodes  []string
 	}{
 		{
-			name:    "valid fields",
-			brand:   "Toyota",
-			model:   "Corolla",
-			year:    time.Now().Year(),
-			price:   20000,
-			wantErr: false,
+			name:   "valid fields",
+			mutate: func(v *domain.Vehicle) {},
+		},
+		{
+			name:       "empty brand",
+			mutate:     func(v *domain.Vehicle) { v.Brand = "" },
+			wantFields: []string{"brand"},
+			wantCodes:  []string{domain.CodeRequired},
+		},
+		{
+			name:       "blank model",
+			mutate:     func(v *domain.Vehicle) { v.Model = "   " },
+			wantFields: []string{"model"},
+			wantCodes:  []string{domain.CodeRequired},
+		},
+		{
+			name:       "empty color",
+			mutate:     func(v *domain.Vehicle) { v.Color = "" },
+			wantFields: []string{"color"},
+			wantCodes:  []string{domain.CodeRequired},
 		},
 		{
-			name:    "empty brand",
-			brand:   "",
-			model:   "Civic",
-			year:    2020,
-			price:   15000,
-			wantErr: true,
+			name:       "color too long",
+			mutate:     func(v *domain.Vehicle) { v.Color = strings.Repeat("a", 51) },
+			wantFields: []string{"color"},
+			wantCodes:  []string{domain.CodeTooLong},
 		},
 		{
-			name:    "empty model",
-			brand:   "Honda",
-			model:   "",
-			year:    2020,
-			price:   15000,
-			wantErr: true,
+			name:       "brand too long",
+			mutate:     func(v *domain.Vehicle) { v.Brand = strings.Repeat("a", 101) },
+			wantFields: []string{"brand"},
+			wantCodes:  []string{domain.CodeTooLong},
 		},
 		{
-			name:    "year too old",
-			brand:   "Ford",
-			model:   "Mustang",
-			year:    1940,
-			price:   30000,
-			wantErr: true,
+			name:       "year too old",
+			mutate:     func(v *domain.Vehicle) { v.Year = 1940 },
+			wantFields: []string{"year"},
+			wantCodes:  []string{domain.CodeOutOfRange},
 		},
 		{
-			name:    "year in the future",
-			brand:   "Tesla",
-			model:   "Model S",
-			year:    time.Now().Year() + 2,
-			price:   80000,
-			wantErr: true,
+			name:       "year in the future",
+			mutate:     func(v *domain.Vehicle) { v.Year = time.Now().Year() + 2 },
+			wantFields: []string{"year"},
+			wantCodes:  []string{domain.CodeOutOfRange},
 		},
 		{
-			name:    "zero price",
-			brand:   "Chevrolet",
-			model:   "Onix",
-			year:    2022,
-			price:   0,
-			wantErr: true,
+			name:       "zero price",
+			mutate:     func(v *domain.Vehicle) { v.Price = domain.NewMoney(0, "BRL") },
+			wantFields: []string{"price"},
+			wantCodes:  []string{domain.CodeMustBePositive},
 		},
 		{
-			name:    "negative price",
-			brand:   "BMW",
-			model:   "320i",
-			year:    2022,
-			price:   -10000,
-			wantErr: true,
+			name:       "negative price",
+			mutate:     func(v *domain.Vehicle) { v.Price = domain.NewMoney(-1000000, "BRL") },
+			wantFields: []string{"price"},
+			wantCodes:  []string{domain.CodeMustBePositive},
+		},
+		{
+			name:       "price with more than two decimals",
+			mutate:     func(v *domain.Vehicle) { v.Price, _ = domain.ParseMoney("20000.005", "BRL") },
+			wantFields: []string{"price"},
+			wantCodes:  []string{domain.CodeTooPrecise},
+		},
+		{
+			name:   "price with trailing zeros beyond two decimals",
+			mutate: func(v *domain.Vehicle) { v.Price, _ = domain.ParseMoney("20000.5000", "BRL") },
+		},
+		{
+			name:       "invalid currency",
+			mutate:     func(v *domain.Vehicle) { v.Price = v.Price.WithCurrency("brl") },
+			wantFields: []string{"currency"},
+			wantCodes:  []string{domain.CodeInvalid},
+		},
+		{
+			name: "valid identifiers",
+			mutate: func(v *domain.Vehicle) {
+				v.VIN, v.Plate, v.Renavam = "1M8GDM9AXKP042788", "BRA2E19", "00639884962"
+			},
+		},
+		{
+			name:   "vin without check digit outside North America",
+			mutate: func(v *domain.Vehicle) { v.VIN = "9BWZZZ377VT004251" },
+		},
+		{
+			name:       "vin with wrong check digit",
+			mutate:     func(v *domain.Vehicle) { v.VIN = "1M8GDM9A1KP042788" },
+			wantFields: []string{"vin"},
+			wantCodes:  []string{domain.CodeInvalidChecksum},
+		},
+		{
+			name:       "vin with forbidden letter",
+			mutate:     func(v *domain.Vehicle) { v.VIN = "9BWZZZ377VT00425O" },
+			wantFields: []string{"vin"},
+			wantCodes:  []string{domain.CodeInvalid},
+		},
+		{
+			name:   "old format plate",
+			mutate: func(v *domain.Vehicle) { v.Plate = "ABC1234" },
+		},
+		{
+			name:       "invalid plate",
+			mutate:     func(v *domain.Vehicle) { v.Plate = "AB12345" },
+			wantFields: []string{"plate"},
+			wantCodes:  []string{domain.CodeInvalid},
+		},
+		{
+			name:       "renavam with wrong check digit",
+			mutate:     func(v *domain.Vehicle) { v.Renavam = "00639884961" },
+			wantFields: []string{"renavam"},
+			wantCodes:  []string{domain.CodeInvalidChecksum},
+		},
+		{
+			name:       "renavam with letters",
+			mutate:     func(v *domain.Vehicle) { v.Renavam = "0063988496A" },
+			wantFields: []string{"renavam"},
+			wantCodes:  []string{domain.CodeInvalid},
+		},
+		{
+			name: "valid specs",
+			mutate: func(v *domain.Vehicle) {
+				v.Mileage = 35000
+				v.FuelType = domain.FuelFlex
+				v.Transmission = domain.TransmissionCVT
+				v.BodyType = domain.BodySedan
+				v.Doors = 4
+				v.EngineDisplacement = 1987
+				v.Condition = domain.ConditionUsed
+			},
+		},
+		{
+			name:       "negative mileage",
+			mutate:     func(v *domain.Vehicle) { v.Mileage = -1 },
+			wantFields: []string{"mileage"},
+			wantCodes:  []string{domain.CodeOutOfRange},
+		},
+		{
+			name: "unknown enumerated specs",
+			mutate: func(v *domain.Vehicle) {
+				v.FuelType = "STEAM"
+				v.Transmission = "manual"
+				v.BodyType = "LIMO"
+				v.Condition = "REFURBISHED"
+			},
+			wantFields: []string{"fuel_type", "transmission", "body_type", "condition"},
+			wantCodes:  []string{domain.CodeInvalid, domain.CodeInvalid, domain.CodeInvalid, domain.CodeInvalid},
+		},
+		{
+			name: "doors and displacement out of range",
+			mutate: func(v *domain.Vehicle) {
+				v.Doors = 1
+				v.EngineDisplacement = 20000
+			},
+			wantFields: []string{"doors", "engine_displacement"},
+			wantCodes:  []string{domain.CodeOutOfRange, domain.CodeOutOfRange},
+		},
+		{
+			name:       "all fields invalid",
+			mutate:     func(v *domain.Vehicle) { *v = domain.Vehicle{} },
+			wantFields: []string{"brand", "model", "year", "color", "price", "currency"},
+			wantCodes: []string{
+				domain.CodeRequired, domain.CodeRequired, domain.CodeOutOfRange, domain.CodeRequired, domain.CodeMustBePositive, domain.CodeInvalid,
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := utils.ValidateVehicleFields(tt.brand, tt.model, tt.year, tt.price)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateVehicleFields() error = %v, wantErr %v", err, tt.wantErr)
+			vehicle := validVehicle()
+			tt.mutate(vehicle)
+
+			err := utils.ValidateVehicle(vehicle)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Errorf("ValidateVehicle() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("ValidateVehicle() error = %v, want domain.ErrValidation", err)
+			}
+			var validationErrs domain.ValidationErrors
+			if !errors.As(err, &validationErrs) {
+				t.Fatalf("ValidateVehicle() error = %v, want domain.ValidationErrors", err)
+			}
+			if len(validationErrs) != len(tt.wantFields) {
+				t.Fatalf("ValidateVehicle() returned %d errors, want %d: %v", len(validationErrs), len(tt.wantFields), validationErrs)
+			}
+			for i, fieldErr := range validationErrs {
+				if fieldErr.Field != tt.wantFields[i] || fieldErr.Code != tt.wantCodes[i] {
+					t.Errorf("error %d = %s/%s, want %s/%s", i, fieldErr.Field, fieldErr.Code, tt.wantFields[i], tt.wantCodes[i])
+				}
 			}
 		})
 	}
 }
+
+func TestVehicleValidator_PartialFields(t *testing.T) {
+	err := utils.NewVehicleValidator().Price(domain.NewMoney(15000000, "BRL")).Err()
+	if err != nil {
+		t.Errorf("expected no error when validating only a valid price, got %v", err)
+	}
+
+	err = utils.NewVehicleValidator().Color("").Price(domain.NewMoney(-100, "BRL")).Err()
+	var validationErrs domain.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Errorf("expected two validation errors, got %v", err)
+	}
+}
+
+func TestNormalizeVehicleIdentifiers(t *testing.T) {
+	if got := utils.NormalizeVIN(" 9bwzzz377vt004251 "); got != "9BWZZZ377VT004251" {
+		t.Errorf("NormalizeVIN() = %q", got)
+	}
+	if got := utils.NormalizePlate("abc-1234"); got != "ABC1234" {
+		t.Errorf("NormalizePlate() = %q", got)
+	}
+	if got := utils.NormalizePlate("bra 2e19"); got != "BRA2E19" {
+		t.Errorf("NormalizePlate() = %q", got)
+	}
+	if got := utils.NormalizeRenavam("639884962"); got != "00639884962" {
+		t.Errorf("NormalizeRenavam() = %q", got)
+	}
+}
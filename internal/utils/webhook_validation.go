@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+)
+
+const (
+	minWebhookSecretLength = 16
+	maxWebhookSecretLength = 128
+	maxWebhookURLLength    = 2048
+)
+
+func ValidateWebhookSubscription(subscription *domain.WebhookSubscription) error {
+	var errs domain.ValidationErrors
+
+	parsed, err := url.Parse(subscription.URL)
+	switch {
+	case strings.TrimSpace(subscription.URL) == "":
+		errs = append(errs, domain.FieldError{Field: "url", Code: domain.CodeRequired, Message: "url cannot be empty"})
+	case len(subscription.URL) > maxWebhookURLLength:
+		errs = append(errs, domain.FieldError{Field: "url", Code: domain.CodeTooLong, Message: fmt.Sprintf("url must have at most %d characters", maxWebhookURLLength)})
+	case err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "":
+		errs = append(errs, domain.FieldError{Field: "url", Code: domain.CodeInvalid, Message: "url must be an absolute http or https URL"})
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		errs = append(errs, domain.FieldError{Field: "event_types", Code: domain.CodeRequired, Message: "event_types cannot be empty"})
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !eventType.IsValid() {
+			errs = append(errs, domain.FieldError{Field: "event_types", Code: domain.CodeInvalid, Message: fmt.Sprintf("unknown event type %q", eventType)})
+		}
+	}
+
+	if len(subscription.Secret) < minWebhookSecretLength {
+		errs = append(errs, domain.FieldError{Field: "secret", Code: domain.CodeTooShort, Message: fmt.Sprintf("secret must have at least %d characters", minWebhookSecretLength)})
+	} else if len(subscription.Secret) > maxWebhookSecretLength {
+		errs = append(errs, domain.FieldError{Field: "secret", Code: domain.CodeTooLong, Message: fmt.Sprintf("secret must have at most %d characters", maxWebhookSecretLength)})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
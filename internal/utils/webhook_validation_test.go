@@ -0,0 +1,102 @@
+package utils_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/utils"
+)
+
+func TestValidateWebhookSubscription(t *testing.T) {
+	validSubscription := func() *domain.WebhookSubscription {
+		return &domain.WebhookSubscription{
+			URL:        "https://example.com/hooks/catalog",
+			EventTypes: []domain.EventType{domain.EventVehicleCreated},
+			Secret:     "0123456789abcdef",
+		}
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(s *domain.WebhookSubscription)
+		wantCodes []string
+	}{
+		{
+			name:   "valid subscription",
+			mutate: func(s *domain.WebhookSubscription) {},
+		},
+		{
+			name:      "empty url",
+			mutate:    func(s *domain.WebhookSubscription) { s.URL = "" },
+			wantCodes: []string{domain.CodeRequired},
+		},
+		{
+			name:      "relative url",
+			mutate:    func(s *domain.WebhookSubscription) { s.URL = "/hooks" },
+			wantCodes: []string{domain.CodeInvalid},
+		},
+		{
+			name:      "unsupported scheme",
+			mutate:    func(s *domain.WebhookSubscription) { s.URL = "ftp://example.com" },
+			wantCodes: []string{domain.CodeInvalid},
+		},
+		{
+			name:      "url too long",
+			mutate:    func(s *domain.WebhookSubscription) { s.URL = "https://example.com/" + strings.Repeat("a", 2048) },
+			wantCodes: []string{domain.CodeTooLong},
+		},
+		{
+			name:      "no event types",
+			mutate:    func(s *domain.WebhookSubscription) { s.EventTypes = nil },
+			wantCodes: []string{domain.CodeRequired},
+		},
+		{
+			name:      "unknown event type",
+			mutate:    func(s *domain.WebhookSubscription) { s.EventTypes = append(s.EventTypes, "vehicle.crashed") },
+			wantCodes: []string{domain.CodeInvalid},
+		},
+		{
+			name:      "short secret",
+			mutate:    func(s *domain.WebhookSubscription) { s.Secret = "secret" },
+			wantCodes: []string{domain.CodeTooShort},
+		},
+		{
+			name:      "long secret",
+			mutate:    func(s *domain.WebhookSubscription) { s.Secret = strings.Repeat("s", 129) },
+			wantCodes: []string{domain.CodeTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := validSubscription()
+			tt.mutate(subscription)
+
+			err := utils.ValidateWebhookSubscription(subscription)
+			if len(tt.wantCodes) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("expected ErrValidation, got %v", err)
+			}
+			var validationErrs domain.ValidationErrors
+			if !errors.As(err, &validationErrs) {
+				t.Fatalf("expected ValidationErrors, got %T", err)
+			}
+			if len(validationErrs) != len(tt.wantCodes) {
+				t.Fatalf("expected %d errors, got %+v", len(tt.wantCodes), validationErrs)
+			}
+			for i, code := range tt.wantCodes {
+				if validationErrs[i].Code != code {
+					t.Errorf("expected code %s, got %s", code, validationErrs[i].Code)
+				}
+			}
+		})
+	}
+}
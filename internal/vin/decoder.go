@@ -0,0 +1,115 @@
+// Package vin decodes the manufacturer and model year encoded in a vehicle
+// identification number.
+package vin
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// modelYearCodes lists the 10th-character codes in order from 1980; the
+// sequence repeats every 30 years.
+const modelYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+const firstModelYear = 1980
+
+var ErrInvalidVIN = errors.New("invalid vin")
+
+//go:embed wmi.csv
+var wmiTable string
+
+var manufacturers = mustLoadManufacturers(wmiTable)
+
+type manufacturer struct {
+	name  string
+	brand string
+}
+
+// Info is what a VIN tells about a vehicle. Manufacturer and Brand are empty
+// when the world manufacturer identifier is not in the table.
+type Info struct {
+	WMI          string
+	Manufacturer string
+	Brand        string
+	// ModelYears lists the model years the year code can stand for, oldest
+	// first. North American VINs pin down a single year; elsewhere the code
+	// is ambiguous across 30-year cycles.
+	ModelYears []int
+}
+
+// Decode reads the manufacturer and model year of a normalized VIN.
+func Decode(vin string) (Info, error) {
+	if len(vin) != 17 {
+		return Info{}, fmt.Errorf("%w: %s must have 17 characters", ErrInvalidVIN, vin)
+	}
+	code := strings.IndexByte(modelYearCodes, vin[9])
+	if code < 0 {
+		return Info{}, fmt.Errorf("%w: %s has no model year code", ErrInvalidVIN, vin)
+	}
+
+	info := Info{WMI: vin[:3]}
+	if m, ok := manufacturers[info.WMI]; ok {
+		info.Manufacturer = m.name
+		info.Brand = m.brand
+	}
+
+	year := firstModelYear + code
+	switch {
+	case !isNorthAmerican(vin):
+		info.ModelYears = []int{year, year + 30}
+	case isDigit(vin[6]):
+		info.ModelYears = []int{year}
+	default:
+		info.ModelYears = []int{year + 30}
+	}
+	return info, nil
+}
+
+// ModelYear returns the latest candidate model year not after maxYear, or 0
+// when every candidate is later.
+func (i Info) ModelYear(maxYear int) int {
+	latest := 0
+	for _, year := range i.ModelYears {
+		if year <= maxYear {
+			latest = year
+		}
+	}
+	return latest
+}
+
+// HasModelYear reports whether year is one of the candidate model years.
+func (i Info) HasModelYear(year int) bool {
+	for _, candidate := range i.ModelYears {
+		if candidate == year {
+			return true
+		}
+	}
+	return false
+}
+
+// isNorthAmerican reports whether the VIN follows the US rule that a digit in
+// the 7th position places the model year between 1980 and 2009, and a letter
+// between 2010 and 2039.
+func isNorthAmerican(vin string) bool {
+	return vin[0] >= '1' && vin[0] <= '5'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func mustLoadManufacturers(table string) map[string]manufacturer {
+	records, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("vin: reading the WMI table: %v", err))
+	}
+
+	byWMI := make(map[string]manufacturer, len(records))
+	for _, record := range records[1:] {
+		byWMI[record[0]] = manufacturer{name: record[1], brand: record[2]}
+	}
+	return byWMI
+}
@@ -0,0 +1,60 @@
+package vin_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/vin"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		vin       string
+		brand     string
+		years     []int
+		modelYear int
+	}{
+		{vin: "9BWZZZ377VT004251", brand: "Volkswagen", years: []int{1997, 2027}, modelYear: 1997},
+		{vin: "9BGKS48U0LB123456", brand: "Chevrolet", years: []int{1990, 2020}, modelYear: 2020},
+		{vin: "1M8GDM9AXKP042788", brand: "MCI", years: []int{1989}, modelYear: 1989},
+		{vin: "5YJ3E1EA7LF000001", brand: "Tesla", years: []int{2020}, modelYear: 2020},
+		{vin: "XTA21099043576182", brand: "", years: []int{2004, 2034}, modelYear: 2004},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.vin, func(t *testing.T) {
+			info, err := vin.Decode(tt.vin)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if info.Brand != tt.brand || info.WMI != tt.vin[:3] {
+				t.Errorf("got brand %q and WMI %q, want %q and %q", info.Brand, info.WMI, tt.brand, tt.vin[:3])
+			}
+			if !reflect.DeepEqual(info.ModelYears, tt.years) {
+				t.Errorf("got model years %v, want %v", info.ModelYears, tt.years)
+			}
+			if got := info.ModelYear(2021); got != tt.modelYear {
+				t.Errorf("got model year %d, want %d", got, tt.modelYear)
+			}
+		})
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	for _, input := range []string{"", "9BWZZZ377VT00425", "9BWZZZ377UT004251", "9BWZZZ3770T004251"} {
+		if _, err := vin.Decode(input); !errors.Is(err, vin.ErrInvalidVIN) {
+			t.Errorf("decoding %q: expected ErrInvalidVIN, got %v", input, err)
+		}
+	}
+}
+
+func TestInfo_HasModelYear(t *testing.T) {
+	info, _ := vin.Decode("9BWZZZ377VT004251")
+	if !info.HasModelYear(1997) || !info.HasModelYear(2027) || info.HasModelYear(1998) {
+		t.Errorf("unexpected model year matches for %v", info.ModelYears)
+	}
+	if got := info.ModelYear(1990); got != 0 {
+		t.Errorf("expected no model year before 1997, got %d", got)
+	}
+}
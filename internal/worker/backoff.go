@@ -0,0 +1,15 @@
+package worker
+
+import "time"
+
+// backoff doubles base for every previous attempt, capped at max.
+func backoff(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 0; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+)
+
+const (
+	defaultPollInterval  = 2 * time.Second
+	defaultBatchSize     = 50
+	defaultBaseBackoff   = time.Second
+	defaultMaxBackoff    = 5 * time.Minute
+	defaultLeaseDuration = 5 * time.Minute
+	defaultMaxAttempts   = 20
+)
+
+type OutboxDispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// LeaseDuration is how long a claimed batch is reserved for this
+	// dispatcher. It should cover the delivery of a whole batch; events still
+	// unmarked when it expires are claimed and delivered again.
+	LeaseDuration time.Duration
+	// MaxAttempts is how many deliveries of an event are tried before it is
+	// abandoned as a dead letter.
+	MaxAttempts int
+}
+
+// OutboxDispatcher delivers pending outbox events through the publisher of
+// their target. Failed deliveries are retried with exponential backoff until
+// MaxAttempts is reached. Events that fail for good, because a receiver rejected them or the
+// attempts ran out, are left in the outbox as dead letters so the later events
+// of the same vehicle can go out.
+type OutboxDispatcher struct {
+	outbox     repository.OutboxRepository
+	transactor repository.Transactor
+	publishers map[string]usecase.EventPublisher
+	config     OutboxDispatcherConfig
+}
+
+// NewOutboxDispatcher delivers the events of each target named in publishers
+// through its publisher. Events of other targets are abandoned.
+func NewOutboxDispatcher(outbox repository.OutboxRepository, transactor repository.Transactor, publishers map[string]usecase.EventPublisher, config OutboxDispatcherConfig) *OutboxDispatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaultBaseBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = defaultLeaseDuration
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+
+	return &OutboxDispatcher{
+		outbox:     outbox,
+		transactor: transactor,
+		publishers: publishers,
+		config:     config,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := d.DispatchPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Warning: outbox dispatch failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers one batch of due events and returns how many of
+// them were published. The batch is claimed in a transaction of its own, so no
+// transaction or row lock is held while the publisher is called, and each
+// outcome is committed as soon as it is known.
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) (int, error) {
+	var events []*domain.OutboxEvent
+	err := d.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		events, err = d.outbox.ClaimPending(ctx, now, now.Add(d.config.LeaseDuration), d.config.BatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, event := range events {
+		deliveryErr := d.deliver(ctx, event)
+		dead := deliveryErr != nil && (errors.Is(deliveryErr, domain.ErrRejected) || event.Attempts+1 >= d.config.MaxAttempts)
+		err = d.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			switch {
+			case dead:
+				return d.outbox.MarkDead(ctx, event.ID, deliveryErr.Error(), time.Now())
+			case deliveryErr != nil:
+				delay := backoff(d.config.BaseBackoff, d.config.MaxBackoff, event.Attempts)
+				return d.outbox.MarkFailed(ctx, event.ID, deliveryErr.Error(), time.Now().Add(delay))
+			default:
+				return d.outbox.MarkSent(ctx, event.ID, time.Now())
+			}
+		})
+		if err != nil {
+			return sent, err
+		}
+
+		if dead {
+			log.Printf("Error: gave up delivering outbox event %s (%s) for vehicle %s to %s after %d attempts: %v", event.ID, event.Type, event.AggregateID, event.Target, event.Attempts+1, deliveryErr)
+			continue
+		}
+		if deliveryErr != nil {
+			log.Printf("Warning: failed to deliver outbox event %s (%s) for vehicle %s to %s: %v", event.ID, event.Type, event.AggregateID, event.Target, deliveryErr)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (d *OutboxDispatcher) deliver(ctx context.Context, outboxEvent *domain.OutboxEvent) error {
+	publisher, ok := d.publishers[outboxEvent.Target]
+	if !ok {
+		return fmt.Errorf("%w: no publisher for target %q", domain.ErrRejected, outboxEvent.Target)
+	}
+
+	var event domain.Event
+	if err := json.Unmarshal(outboxEvent.Payload, &event); err != nil {
+		return fmt.Errorf("%w: decoding event envelope: %v", domain.ErrRejected, err)
+	}
+
+	return publisher.Publish(ctx, event)
+}
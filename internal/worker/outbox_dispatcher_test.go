@@ -0,0 +1,248 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/usecase"
+	musecase "github.com/NicolasNSC/catalog-service-fiap/internal/usecase/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OutboxDispatcherSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	outbox     *mocks.MockOutboxRepository
+	transactor *mocks.MockTransactor
+	publisher  *musecase.MockEventPublisher
+	dispatcher *worker.OutboxDispatcher
+}
+
+func (suite *OutboxDispatcherSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.outbox = mocks.NewMockOutboxRepository(ctrl)
+	suite.transactor = mocks.NewMockTransactor(ctrl)
+	suite.publisher = musecase.NewMockEventPublisher(ctrl)
+
+	suite.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	suite.dispatcher = worker.NewOutboxDispatcher(suite.outbox, suite.transactor, map[string]usecase.EventPublisher{"showcase": suite.publisher}, worker.OutboxDispatcherConfig{
+		BatchSize:   10,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+		MaxAttempts: 30,
+	})
+}
+
+func Test_OutboxDispatcherSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(OutboxDispatcherSuite))
+}
+
+// envelope encodes an event the way the outbox publisher stores it.
+func envelope(id string, eventType domain.EventType) []byte {
+	payload, _ := json.Marshal(domain.Event{
+		ID:          id,
+		Type:        eventType,
+		Version:     domain.EventSchemaVersion,
+		AggregateID: "v-" + id,
+		Payload:     json.RawMessage(`{}`),
+	})
+	return payload
+}
+
+// scheduledWithin matches a next-attempt time roughly delay from now.
+func scheduledWithin(delay time.Duration) gomock.Matcher {
+	start := time.Now()
+	return gomock.Cond(func(next time.Time) bool {
+		return !next.Before(start.Add(delay)) && next.Before(time.Now().Add(delay+time.Second))
+	})
+}
+
+func (suite *OutboxDispatcherSuite) Test_DispatchPending() {
+	suite.T().Run("should publish the stored envelopes and mark them sent", func(t *testing.T) {
+		events := []*domain.OutboxEvent{
+			{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleCreated, Payload: envelope("evt-1", domain.EventVehicleCreated)},
+			{ID: "evt-2", Target: "showcase", Type: domain.EventVehicleSold, Payload: envelope("evt-2", domain.EventVehicleSold)},
+		}
+
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		for _, event := range events {
+			suite.publisher.EXPECT().
+				Publish(suite.ctx, gomock.Cond(func(e domain.Event) bool {
+					return e.ID == event.ID && e.Type == event.Type && e.AggregateID == "v-"+event.ID
+				})).
+				Return(nil)
+			suite.outbox.EXPECT().MarkSent(suite.ctx, event.ID, gomock.Any()).Return(nil)
+		}
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(2, sent)
+	})
+
+	suite.T().Run("should deliver outside of the claim and mark transactions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		outbox := mocks.NewMockOutboxRepository(ctrl)
+		transactor := mocks.NewMockTransactor(ctrl)
+		publisher := musecase.NewMockEventPublisher(ctrl)
+
+		inTransaction, transactions := false, 0
+		transactor.EXPECT().
+			WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				inTransaction = true
+				transactions++
+				defer func() { inTransaction = false }()
+				return fn(ctx)
+			}).
+			AnyTimes()
+
+		events := []*domain.OutboxEvent{
+			{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleCreated, Payload: envelope("evt-1", domain.EventVehicleCreated)},
+			{ID: "evt-2", Target: "showcase", Type: domain.EventVehicleSold, Payload: envelope("evt-2", domain.EventVehicleSold)},
+		}
+		outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), scheduledWithin(time.Minute), 10).Return(events, nil)
+		publisher.EXPECT().
+			Publish(suite.ctx, gomock.Any()).
+			DoAndReturn(func(context.Context, domain.Event) error {
+				if inTransaction {
+					t.Error("expected the event to be delivered outside of a transaction")
+				}
+				return nil
+			}).
+			Times(2)
+		outbox.EXPECT().MarkSent(suite.ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		dispatcher := worker.NewOutboxDispatcher(outbox, transactor, map[string]usecase.EventPublisher{"showcase": publisher}, worker.OutboxDispatcherConfig{BatchSize: 10, LeaseDuration: time.Minute})
+		sent, err := dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(2, sent)
+		suite.Equal(3, transactions)
+	})
+
+	suite.T().Run("should keep failed events pending with exponential backoff", func(t *testing.T) {
+		events := []*domain.OutboxEvent{
+			{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleDeleted, Payload: envelope("evt-1", domain.EventVehicleDeleted), Attempts: 0},
+			{ID: "evt-2", Target: "showcase", Type: domain.EventVehicleDeleted, Payload: envelope("evt-2", domain.EventVehicleDeleted), Attempts: 3},
+			{ID: "evt-3", Target: "showcase", Type: domain.EventVehicleDeleted, Payload: envelope("evt-3", domain.EventVehicleDeleted), Attempts: 20},
+		}
+
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError).Times(3)
+		suite.outbox.EXPECT().MarkFailed(suite.ctx, "evt-1", assert.AnError.Error(), scheduledWithin(time.Second)).Return(nil)
+		suite.outbox.EXPECT().MarkFailed(suite.ctx, "evt-2", assert.AnError.Error(), scheduledWithin(8*time.Second)).Return(nil)
+		suite.outbox.EXPECT().MarkFailed(suite.ctx, "evt-3", assert.AnError.Error(), scheduledWithin(time.Minute)).Return(nil)
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, sent)
+	})
+
+	suite.T().Run("should abandon events the receiver rejects without retrying them", func(t *testing.T) {
+		events := []*domain.OutboxEvent{{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleUpdated, Payload: envelope("evt-1", domain.EventVehicleUpdated)}}
+
+		rejected := fmt.Errorf("%w: listing not found", domain.ErrRejected)
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(rejected)
+		suite.outbox.EXPECT().MarkDead(suite.ctx, "evt-1", rejected.Error(), gomock.Any()).Return(nil)
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, sent)
+	})
+
+	suite.T().Run("should abandon events once the attempts run out", func(t *testing.T) {
+		events := []*domain.OutboxEvent{{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleUpdated, Payload: envelope("evt-1", domain.EventVehicleUpdated), Attempts: 29}}
+
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(assert.AnError)
+		suite.outbox.EXPECT().MarkDead(suite.ctx, "evt-1", assert.AnError.Error(), gomock.Any()).Return(nil)
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, sent)
+	})
+
+	suite.T().Run("should abandon undecodable envelopes", func(t *testing.T) {
+		events := []*domain.OutboxEvent{{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleCreated, Payload: []byte(`not json`)}}
+
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		suite.outbox.EXPECT().MarkDead(suite.ctx, "evt-1", gomock.Any(), gomock.Any()).Return(nil)
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, sent)
+	})
+
+	suite.T().Run("should abandon events of unknown targets", func(t *testing.T) {
+		events := []*domain.OutboxEvent{{ID: "evt-1", Target: "webhook:https://gone.example.com", Type: domain.EventVehicleCreated, Payload: envelope("evt-1", domain.EventVehicleCreated)}}
+
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		suite.outbox.EXPECT().
+			MarkDead(suite.ctx, "evt-1", `request rejected: no publisher for target "webhook:https://gone.example.com"`, gomock.Any()).
+			Return(nil)
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, sent)
+	})
+
+	suite.T().Run("should return error when claiming fails", func(t *testing.T) {
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(nil, assert.AnError)
+
+		sent, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.ErrorIs(err, assert.AnError)
+		suite.Equal(0, sent)
+	})
+
+	suite.T().Run("should return error when marking sent fails", func(t *testing.T) {
+		events := []*domain.OutboxEvent{{ID: "evt-1", Target: "showcase", Type: domain.EventVehicleDeleted, Payload: envelope("evt-1", domain.EventVehicleDeleted)}}
+
+		suite.outbox.EXPECT().ClaimPending(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(events, nil)
+		suite.publisher.EXPECT().Publish(suite.ctx, gomock.Any()).Return(nil)
+		suite.outbox.EXPECT().MarkSent(suite.ctx, "evt-1", gomock.Any()).Return(assert.AnError)
+
+		_, err := suite.dispatcher.DispatchPending(suite.ctx)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *OutboxDispatcherSuite) Test_Run() {
+	suite.T().Run("should stop polling when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(suite.ctx)
+		suite.outbox.EXPECT().ClaimPending(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+			DoAndReturn(func(context.Context, time.Time, time.Time, int) ([]*domain.OutboxEvent, error) {
+				cancel()
+				return nil, nil
+			})
+
+		done := make(chan struct{})
+		go func() {
+			suite.dispatcher.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("dispatcher did not stop after cancellation")
+		}
+	})
+}
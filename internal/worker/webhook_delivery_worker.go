@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+)
+
+const (
+	defaultWebhookTimeout     = 5 * time.Second
+	defaultWebhookMaxAttempts = 10
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA256 of the request body,
+// keyed with the subscription secret and prefixed with "sha256=".
+const SignatureHeader = "X-Signature"
+
+type WebhookDeliveryWorkerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Timeout      time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	MaxAttempts  int
+	// LeaseDuration is how long a claimed batch is reserved for this worker.
+	// It should cover the delivery of a whole batch; deliveries still unmarked
+	// when it expires are claimed and sent again.
+	LeaseDuration time.Duration
+}
+
+// WebhookDeliveryWorker sends the pending entries of the webhook delivery log.
+// Failed deliveries are retried with exponential backoff until MaxAttempts is
+// reached, after which they are marked FAILED and can only be redelivered
+// manually.
+type WebhookDeliveryWorker struct {
+	webhooks   repository.WebhookRepository
+	transactor repository.Transactor
+	client     *http.Client
+	config     WebhookDeliveryWorkerConfig
+}
+
+func NewWebhookDeliveryWorker(webhooks repository.WebhookRepository, transactor repository.Transactor, config WebhookDeliveryWorkerConfig) *WebhookDeliveryWorker {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultWebhookTimeout
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaultBaseBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = defaultLeaseDuration
+	}
+
+	return &WebhookDeliveryWorker{
+		webhooks:   webhooks,
+		transactor: transactor,
+		client:     &http.Client{Timeout: config.Timeout},
+		config:     config,
+	}
+}
+
+// Run polls the delivery log until ctx is cancelled.
+func (w *WebhookDeliveryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := w.DeliverPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Warning: webhook delivery failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverPending sends one batch of due deliveries and returns how many of
+// them succeeded. The batch is claimed in a transaction of its own, so no
+// transaction or row lock is held while the receivers are called, and each
+// outcome is committed as soon as it is known.
+func (w *WebhookDeliveryWorker) DeliverPending(ctx context.Context) (int, error) {
+	var pending []*repository.PendingWebhookDelivery
+	err := w.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		pending, err = w.webhooks.ClaimPendingDeliveries(ctx, now, now.Add(w.config.LeaseDuration), w.config.BatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, p := range pending {
+		delivery := p.Delivery
+		delivery.Attempts++
+
+		var sendErr error
+		delivery.ResponseStatus, sendErr = w.send(ctx, delivery, p.Secret)
+		if sendErr != nil {
+			log.Printf("Warning: failed to deliver webhook %s (%s) to %s: %v", delivery.ID, delivery.EventType, delivery.URL, sendErr)
+			delivery.LastError = sendErr.Error()
+			if delivery.Attempts >= w.config.MaxAttempts {
+				delivery.Status = domain.DeliveryFailed
+			} else {
+				delivery.NextAttemptAt = time.Now().Add(backoff(w.config.BaseBackoff, w.config.MaxBackoff, delivery.Attempts-1))
+			}
+		} else {
+			now := time.Now()
+			delivery.Status = domain.DeliverySucceeded
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+		}
+
+		err = w.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return w.webhooks.UpdateDelivery(ctx, delivery)
+		})
+		if err != nil {
+			return delivered, err
+		}
+		if sendErr == nil {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// send POSTs the stored envelope and returns the response status, if any.
+func (w *WebhookDeliveryWorker) send(ctx context.Context, delivery *domain.WebhookDelivery, secret string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", delivery.EventID)
+	req.Header.Set("X-Event-Type", string(delivery.EventType))
+	req.Header.Set("X-Delivery-ID", delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(secret, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned non-success status: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the X-Signature value of payload for secret. Receivers should
+// compute it over the raw request body and compare in constant time.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,199 @@
+package worker_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NicolasNSC/catalog-service-fiap/internal/domain"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/repository/mocks"
+	"github.com/NicolasNSC/catalog-service-fiap/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type WebhookDeliveryWorkerSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	webhooks   *mocks.MockWebhookRepository
+	transactor *mocks.MockTransactor
+	worker     *worker.WebhookDeliveryWorker
+}
+
+func (suite *WebhookDeliveryWorkerSuite) BeforeTest(_, _ string) {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+	suite.ctx = context.Background()
+	suite.webhooks = mocks.NewMockWebhookRepository(ctrl)
+	suite.transactor = mocks.NewMockTransactor(ctrl)
+
+	suite.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	suite.worker = worker.NewWebhookDeliveryWorker(suite.webhooks, suite.transactor, worker.WebhookDeliveryWorkerConfig{
+		BatchSize:   10,
+		Timeout:     time.Second,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+		MaxAttempts: 3,
+	})
+}
+
+func Test_WebhookDeliveryWorkerSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(WebhookDeliveryWorkerSuite))
+}
+
+func pendingDelivery(url string, attempts int) *repository.PendingWebhookDelivery {
+	return &repository.PendingWebhookDelivery{
+		Delivery: &domain.WebhookDelivery{
+			ID:             "dlv-1",
+			SubscriptionID: "wh-1",
+			EventID:        "evt-1",
+			EventType:      domain.EventVehicleSold,
+			URL:            url,
+			Payload:        []byte(`{"id":"evt-1","type":"vehicle.sold"}`),
+			Status:         domain.DeliveryPending,
+			Attempts:       attempts,
+		},
+		Secret: "0123456789abcdef",
+	}
+}
+
+func (suite *WebhookDeliveryWorkerSuite) Test_DeliverPending() {
+	suite.T().Run("should sign the payload and mark the delivery as succeeded", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		pending := pendingDelivery(server.URL, 0)
+		suite.webhooks.EXPECT().ClaimPendingDeliveries(suite.ctx, gomock.Any(), gomock.Any(), 10).
+			Return([]*repository.PendingWebhookDelivery{pending}, nil)
+		suite.webhooks.EXPECT().
+			UpdateDelivery(suite.ctx, gomock.Cond(func(d *domain.WebhookDelivery) bool {
+				return d.Status == domain.DeliverySucceeded && d.Attempts == 1 && d.ResponseStatus == http.StatusNoContent && d.DeliveredAt != nil
+			})).
+			Return(nil)
+
+		delivered, err := suite.worker.DeliverPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(1, delivered)
+
+		suite.Equal(pending.Delivery.Payload, body)
+		suite.Equal(worker.Sign("0123456789abcdef", body), received.Header.Get(worker.SignatureHeader))
+		suite.Equal("evt-1", received.Header.Get("X-Event-ID"))
+		suite.Equal("vehicle.sold", received.Header.Get("X-Event-Type"))
+		suite.Equal("dlv-1", received.Header.Get("X-Delivery-ID"))
+	})
+
+	suite.T().Run("should send outside of the claim and update transactions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		webhooks := mocks.NewMockWebhookRepository(ctrl)
+		transactor := mocks.NewMockTransactor(ctrl)
+
+		inTransaction, transactions := false, 0
+		transactor.EXPECT().
+			WithinTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				inTransaction = true
+				transactions++
+				defer func() { inTransaction = false }()
+				return fn(ctx)
+			}).
+			AnyTimes()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if inTransaction {
+				t.Error("expected the webhook to be sent outside of a transaction")
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		webhooks.EXPECT().ClaimPendingDeliveries(suite.ctx, gomock.Any(), scheduledWithin(time.Minute), 10).
+			Return([]*repository.PendingWebhookDelivery{pendingDelivery(server.URL, 0), pendingDelivery(server.URL, 0)}, nil)
+		webhooks.EXPECT().UpdateDelivery(suite.ctx, gomock.Any()).Return(nil).Times(2)
+
+		w := worker.NewWebhookDeliveryWorker(webhooks, transactor, worker.WebhookDeliveryWorkerConfig{BatchSize: 10, LeaseDuration: time.Minute})
+		delivered, err := w.DeliverPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(2, delivered)
+		suite.Equal(3, transactions)
+	})
+
+	suite.T().Run("should schedule a retry with backoff when the receiver fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		start := time.Now()
+		suite.webhooks.EXPECT().ClaimPendingDeliveries(suite.ctx, gomock.Any(), gomock.Any(), 10).
+			Return([]*repository.PendingWebhookDelivery{pendingDelivery(server.URL, 1)}, nil)
+		suite.webhooks.EXPECT().
+			UpdateDelivery(suite.ctx, gomock.Cond(func(d *domain.WebhookDelivery) bool {
+				return d.Status == domain.DeliveryPending && d.Attempts == 2 && d.ResponseStatus == http.StatusBadGateway &&
+					d.LastError != "" && !d.NextAttemptAt.Before(start.Add(2*time.Second))
+			})).
+			Return(nil)
+
+		delivered, err := suite.worker.DeliverPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, delivered)
+	})
+
+	suite.T().Run("should give up after the maximum number of attempts", func(t *testing.T) {
+		suite.webhooks.EXPECT().ClaimPendingDeliveries(suite.ctx, gomock.Any(), gomock.Any(), 10).
+			Return([]*repository.PendingWebhookDelivery{pendingDelivery("http://127.0.0.1:1", 2)}, nil)
+		suite.webhooks.EXPECT().
+			UpdateDelivery(suite.ctx, gomock.Cond(func(d *domain.WebhookDelivery) bool {
+				return d.Status == domain.DeliveryFailed && d.Attempts == 3 && d.ResponseStatus == 0 && d.LastError != ""
+			})).
+			Return(nil)
+
+		delivered, err := suite.worker.DeliverPending(suite.ctx)
+		suite.NoError(err)
+		suite.Equal(0, delivered)
+	})
+
+	suite.T().Run("should return error when claiming fails", func(t *testing.T) {
+		suite.webhooks.EXPECT().ClaimPendingDeliveries(suite.ctx, gomock.Any(), gomock.Any(), 10).Return(nil, assert.AnError)
+
+		_, err := suite.worker.DeliverPending(suite.ctx)
+		suite.ErrorIs(err, assert.AnError)
+	})
+
+	suite.T().Run("should return error when recording the outcome fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		suite.webhooks.EXPECT().ClaimPendingDeliveries(suite.ctx, gomock.Any(), gomock.Any(), 10).
+			Return([]*repository.PendingWebhookDelivery{pendingDelivery(server.URL, 0)}, nil)
+		suite.webhooks.EXPECT().UpdateDelivery(suite.ctx, gomock.Any()).Return(assert.AnError)
+
+		_, err := suite.worker.DeliverPending(suite.ctx)
+		suite.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (suite *WebhookDeliveryWorkerSuite) Test_Sign() {
+	suite.T().Run("should produce the HMAC-SHA256 of the payload", func(t *testing.T) {
+		// Reference value computed with: printf '{}' | openssl dgst -sha256 -hmac secret
+		suite.Equal("sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13", worker.Sign("secret", []byte(`{}`)))
+	})
+}